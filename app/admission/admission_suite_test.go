@@ -0,0 +1,434 @@
+package admission_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/education-hub/BE/app/admission"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/pkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAdmission(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Admission Suite")
+}
+
+var _ = Describe("admission", func() {
+	Context("Validasi Perpindahan Status", func() {
+		When("Status Tidak Bisa Dipilih Admin", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := admission.DefaultPipeline.Validate(admission.SendDetailCostsRegistration, admission.DonePayment, true)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Status Not Available"))
+			})
+		})
+		When("Pendaftaran Sudah Selesai", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := admission.DefaultPipeline.Validate(admission.Finish, admission.FileApproved, true)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Admission has already been closed"))
+			})
+		})
+		When("Status Sebelumnya Tidak Sesuai", func() {
+			It("Akan Mengembalikan Alasan Penolakan", func() {
+				_, err := admission.DefaultPipeline.Validate(admission.CheckFileRegistration, admission.SendTestLink, true)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Participant has not paid the registration fee"))
+			})
+		})
+		When("Perpindahan Dari Sistem Tidak Sesuai", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := admission.DefaultPipeline.Validate(admission.CheckFileRegistration, admission.DonePayment, false)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Perpindahan Valid", func() {
+			It("Akan Mengembalikan Transisi", func() {
+				res, err := admission.DefaultPipeline.Validate(admission.AlreadyPaidHerRegistration, admission.Finish, true)
+				Expect(err).Should(BeNil())
+				Expect(res.Label).To(Equal(string(admission.Finish)))
+			})
+		})
+		When("Pembayaran Dibatalkan", func() {
+			It("Akan Mengembalikan Label Gagal Bayar", func() {
+				res, err := admission.DefaultPipeline.Revert(admission.SendDetailCostsRegistration)
+				Expect(err).Should(BeNil())
+				Expect(res.To).To(Equal(admission.FileApproved))
+				Expect(res.Label).To(Equal("Failed Done Payment"))
+			})
+		})
+		When("Periode Pendaftaran Gratis", func() {
+			It("Akan Langsung Lunas Dan Bisa Lanjut Ke Tes", func() {
+				fee := 0
+				transition, err := admission.DefaultPipeline.Validate(admission.FileApproved, admission.SendDetailCostsRegistration, true)
+				Expect(err).Should(BeNil())
+				res := transition.Waive(&entity.AdmissionPeriod{RegistrationFee: &fee})
+				Expect(res.To).To(Equal(admission.DonePayment))
+				next, err := admission.DefaultPipeline.Validate(res.To, admission.SendTestLink, true)
+				Expect(err).Should(BeNil())
+				Expect(next.To).To(Equal(admission.SendTestLink))
+			})
+			It("Periode Berbayar Tetap Menunggu Pembayaran", func() {
+				fee := 150000
+				transition, _ := admission.DefaultPipeline.Validate(admission.FileApproved, admission.SendDetailCostsRegistration, true)
+				Expect(transition.Waive(&entity.AdmissionPeriod{RegistrationFee: &fee}).To).To(Equal(admission.SendDetailCostsRegistration))
+				Expect(transition.Waive(&entity.AdmissionPeriod{}).To).To(Equal(admission.SendDetailCostsRegistration))
+			})
+		})
+	})
+	Context("Pipeline Sekolah", func() {
+		When("Tahapan Tidak Dikenal", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := admission.NewPipeline([]string{"test", "dance"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Tahapan Duplikat", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := admission.NewPipeline([]string{"test", "test"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Tanpa Tes Dan Dengan Wawancara", func() {
+			var pipeline admission.Pipeline
+			BeforeEach(func() {
+				pipeline, _ = admission.NewPipeline([]string{"interview", "herregistration_payment"})
+			})
+			It("Akan Melewati Tahapan Tes", func() {
+				_, err := pipeline.Validate(admission.FileApproved, admission.SendTestLink, true)
+				Expect(err).ShouldNot(BeNil())
+				res, err := pipeline.Validate(admission.FileApproved, admission.InterviewScheduled, true)
+				Expect(err).Should(BeNil())
+				Expect(res.To).To(Equal(admission.InterviewScheduled))
+			})
+			It("Akan Kembali Ke Hasil Wawancara Saat Pembayaran Batal", func() {
+				res, err := pipeline.Revert(admission.SendDetailCostsHerRegistration)
+				Expect(err).Should(BeNil())
+				Expect(res.To).To(Equal(admission.InterviewPassed))
+			})
+			It("Akan Mengembalikan Status Berurutan", func() {
+				Expect(pipeline.Statuses()).To(Equal([]admission.Status{admission.CheckFileRegistration, admission.FileApproved, admission.InterviewScheduled, admission.InterviewPassed, admission.SendDetailCostsHerRegistration, admission.AlreadyPaidHerRegistration, admission.Finish}))
+			})
+		})
+		When("Pipeline Belum Diatur", func() {
+			It("Akan Memakai Pipeline Bawaan", func() {
+				pipeline, err := admission.PipelineFromSteps(nil)
+				Expect(err).Should(BeNil())
+				Expect(pipeline).To(Equal(admission.DefaultPipeline))
+			})
+		})
+	})
+	Context("Alasan Penolakan", func() {
+		When("Alasan Tidak Dikenal", func() {
+			It("Akan Memakai Label Bawaan", func() {
+				Expect(admission.RejectionReason("lainnya").Valid()).To(BeFalse())
+				Expect(admission.RejectionReason("lainnya").Label()).To(Equal("Berkas Pendaftaran Ditolak"))
+			})
+		})
+		When("Status Penolakan", func() {
+			It("Akan Dikenali Sebagai Penolakan", func() {
+				Expect(admission.IsRejection(admission.FailedFileApproved)).To(BeTrue())
+				Expect(admission.IsRejection(admission.FailedTestResult)).To(BeFalse())
+			})
+		})
+	})
+	Context("Revisi Berkas", func() {
+		When("Field Revisi Kosong", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := admission.JoinRevision(nil)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Field Revisi Tidak Dikenal", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := admission.JoinRevision([]string{"student_photo", "password"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Field Revisi Valid", func() {
+			It("Akan Disimpan Dan Dibaca Kembali", func() {
+				res, err := admission.JoinRevision([]string{"student_photo", "parent_signature"})
+				Expect(err).Should(BeNil())
+				Expect(admission.SplitRevision(res)).To(Equal([]string{"student_photo", "parent_signature"}))
+			})
+		})
+		When("Siswa Mengirim Ulang Berkas", func() {
+			It("Akan Kembali Ke Pemeriksaan Berkas", func() {
+				_, err := admission.DefaultPipeline.Validate(admission.CheckFileRegistration, admission.NeedsRevision, true)
+				Expect(err).Should(BeNil())
+				res, err := admission.DefaultPipeline.Validate(admission.NeedsRevision, admission.CheckFileRegistration, false)
+				Expect(err).Should(BeNil())
+				Expect(res.To).To(Equal(admission.CheckFileRegistration))
+			})
+		})
+	})
+	Context("Kuota Dan Daftar Tunggu", func() {
+		When("Pipeline Dengan Daftar Ulang", func() {
+			It("Akan Mengambil Kursi Saat Masuk Daftar Ulang", func() {
+				Expect(admission.DefaultPipeline.Seat()).To(Equal(admission.SendDetailCostsHerRegistration))
+				res, err := admission.DefaultPipeline.Validate(admission.TestResult, admission.SendDetailCostsHerRegistration, true)
+				Expect(err).Should(BeNil())
+				Expect(res.Seat).To(BeTrue())
+			})
+		})
+		When("Pipeline Tanpa Daftar Ulang", func() {
+			It("Akan Mengambil Kursi Saat Selesai", func() {
+				pipeline, _ := admission.NewPipeline([]string{"test"})
+				Expect(pipeline.Seat()).To(Equal(admission.Finish))
+				res, err := pipeline.Validate(admission.Waitlisted, admission.Finish, false)
+				Expect(err).Should(BeNil())
+				Expect(res.Seat).To(BeTrue())
+			})
+		})
+		When("Admin Memilih Daftar Tunggu", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := admission.DefaultPipeline.Validate(admission.TestResult, admission.Waitlisted, true)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Kursi Sekolah Penuh", func() {
+			It("Akan Dinyatakan Penuh", func() {
+				quotas := []entity.Quota{{Capacity: 100}, {Track: "zonasi", Capacity: 50}}
+				Expect(admission.Full(quotas, map[string]int{"": 100, "zonasi": 20}, "zonasi")).To(BeTrue())
+			})
+		})
+		When("Kursi Jalur Penuh", func() {
+			It("Hanya Jalur Tersebut Yang Penuh", func() {
+				quotas := []entity.Quota{{Capacity: 100}, {Track: "zonasi", Capacity: 50}}
+				Expect(admission.Full(quotas, map[string]int{"": 60, "zonasi": 50}, "zonasi")).To(BeTrue())
+				Expect(admission.Full(quotas, map[string]int{"": 60}, "prestasi")).To(BeFalse())
+			})
+		})
+		When("Sekolah Tidak Membatasi Kuota", func() {
+			It("Tidak Akan Pernah Penuh", func() {
+				Expect(admission.Full(nil, nil, "zonasi")).To(BeFalse())
+			})
+		})
+		When("Jalur Tidak Dibuka Sekolah", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				quotas := []entity.Quota{{Capacity: 100}, {Track: "zonasi", Capacity: 50}}
+				Expect(admission.CheckTrack(quotas, "afirmasi")).ShouldNot(BeNil())
+				Expect(admission.CheckTrack(quotas, "zonasi")).Should(BeNil())
+				Expect(admission.CheckTrack([]entity.Quota{{Capacity: 100}}, "")).Should(BeNil())
+			})
+		})
+	})
+	Context("Batas Waktu Tahapan", func() {
+		When("Peserta Belum Membayar", func() {
+			It("Akan Bisa Kedaluwarsa", func() {
+				res, err := admission.DefaultPipeline.Validate(admission.SendDetailCostsRegistration, admission.Expired, false)
+				Expect(err).Should(BeNil())
+				Expect(res.Manual).To(BeFalse())
+			})
+		})
+		When("Menunggu Keputusan Admin", func() {
+			It("Tidak Akan Kedaluwarsa", func() {
+				_, err := admission.DefaultPipeline.Validate(admission.CheckFileRegistration, admission.Expired, false)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Pendaftaran Kedaluwarsa", func() {
+			It("Akan Ditutup", func() {
+				Expect(admission.IsClosed(admission.Expired)).To(BeTrue())
+			})
+		})
+	})
+	Context("Peringkat Zonasi", func() {
+		school := pkg.Coordinate{Lat: -6.2, Lng: 106.8}
+		near, far, lng := -6.21, -6.3, 106.8
+		When("Jarak Peserta Berbeda", func() {
+			It("Akan Mengurutkan Dari Yang Terdekat", func() {
+				res := admission.RankByDistance(school, []entity.Applicant{
+					{ProgressID: 1, SubmissionID: 1, Latitude: &far, Longitude: &lng},
+					{ProgressID: 2, SubmissionID: 2, Latitude: &near, Longitude: &lng},
+				})
+				Expect(res[0].ProgressId).To(Equal(2))
+				Expect(*res[0].Distance).To(BeNumerically("~", 1.112, 0.001))
+				Expect(res[1].Rank).To(Equal(2))
+			})
+		})
+		When("Jarak Peserta Sama", func() {
+			It("Akan Mendahulukan Yang Mendaftar Lebih Awal", func() {
+				res := admission.RankByDistance(school, []entity.Applicant{
+					{ProgressID: 1, SubmissionID: 9, Latitude: &near, Longitude: &lng},
+					{ProgressID: 2, SubmissionID: 3, Latitude: &near, Longitude: &lng},
+				})
+				Expect(res[0].ProgressId).To(Equal(2))
+			})
+		})
+		When("Alamat Peserta Tidak Ditemukan", func() {
+			It("Akan Ditempatkan Paling Akhir", func() {
+				res := admission.RankByDistance(school, []entity.Applicant{
+					{ProgressID: 1, SubmissionID: 1},
+					{ProgressID: 2, SubmissionID: 2, Latitude: &far, Longitude: &lng},
+				})
+				Expect(res[0].ProgressId).To(Equal(2))
+				Expect(res[1].Distance).To(BeNil())
+			})
+		})
+		When("Alamat Terdaftar Di Geocoder Offline", func() {
+			It("Akan Mengembalikan Koordinat", func() {
+				geocoder := &pkg.OfflineGeocoder{Points: map[string]pkg.Coordinate{"jl. merdeka 1, jakarta": school}}
+				res, err := geocoder.Geocode(context.Background(), pkg.Address(" Jl. Merdeka 1", "", "Jakarta"))
+				Expect(err).Should(BeNil())
+				Expect(*res).To(Equal(school))
+				_, err = geocoder.Geocode(context.Background(), "Bandung")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+	})
+	Context("Seleksi Berbobot", func() {
+		When("Hasil Tes Dalam Berbagai Format", func() {
+			It("Akan Dibaca Sebagai Nilai Dari 100", func() {
+				res, ok := admission.ParseTestScore("8/10")
+				Expect(ok).To(BeTrue())
+				Expect(res).To(Equal(80.0))
+				res, _ = admission.ParseTestScore("75,5 %")
+				Expect(res).To(Equal(75.5))
+				_, ok = admission.ParseTestScore("-")
+				Expect(ok).To(BeFalse())
+			})
+		})
+		When("Kriteria Memiliki Bobot Berbeda", func() {
+			It("Akan Mengurutkan Dari Nilai Tertinggi", func() {
+				high, low, near, far := 90.0, 60.0, 1.0, 4.0
+				criteria := []entity.SelectionCriterion{{Criterion: "report_average", Weight: 30}, {Criterion: "distance", Weight: 70}}
+				res := admission.Score(criteria, []admission.Candidate{
+					{ProgressID: 1, SubmissionID: 1, ReportAverage: &high, Distance: &far},
+					{ProgressID: 2, SubmissionID: 2, ReportAverage: &low, Distance: &near},
+				})
+				Expect(res[0].ProgressId).To(Equal(2))
+				Expect(res[0].Score).To(Equal(70.5))
+				Expect(res[0].Scores["distance"]).To(Equal(75.0))
+				Expect(res[1].Score).To(Equal(27.0))
+			})
+		})
+		When("Nilai Peserta Sama", func() {
+			It("Akan Mendahulukan Yang Mendaftar Lebih Awal", func() {
+				criteria := []entity.SelectionCriterion{{Criterion: "achievements", Weight: 1}}
+				res := admission.Score(criteria, []admission.Candidate{
+					{ProgressID: 1, SubmissionID: 8, Achievements: 2},
+					{ProgressID: 2, SubmissionID: 5, Achievements: 2},
+				})
+				Expect(res[0].ProgressId).To(Equal(2))
+				Expect(res[0].Score).To(Equal(100.0))
+			})
+		})
+	})
+	Context("Banding", func() {
+		When("Progress Tidak Gagal", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				Expect(admission.IsAppealable(admission.FailedTestResult)).To(BeTrue())
+				_, err := admission.DefaultPipeline.Reopen(admission.Finish, admission.FileApproved)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Banding Berkas Diterima", func() {
+			It("Hanya Bisa Dibuka Sampai Berkas Disetujui", func() {
+				res, err := admission.DefaultPipeline.Reopen(admission.FailedFileApproved, admission.FileApproved)
+				Expect(err).Should(BeNil())
+				Expect(res.To).To(Equal(admission.FileApproved))
+				_, err = admission.DefaultPipeline.Reopen(admission.FailedFileApproved, admission.SendTestLink)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Banding Tes Diterima", func() {
+			It("Tidak Bisa Langsung Mengambil Kursi", func() {
+				res, err := admission.DefaultPipeline.Reopen(admission.FailedTestResult, admission.SendTestLink)
+				Expect(err).Should(BeNil())
+				Expect(res.Manual).To(BeTrue())
+				_, err = admission.DefaultPipeline.Reopen(admission.FailedTestResult, admission.SendDetailCostsHerRegistration)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+	})
+	Context("Penawaran Dan Pengunduran Diri", func() {
+		When("Sekolah Memberi Penawaran", func() {
+			It("Siswa Bisa Menerima Atau Menolak", func() {
+				_, err := admission.DefaultPipeline.Validate(admission.AlreadyPaidHerRegistration, admission.OfferMade, true)
+				Expect(err).Should(BeNil())
+				_, err = admission.DefaultPipeline.Validate(admission.OfferMade, admission.OfferAccepted, false)
+				Expect(err).Should(BeNil())
+				_, err = admission.DefaultPipeline.Validate(admission.OfferMade, admission.OfferDeclined, false)
+				Expect(err).Should(BeNil())
+				_, err = admission.DefaultPipeline.Validate(admission.OfferMade, admission.Finish, true)
+				Expect(err).ShouldNot(BeNil())
+				_, err = admission.DefaultPipeline.Validate(admission.OfferAccepted, admission.Finish, true)
+				Expect(err).Should(BeNil())
+			})
+		})
+		When("Pipeline Tanpa Daftar Ulang", func() {
+			It("Penawaran Akan Mengambil Kursi", func() {
+				pipeline, _ := admission.NewPipeline([]string{"test"})
+				res, err := pipeline.Validate(admission.TestResult, admission.OfferMade, true)
+				Expect(err).Should(BeNil())
+				Expect(res.Seat).To(BeTrue())
+			})
+		})
+		When("Siswa Mengundurkan Diri", func() {
+			It("Bisa Dari Setiap Status Yang Masih Berjalan", func() {
+				for _, status := range []admission.Status{admission.CheckFileRegistration, admission.Waitlisted, admission.SendTestLink, admission.OfferAccepted} {
+					_, err := admission.DefaultPipeline.Validate(status, admission.Withdrawn, false)
+					Expect(err).Should(BeNil())
+				}
+				_, err := admission.DefaultPipeline.Validate(admission.Finish, admission.Withdrawn, false)
+				Expect(err).ShouldNot(BeNil())
+				Expect(admission.DefaultPipeline.IsManual(admission.Withdrawn)).To(BeFalse())
+			})
+		})
+		When("Siswa Mendaftar Lagi Setelah Mengundurkan Diri", func() {
+			It("Progress Lama Tidak Menghalangi Pendaftaran", func() {
+				Expect(admission.Released).To(ContainElements(admission.Withdrawn, admission.OfferDeclined, admission.Expired, admission.FailedInterview))
+				Expect(admission.Released).NotTo(ContainElement(admission.Finish))
+				Expect(admission.Released).To(HaveLen(len(admission.Closed) - 1))
+			})
+		})
+	})
+	Context("Notifikasi Orang Tua", func() {
+		When("Email Siswa Diteruskan Ke Orang Tua", func() {
+			It("Akan Dikirim Ke Email Orang Tua Dengan Isi Yang Sama", func() {
+				res := admission.ParentMail(map[string]any{"email": "budi@mail.com", "name": "Budi Santoso", "school": "SMA Negeri 1"}, entity.User{Email: "ayah@mail.com", FirstName: "Slamet", SureName: "Santoso"})
+				Expect(string(res)).To(MatchJSON(`{"email": "ayah@mail.com", "name": "Budi Santoso", "school": "SMA Negeri 1", "parent": "Slamet Santoso"}`))
+			})
+		})
+	})
+	Context("Surat Penerimaan", func() {
+		letter := entity.AcceptanceLetter{
+			Reference:   "SK-20100001-2023-0A1B2C3D4E5F",
+			StudentName: "Budi Santoso",
+			NISN:        "0012345678",
+			SchoolName:  "SMA Negeri 1",
+			Npsn:        "20100001",
+			IssuedAt:    time.Date(2023, time.August, 17, 9, 0, 0, 0, time.UTC),
+		}
+		When("Nomor Surat Dibuat", func() {
+			It("Akan Unik Untuk Setiap Surat", func() {
+				first, err := admission.NewReference("20100001", letter.IssuedAt)
+				Expect(err).Should(BeNil())
+				second, _ := admission.NewReference("20100001", letter.IssuedAt)
+				Expect(first).To(MatchRegexp(`^SK-20100001-2023-[0-9A-F]{12}$`))
+				Expect(first).NotTo(Equal(second))
+			})
+		})
+		When("Template Memakai Placeholder", func() {
+			It("Akan Diisi Dengan Data Surat", func() {
+				res := admission.FillLetter("{student_name} ({nisn}) diterima di {school_name} pada {date}, nomor {reference}", letter)
+				Expect(res).To(Equal("Budi Santoso (0012345678) diterima di SMA Negeri 1 pada 17 Agustus 2023, nomor SK-20100001-2023-0A1B2C3D4E5F"))
+			})
+		})
+		When("Surat Dicetak", func() {
+			It("Akan Memuat Nomor Dan Alamat Verifikasi", func() {
+				res, err := admission.RenderLetter(admission.DefaultLetter, letter, &entity.School{City: "Bogor"}, nil, "https://ppdb.id/letters/")
+				Expect(err).Should(BeNil())
+				Expect(string(res[:8])).To(Equal("%PDF-1.4"))
+				Expect(string(res)).To(ContainSubstring("Budi Santoso dengan NISN 0012345678"))
+				Expect(string(res)).To(ContainSubstring("https://ppdb.id/letters/SK-20100001-2023-0A1B2C3D4E5F"))
+				Expect(string(res)).To(ContainSubstring("Bogor, 17 Agustus 2023"))
+			})
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package admission
+
+import (
+	"context"
+	"time"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+	"gorm.io/gorm"
+)
+
+const (
+	AppealPending  = "pending"
+	AppealAccepted = "accepted"
+	AppealDenied   = "denied"
+)
+
+// Decision is the answer of a school admin to an appeal, Status is where an accepted appeal reopens the progress.
+type Decision struct {
+	Actor  int
+	Accept bool
+	Status Status
+	// Reason is shown to the student and sent along with the notification.
+	Reason string
+}
+
+func (w *workflow) Appeal(ctx context.Context, appeal entity.Appeal) (*entity.Appeal, error) {
+	db := w.dep.Db.WithContext(ctx)
+	prog, err := w.store.GetProgressByid(db, int(appeal.ProgressID))
+	if err != nil {
+		return nil, err
+	}
+	if prog.UserID != appeal.UserID {
+		return nil, errorr.NewBad("Data Not Found")
+	}
+	if !IsAppealable(Status(prog.Status)) {
+		return nil, errorr.NewBad("Only a failed progress can be appealed")
+	}
+	exist, err := w.store.HasAppeal(db, int(prog.ID))
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errorr.NewBad("Progress has already been appealed")
+	}
+	appeal.SchoolID = prog.SchoolID
+	appeal.FromStatus = prog.Status
+	appeal.Status = AppealPending
+	res, err := w.store.CreateAppeal(db, appeal)
+	if err != nil {
+		return nil, err
+	}
+	w.flush(&outbox{admin: []any{map[string]any{"type": "appeal", "appeal_id": res.ID, "progress_id": prog.ID, "status": "Appeal Opened"}}})
+	return res, nil
+}
+
+func (w *workflow) DecideAppeal(ctx context.Context, schid int, id int, decision Decision) (*entity.Appeal, error) {
+	db := w.dep.Db.WithContext(ctx)
+	appeal, err := w.store.GetAppealById(db, id)
+	if err != nil {
+		return nil, err
+	}
+	if int(appeal.SchoolID) != schid {
+		return nil, errorr.NewBad("Data Not Found")
+	}
+	if appeal.Status != AppealPending {
+		return nil, errorr.NewBad("Appeal has already been decided")
+	}
+	prog, err := w.store.GetProgressByid(db, int(appeal.ProgressID))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	appeal.DecidedBy = uint(decision.Actor)
+	appeal.DecidedAt = &now
+	appeal.Response = decision.Reason
+	out := &outbox{}
+	if decision.Accept {
+		pipeline, err := w.Pipeline(ctx, schid)
+		if err != nil {
+			return nil, err
+		}
+		transition, err := pipeline.Reopen(Status(prog.Status), decision.Status)
+		if err != nil {
+			return nil, err
+		}
+		appeal.Status = AppealAccepted
+		appeal.ReopenStatus = string(decision.Status)
+		change := Change{Actor: decision.Actor, Status: decision.Status, Reason: "Appeal accepted", Note: decision.Reason, record: func(db *gorm.DB) error {
+			return w.store.DecideAppeal(db, *appeal)
+		}}
+		if _, err := w.move(ctx, prog, *transition, change, out); err != nil {
+			return nil, err
+		}
+	} else {
+		appeal.Status = AppealDenied
+		if err := w.store.DecideAppeal(db, *appeal); err != nil {
+			return nil, err
+		}
+	}
+	w.notifyAppeal(ctx, out, prog, appeal)
+	w.flush(out)
+	return appeal, nil
+}
+
+// notifyAppeal tells the student about the decision, a denied appeal is also sent by email
+// the same way as a rejection.
+func (w *workflow) notifyAppeal(ctx context.Context, out *outbox, prog *entity.Progress, appeal *entity.Appeal) {
+	user, err := w.users.GetById(w.dep.Db.WithContext(ctx), int(prog.UserID))
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN GETTING USER DATA: %v", err)
+		user = &entity.User{}
+	}
+	out.parents = w.parents(ctx, prog)
+	defer func() { out.parents = nil }()
+	out.toStudent(user, map[string]any{"type": "appeal", "status": appeal.Status, "appeal_id": appeal.ID, "progress_id": prog.ID})
+	if appeal.Status != AppealDenied {
+		return
+	}
+	school, err := w.store.GetById(w.dep.Db.WithContext(ctx), int(prog.SchoolID))
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN GETTING SCHOOL DATA: %v", err)
+		school = &entity.School{}
+	}
+	out.publish([]string{"13"}, map[string]any{"email": user.Email, "name": user.FirstName + " " + user.SureName, "school": school.Name, "reason": "Banding Ditolak", "reason_code": "appeal_denied", "note": appeal.Response})
+}
@@ -0,0 +1,190 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"strings"
+	"time"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/pkg"
+)
+
+// DefaultLetter is used by the schools that have not written their own acceptance letter.
+var DefaultLetter = entity.LetterTemplate{
+	Title:       "SURAT KETERANGAN DITERIMA",
+	Body:        "Kepala {school_name} dengan ini menerangkan bahwa {student_name} dengan NISN {nisn} telah dinyatakan DITERIMA sebagai peserta didik baru di {school_name} berdasarkan hasil seleksi penerimaan peserta didik baru.\n\nDemikian surat keterangan ini dibuat untuk dipergunakan sebagaimana mestinya.",
+	SignerTitle: "Kepala Sekolah",
+}
+
+var months = []string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+// FormatDate writes a date the way it is written on Indonesian letters, such as "17 Agustus 2023".
+func FormatDate(date time.Time) string {
+	return fmt.Sprintf("%d %s %d", date.Day(), months[date.Month()-1], date.Year())
+}
+
+// NewReference numbers a letter of a school, the random part makes it unique and impossible to guess.
+func NewReference(npsn string, date time.Time) (string, error) {
+	random := make([]byte, 6)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("SK-%s-%d-%s", npsn, date.Year(), strings.ToUpper(hex.EncodeToString(random))), nil
+}
+
+// FillLetter replaces the placeholders of a template text with the data of the letter.
+func FillLetter(text string, letter entity.AcceptanceLetter) string {
+	return strings.NewReplacer(
+		"{student_name}", letter.StudentName,
+		"{nisn}", letter.NISN,
+		"{school_name}", letter.SchoolName,
+		"{npsn}", letter.Npsn,
+		"{date}", FormatDate(letter.IssuedAt),
+		"{reference}", letter.Reference,
+	).Replace(text)
+}
+
+// RenderLetter lays out the acceptance letter under the letterhead of the school, the logo is left out when it is nil.
+func RenderLetter(tmpl entity.LetterTemplate, letter entity.AcceptanceLetter, school *entity.School, logo image.Image, verifyurl string) ([]byte, error) {
+	doc := pkg.NewPDF()
+	if logo != nil {
+		if err := doc.Image(logo, 50, 40, 60, 60); err != nil {
+			return nil, err
+		}
+	}
+	doc.Text(125, 45, 14, true, strings.ToUpper(letter.SchoolName))
+	doc.Text(125, 65, 10, false, "NPSN "+letter.Npsn)
+	doc.TextBox(125, 80, 420, 9, pkg.Address(school.Detail, school.Village, school.District, school.City, school.Province, school.ZipCode))
+	doc.Line(50, 110, pkg.PageWidth-50, 110)
+
+	doc.Text(50, 135, 13, true, FillLetter(tmpl.Title, letter))
+	doc.Text(50, 155, 10, false, "Nomor: "+letter.Reference)
+	y := 185.0
+	for _, paragraph := range strings.Split(FillLetter(tmpl.Body, letter), "\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			y += 8
+			continue
+		}
+		y = doc.TextBox(50, y, pkg.PageWidth-100, 11, paragraph)
+	}
+
+	y += 30
+	doc.Text(360, y, 11, false, fmt.Sprintf("%s, %s", school.City, FormatDate(letter.IssuedAt)))
+	doc.Text(360, y+16, 11, false, FillLetter(tmpl.SignerTitle, letter))
+	doc.Text(360, y+90, 11, true, FillLetter(tmpl.SignerName, letter))
+
+	verify := "Keaslian surat ini dapat diperiksa dengan nomor " + letter.Reference
+	if verifyurl != "" {
+		verify = "Keaslian surat ini dapat diperiksa di " + verifyurl + letter.Reference
+	}
+	doc.Line(50, pkg.PageHeight-60, pkg.PageWidth-50, pkg.PageHeight-60)
+	doc.Text(50, pkg.PageHeight-50, 8, false, verify)
+	return doc.Bytes(), nil
+}
+
+func (w *workflow) IssueLetter(ctx context.Context, prog *entity.Progress) (*entity.AcceptanceLetter, error) {
+	db := w.dep.Db.WithContext(ctx)
+	if Status(prog.Status) != Finish {
+		return nil, errorr.NewBad("Acceptance letter is not available yet")
+	}
+	letter, err := w.store.GetLetterByProgress(db, int(prog.ID))
+	if err == nil {
+		return letter, nil
+	}
+	if !missing(err) {
+		return nil, err
+	}
+	school, err := w.store.GetById(db, int(prog.SchoolID))
+	if err != nil {
+		return nil, err
+	}
+	subm, err := w.store.GetLastSubmission(db, int(prog.UserID), int(prog.SchoolID))
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := w.store.GetLetterTemplate(db, int(prog.SchoolID))
+	if missing(err) {
+		tmpl, err = &DefaultLetter, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	reference, err := NewReference(school.Npsn, now)
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN NUMBERING ACCEPTANCE LETTER: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	res := entity.AcceptanceLetter{
+		ProgressID:  prog.ID,
+		UserID:      prog.UserID,
+		SchoolID:    prog.SchoolID,
+		Reference:   reference,
+		StudentName: subm.StudentName,
+		NISN:        subm.NISN,
+		SchoolName:  school.Name,
+		Npsn:        school.Npsn,
+		File:        "Letter_" + reference + ".pdf",
+		IssuedAt:    now,
+	}
+	verifyurl := ""
+	if w.dep.Config != nil {
+		verifyurl = w.dep.Config.VerifyURL
+	}
+	file, err := RenderLetter(*tmpl, res, school, w.logo(school.Image), verifyurl)
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN RENDERING ACCEPTANCE LETTER: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	if err := w.dep.Storage.WriteFile(res.File, file); err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN STORING ACCEPTANCE LETTER: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	created, err := w.store.CreateLetter(db, res)
+	if err != nil {
+		// a concurrent request may have issued the letter in the meantime
+		if letter, err := w.store.GetLetterByProgress(db, int(prog.ID)); err == nil {
+			return letter, nil
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// logo loads the image of the school for the letterhead, the letter is still issued without it.
+func (w *workflow) logo(name string) image.Image {
+	if name == "" {
+		return nil
+	}
+	file, err := w.dep.Storage.ReadFile(name)
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN READING SCHOOL LOGO: %v", err)
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(file))
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN DECODING SCHOOL LOGO: %v", err)
+		return nil
+	}
+	return img
+}
+
+// enroll issues the acceptance letter of a finished progress and releases the other schools of the student.
+func (w *workflow) enroll(ctx context.Context, prog *entity.Progress, out *outbox) {
+	if _, err := w.IssueLetter(ctx, prog); err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN ISSUING ACCEPTANCE LETTER OF PROGRESS %d: %v", prog.ID, err)
+	}
+	w.releaseOthers(ctx, prog, out)
+}
+
+// missing reports whether the store did not find the data.
+func missing(err error) bool {
+	_, ok := err.(errorr.BadRequest)
+	return ok
+}
@@ -0,0 +1,315 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	admission "github.com/education-hub/BE/app/admission"
+
+	entities "github.com/education-hub/BE/app/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Workflow is an autogenerated mock type for the Workflow type
+type Workflow struct {
+	mock.Mock
+}
+
+// Appeal provides a mock function with given fields: ctx, appeal
+func (_m *Workflow) Appeal(ctx context.Context, appeal entities.Appeal) (*entities.Appeal, error) {
+	ret := _m.Called(ctx, appeal)
+
+	var r0 *entities.Appeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Appeal) (*entities.Appeal, error)); ok {
+		return rf(ctx, appeal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Appeal) *entities.Appeal); ok {
+		r0 = rf(ctx, appeal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Appeal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entities.Appeal) error); ok {
+		r1 = rf(ctx, appeal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecideAppeal provides a mock function with given fields: ctx, schid, id, decision
+func (_m *Workflow) DecideAppeal(ctx context.Context, schid int, id int, decision admission.Decision) (*entities.Appeal, error) {
+	ret := _m.Called(ctx, schid, id, decision)
+
+	var r0 *entities.Appeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, admission.Decision) (*entities.Appeal, error)); ok {
+		return rf(ctx, schid, id, decision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, admission.Decision) *entities.Appeal); ok {
+		r0 = rf(ctx, schid, id, decision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Appeal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, admission.Decision) error); ok {
+		r1 = rf(ctx, schid, id, decision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Expire provides a mock function with given fields: ctx, now
+func (_m *Workflow) Expire(ctx context.Context, now time.Time) []admission.Result {
+	ret := _m.Called(ctx, now)
+
+	var r0 []admission.Result
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []admission.Result); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]admission.Result)
+		}
+	}
+
+	return r0
+}
+
+// IssueLetter provides a mock function with given fields: ctx, prog
+func (_m *Workflow) IssueLetter(ctx context.Context, prog *entities.Progress) (*entities.AcceptanceLetter, error) {
+	ret := _m.Called(ctx, prog)
+
+	var r0 *entities.AcceptanceLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Progress) (*entities.AcceptanceLetter, error)); ok {
+		return rf(ctx, prog)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Progress) *entities.AcceptanceLetter); ok {
+		r0 = rf(ctx, prog)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.AcceptanceLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.Progress) error); ok {
+		r1 = rf(ctx, prog)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Pipeline provides a mock function with given fields: ctx, schid
+func (_m *Workflow) Pipeline(ctx context.Context, schid int) (admission.Pipeline, error) {
+	ret := _m.Called(ctx, schid)
+
+	var r0 admission.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (admission.Pipeline, error)); ok {
+		return rf(ctx, schid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) admission.Pipeline); ok {
+		r0 = rf(ctx, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(admission.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Promote provides a mock function with given fields: ctx, schid
+func (_m *Workflow) Promote(ctx context.Context, schid int) error {
+	ret := _m.Called(ctx, schid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, schid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Respond provides a mock function with given fields: ctx, uid, id, status, reason
+func (_m *Workflow) Respond(ctx context.Context, uid int, id int, status admission.Status, reason string) (*entities.Progress, error) {
+	ret := _m.Called(ctx, uid, id, status, reason)
+
+	var r0 *entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, admission.Status, string) (*entities.Progress, error)); ok {
+		return rf(ctx, uid, id, status, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, admission.Status, string) *entities.Progress); ok {
+		r0 = rf(ctx, uid, id, status, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, admission.Status, string) error); ok {
+		r1 = rf(ctx, uid, id, status, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResubmitProgressByUid provides a mock function with given fields: ctx, uid, schid
+func (_m *Workflow) ResubmitProgressByUid(ctx context.Context, uid int, schid int) (*entities.Progress, error) {
+	ret := _m.Called(ctx, uid, schid)
+
+	var r0 *entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*entities.Progress, error)); ok {
+		return rf(ctx, uid, schid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *entities.Progress); ok {
+		r0 = rf(ctx, uid, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, uid, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevertProgressByUid provides a mock function with given fields: ctx, uid, schid, reason
+func (_m *Workflow) RevertProgressByUid(ctx context.Context, uid int, schid int, reason string) (*entities.Progress, error) {
+	ret := _m.Called(ctx, uid, schid, reason)
+
+	var r0 *entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) (*entities.Progress, error)); ok {
+		return rf(ctx, uid, schid, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) *entities.Progress); ok {
+		r0 = rf(ctx, uid, schid, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string) error); ok {
+		r1 = rf(ctx, uid, schid, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProgress provides a mock function with given fields: ctx, id, change
+func (_m *Workflow) UpdateProgress(ctx context.Context, id int, change admission.Change) (*entities.Progress, error) {
+	ret := _m.Called(ctx, id, change)
+
+	var r0 *entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, admission.Change) (*entities.Progress, error)); ok {
+		return rf(ctx, id, change)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, admission.Change) *entities.Progress); ok {
+		r0 = rf(ctx, id, change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, admission.Change) error); ok {
+		r1 = rf(ctx, id, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProgressByUid provides a mock function with given fields: ctx, uid, schid, status, reason
+func (_m *Workflow) UpdateProgressByUid(ctx context.Context, uid int, schid int, status admission.Status, reason string) (*entities.Progress, error) {
+	ret := _m.Called(ctx, uid, schid, status, reason)
+
+	var r0 *entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, admission.Status, string) (*entities.Progress, error)); ok {
+		return rf(ctx, uid, schid, status, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, admission.Status, string) *entities.Progress); ok {
+		r0 = rf(ctx, uid, schid, status, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, admission.Status, string) error); ok {
+		r1 = rf(ctx, uid, schid, status, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProgresses provides a mock function with given fields: ctx, schid, ids, change
+func (_m *Workflow) UpdateProgresses(ctx context.Context, schid int, ids []int, change admission.Change) []admission.Result {
+	ret := _m.Called(ctx, schid, ids, change)
+
+	var r0 []admission.Result
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int, admission.Change) []admission.Result); ok {
+		r0 = rf(ctx, schid, ids, change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]admission.Result)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewWorkflow interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWorkflow creates a new instance of Workflow. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWorkflow(t mockConstructorTestingTNewWorkflow) *Workflow {
+	mock := &Workflow{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,288 @@
+package admission
+
+import (
+	"sort"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+)
+
+// Stage is an optional step a school can put between the file check and the finish.
+type Stage string
+
+const (
+	StageRegistrationPayment    Stage = "registration_payment"
+	StageTest                   Stage = "test"
+	StageInterview              Stage = "interview"
+	StageHerRegistrationPayment Stage = "herregistration_payment"
+)
+
+type (
+	// Pipeline is the ordered list of stages a school runs its admission with.
+	Pipeline []Stage
+	stage    struct {
+		enter Status
+		pass  Status
+		fail  Status
+		// manual is true when the admin records the outcome of the stage.
+		manual bool
+		revert string
+	}
+)
+
+// DefaultPipeline is used by schools that have not defined their own.
+var DefaultPipeline = Pipeline{StageRegistrationPayment, StageTest, StageHerRegistrationPayment}
+
+var stages = map[Stage]stage{
+	StageRegistrationPayment:    {enter: SendDetailCostsRegistration, pass: DonePayment, revert: "Failed Done Payment"},
+	StageTest:                   {enter: SendTestLink, pass: TestResult, fail: FailedTestResult},
+	StageInterview:              {enter: InterviewScheduled, pass: InterviewPassed, fail: FailedInterview, manual: true},
+	StageHerRegistrationPayment: {enter: SendDetailCostsHerRegistration, pass: AlreadyPaidHerRegistration, revert: "Failed Already Paid Her-Registration"},
+}
+
+// reasons explains to the admin what is missing when a manual status is requested
+// while the progress is not yet at the required status.
+var reasons = map[Status]string{
+	CheckFileRegistration:      "participant has not submitted the registration form",
+	FileApproved:               "Participant registration form has not been approved",
+	DonePayment:                "Participant has not paid the registration fee",
+	TestResult:                 "participant has not taken the test or did not pass the test",
+	InterviewScheduled:         "participant has not been scheduled for an interview",
+	InterviewPassed:            "participant has not passed the interview",
+	AlreadyPaidHerRegistration: "Participant has not paid her registration fee",
+}
+
+// reached is the status a failed progress would have reached had it passed.
+var reached = map[Status]Status{
+	FailedFileApproved: FileApproved,
+	FailedTestResult:   TestResult,
+}
+
+func NewPipeline(val []string) (Pipeline, error) {
+	res := Pipeline{}
+	seen := map[Stage]bool{}
+	for _, v := range val {
+		st := Stage(v)
+		if _, ok := stages[st]; !ok {
+			return nil, errorr.NewBad("Unknown pipeline stage " + v)
+		}
+		if seen[st] {
+			return nil, errorr.NewBad("Pipeline stage " + v + " is defined more than once")
+		}
+		seen[st] = true
+		res = append(res, st)
+	}
+	return res, nil
+}
+
+// PipelineFromSteps builds the pipeline persisted for a school, falling back to the default one.
+func PipelineFromSteps(steps []entity.PipelineStep) (Pipeline, error) {
+	if len(steps) == 0 {
+		return DefaultPipeline, nil
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].Position < steps[j].Position })
+	val := []string{}
+	for _, step := range steps {
+		val = append(val, step.Stage)
+	}
+	return NewPipeline(val)
+}
+
+func (p Pipeline) Steps(schid uint) []entity.PipelineStep {
+	res := []entity.PipelineStep{}
+	for i, st := range p {
+		res = append(res, entity.PipelineStep{SchoolID: schid, Position: i + 1, Stage: string(st)})
+	}
+	return res
+}
+
+func (p Pipeline) Has(st Stage) bool {
+	for _, val := range p {
+		if val == st {
+			return true
+		}
+	}
+	return false
+}
+
+// Transitions expands the pipeline into every allowed move, the file check always
+// comes first and the finish always comes last. The school can make an offer the student
+// accepts or declines before the finish, and the student can withdraw at any time.
+func (p Pipeline) Transitions() []Transition {
+	res := []Transition{
+		{From: CheckFileRegistration, To: FileApproved, Manual: true},
+		{From: CheckFileRegistration, To: FailedFileApproved, Manual: true},
+		{From: CheckFileRegistration, To: NeedsRevision, Manual: true},
+		{From: NeedsRevision, To: CheckFileRegistration},
+		{From: NeedsRevision, To: Expired},
+	}
+	seat := p.Seat()
+	prev := FileApproved
+	for _, val := range p {
+		st := stages[val]
+		if st.enter == seat {
+			res = append(res, waitlist(prev, seat)...)
+		}
+		res = append(res, Transition{From: prev, To: st.enter, Manual: true, Seat: st.enter == seat})
+		res = append(res, Transition{From: st.enter, To: st.pass, Manual: st.manual})
+		if st.fail != "" {
+			res = append(res, Transition{From: st.enter, To: st.fail, Manual: st.manual})
+		}
+		if st.revert != "" {
+			res = append(res, Transition{From: st.enter, To: prev, Revert: true, Label: st.revert})
+		}
+		if IsWaiting(st.enter) {
+			res = append(res, Transition{From: st.enter, To: Expired})
+		}
+		prev = st.pass
+	}
+	if seat == Finish {
+		res = append(res, waitlist(prev, seat)...)
+	}
+	res = append(res,
+		Transition{From: prev, To: OfferMade, Manual: true, Seat: seat == Finish},
+		Transition{From: OfferMade, To: OfferAccepted},
+		Transition{From: OfferMade, To: OfferDeclined},
+		Transition{From: OfferMade, To: Expired},
+		Transition{From: OfferAccepted, To: Finish, Manual: true},
+		Transition{From: prev, To: Finish, Manual: true, Seat: seat == Finish},
+	)
+	return append(res, withdraw(res)...)
+}
+
+// withdraw lets the student leave from every status that is still open.
+func withdraw(transitions []Transition) []Transition {
+	res := []Transition{}
+	seen := map[Status]bool{}
+	for _, val := range transitions {
+		for _, status := range []Status{val.From, val.To} {
+			if !seen[status] && !IsClosed(status) {
+				seen[status] = true
+				res = append(res, Transition{From: status, To: Withdrawn})
+			}
+		}
+	}
+	return res
+}
+
+// waitlist holds a participant moving to the seat while the school is full, and gives the seat once one is free.
+func waitlist(prev, seat Status) []Transition {
+	return []Transition{
+		{From: prev, To: Waitlisted},
+		{From: Waitlisted, To: seat, Seat: true},
+	}
+}
+
+// Seat returns the first status of the pipeline that takes one of the school seats.
+func (p Pipeline) Seat() Status {
+	for _, val := range p.Statuses() {
+		if IsSeated(val) {
+			return val
+		}
+	}
+	return Finish
+}
+
+// Statuses lists the statuses a successful admission goes through, in order.
+func (p Pipeline) Statuses() []Status {
+	res := []Status{CheckFileRegistration, FileApproved}
+	for _, val := range p {
+		res = append(res, stages[val].enter, stages[val].pass)
+	}
+	return append(res, Finish)
+}
+
+// Contains reports whether a progress with the given status can still move on in the pipeline.
+func (p Pipeline) Contains(status Status) bool {
+	if IsClosed(status) {
+		return true
+	}
+	for _, val := range p.Transitions() {
+		if val.From == status || val.To == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsManual reports whether a school admin may request the status directly.
+func (p Pipeline) IsManual(status Status) bool {
+	for _, val := range p.Transitions() {
+		if val.To == status && val.Manual {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns the transition that moves a progress from one status to another.
+// manual is true when the move is requested by a school admin.
+func (p Pipeline) Validate(from, to Status, manual bool) (*Transition, error) {
+	if manual && !p.IsManual(to) {
+		return nil, errorr.NewBad("Status Not Available")
+	}
+	if IsClosed(from) {
+		return nil, errorr.NewBad("Admission has already been closed")
+	}
+	var required Status
+	for _, val := range p.Transitions() {
+		if val.To != to || val.Manual != manual || val.Revert {
+			continue
+		}
+		if val.From == from {
+			return val.labeled(), nil
+		}
+		required = val.From
+	}
+	if reason, ok := reasons[required]; ok && manual {
+		return nil, errorr.NewBad(reason)
+	}
+	return nil, errorr.NewBad("Cannot change progress from " + string(from) + " to " + string(to))
+}
+
+// Revert returns the move back to the previous stage for a progress waiting on a payment.
+func (p Pipeline) Revert(from Status) (*Transition, error) {
+	for _, val := range p.Transitions() {
+		if val.From == from && val.Revert {
+			return val.labeled(), nil
+		}
+	}
+	return nil, errorr.NewBad("Cannot revert progress from " + string(from))
+}
+
+// Reopen returns the move that brings a failed progress back into the pipeline once its appeal is
+// accepted. The progress can go back to any status up to the one it failed to reach, never to a seat.
+func (p Pipeline) Reopen(from, to Status) (*Transition, error) {
+	last, ok := reached[from]
+	if !ok {
+		return nil, errorr.NewBad("Only a failed progress can be reopened")
+	}
+	for _, val := range p.Statuses() {
+		if IsSeated(val) {
+			break
+		}
+		if val == to {
+			return Transition{From: from, To: to, Manual: true}.labeled(), nil
+		}
+		if val == last {
+			break
+		}
+	}
+	return nil, errorr.NewBad("Cannot reopen progress at " + string(to))
+}
+
+// Waive skips the registration payment of an admission period that charges no fee, the progress
+// is moved straight to the paid status instead of waiting on a payment that never comes.
+func (t Transition) Waive(period *entity.AdmissionPeriod) Transition {
+	if t.To != SendDetailCostsRegistration || period == nil || period.RegistrationFee == nil || *period.RegistrationFee != 0 {
+		return t
+	}
+	return *Transition{From: t.From, To: DonePayment}.labeled()
+}
+
+func (t Transition) labeled() *Transition {
+	if t.Label == "" {
+		t.Label = string(t.To)
+	}
+	return &t
+}
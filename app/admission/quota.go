@@ -0,0 +1,42 @@
+package admission
+
+import (
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+)
+
+// ErrNoSeat is returned when a waitlisted participant is promoted while the school is still full.
+var ErrNoSeat = errorr.NewBad("No seat available")
+
+// Full reports whether a participant of the track has no seat left. A quota without a track
+// is the capacity of the whole school, used maps every quota track to the seats already taken.
+func Full(quotas []entity.Quota, used map[string]int, track string) bool {
+	for _, val := range quotas {
+		if val.Track != "" && val.Track != track {
+			continue
+		}
+		if used[val.Track] >= val.Capacity {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTrack makes sure a participant applies through a track the school has seats for,
+// any track is accepted when the school does not split its intake.
+func CheckTrack(quotas []entity.Quota, track string) error {
+	split := false
+	for _, val := range quotas {
+		if val.Track == "" {
+			continue
+		}
+		if val.Track == track {
+			return nil
+		}
+		split = true
+	}
+	if split {
+		return errorr.NewBad("Track not available at this school")
+	}
+	return nil
+}
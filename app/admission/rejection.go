@@ -0,0 +1,38 @@
+package admission
+
+// RejectionReason is the structured reason a school admin gives when rejecting a participant.
+type RejectionReason string
+
+const (
+	RejectIncompleteDocuments RejectionReason = "incomplete_documents"
+	RejectInvalidDocuments    RejectionReason = "invalid_documents"
+	RejectRequirementsNotMet  RejectionReason = "requirements_not_met"
+	RejectQuotaFull           RejectionReason = "quota_full"
+	RejectOther               RejectionReason = "other"
+)
+
+// rejectionLabels are sent to the student in the rejection notification.
+var rejectionLabels = map[RejectionReason]string{
+	RejectIncompleteDocuments: "Berkas Pendaftaran Tidak Lengkap",
+	RejectInvalidDocuments:    "Berkas Pendaftaran Tidak Valid",
+	RejectRequirementsNotMet:  "Tidak Memenuhi Persyaratan",
+	RejectQuotaFull:           "Kuota Penerimaan Sudah Penuh",
+	RejectOther:               "Berkas Pendaftaran Ditolak",
+}
+
+func (r RejectionReason) Valid() bool {
+	_, ok := rejectionLabels[r]
+	return ok
+}
+
+func (r RejectionReason) Label() string {
+	if label, ok := rejectionLabels[r]; ok {
+		return label
+	}
+	return rejectionLabels[RejectOther]
+}
+
+// IsRejection reports whether the status is a rejection made by the school admin.
+func IsRejection(status Status) bool {
+	return status == FailedFileApproved || status == FailedInterview
+}
@@ -0,0 +1,57 @@
+package admission
+
+import (
+	"strings"
+
+	"github.com/education-hub/BE/errorr"
+)
+
+// RevisionDocuments are the submission files a school admin can ask to be uploaded again.
+var RevisionDocuments = []string{"student_photo", "student_signature", "parent_signature"}
+
+// RevisionFields are the submission data a school admin can ask to be corrected.
+var RevisionFields = []string{
+	"student_name", "place_date", "gender", "religion", "graduation_from", "nisn", "student_address",
+	"parent_name", "parent_job", "parent_religion", "parent_gender", "parent_phone", "parent_address",
+}
+
+func IsRevisionDocument(field string) bool {
+	for _, val := range RevisionDocuments {
+		if val == field {
+			return true
+		}
+	}
+	return false
+}
+
+func isRevisionField(field string) bool {
+	if IsRevisionDocument(field) {
+		return true
+	}
+	for _, val := range RevisionFields {
+		if val == field {
+			return true
+		}
+	}
+	return false
+}
+
+// JoinRevision validates the flagged fields and returns them in the form stored on the progress.
+func JoinRevision(fields []string) (string, error) {
+	if len(fields) == 0 {
+		return "", errorr.NewBad("Revision fields are required")
+	}
+	for _, val := range fields {
+		if !isRevisionField(val) {
+			return "", errorr.NewBad("Unknown revision field " + val)
+		}
+	}
+	return strings.Join(fields, ","), nil
+}
+
+func SplitRevision(fields string) []string {
+	if fields == "" {
+		return []string{}
+	}
+	return strings.Split(fields, ",")
+}
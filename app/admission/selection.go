@@ -0,0 +1,130 @@
+package admission
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	entity "github.com/education-hub/BE/app/entities"
+)
+
+// Criterion is a value the scored selection ranks the applicants of a school with.
+type Criterion string
+
+const (
+	CriterionTestScore     Criterion = "test_score"
+	CriterionReportAverage Criterion = "report_average"
+	CriterionDistance      Criterion = "distance"
+	CriterionAchievements  Criterion = "achievements"
+)
+
+// Candidate holds the raw values of an applicant, a nil value scores zero on its criterion.
+type Candidate struct {
+	ProgressID    int
+	SubmissionID  int
+	UserID        int
+	Name          string
+	Status        string
+	TestScore     *float64
+	ReportAverage *float64
+	Distance      *float64
+	Achievements  int
+}
+
+var testScore = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*(?:/\s*(\d+(?:[.,]\d+)?)|%)?`)
+
+// ParseTestScore reads a quiz result such as "80%", "8/10" or "80" as a score out of 100.
+func ParseTestScore(result string) (float64, bool) {
+	match := testScore.FindStringSubmatch(result)
+	if match == nil {
+		return 0, false
+	}
+	val, err := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+	if err != nil {
+		return 0, false
+	}
+	if match[2] != "" {
+		total, err := strconv.ParseFloat(strings.Replace(match[2], ",", ".", 1), 64)
+		if err != nil || total == 0 {
+			return 0, false
+		}
+		val = val / total * 100
+	}
+	return math.Min(val, 100), true
+}
+
+// Score computes the weighted score out of 100 of every candidate and ranks them from the highest one,
+// equal scores keep the order the candidates registered in. Test scores and report averages are already
+// out of 100, the distance and the achievements are scored against the farthest and the best candidate.
+func Score(criteria []entity.SelectionCriterion, candidates []Candidate) []entity.ResSelectionRank {
+	farthest, best := 0.0, 0
+	for _, val := range candidates {
+		if val.Distance != nil && *val.Distance > farthest {
+			farthest = *val.Distance
+		}
+		if val.Achievements > best {
+			best = val.Achievements
+		}
+	}
+	total := 0.0
+	for _, val := range criteria {
+		total += val.Weight
+	}
+	res := []entity.ResSelectionRank{}
+	for _, val := range candidates {
+		rank := entity.ResSelectionRank{
+			ProgressId:   val.ProgressID,
+			SubmissionId: val.SubmissionID,
+			UserId:       val.UserID,
+			StudentName:  val.Name,
+			Status:       val.Status,
+			Scores:       map[string]float64{},
+		}
+		for _, crit := range criteria {
+			score := 0.0
+			switch Criterion(crit.Criterion) {
+			case CriterionTestScore:
+				if val.TestScore != nil {
+					score = *val.TestScore
+				}
+			case CriterionReportAverage:
+				if val.ReportAverage != nil {
+					score = *val.ReportAverage
+				}
+			case CriterionDistance:
+				if val.Distance != nil {
+					score = 100
+					if farthest > 0 {
+						score = (1 - *val.Distance/farthest) * 100
+					}
+				}
+			case CriterionAchievements:
+				if best > 0 {
+					score = float64(val.Achievements) / float64(best) * 100
+				}
+			}
+			rank.Scores[crit.Criterion] = round(score)
+			if total > 0 {
+				rank.Score += score * crit.Weight / total
+			}
+		}
+		rank.Score = round(rank.Score)
+		res = append(res, rank)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		return res[i].SubmissionId < res[j].SubmissionId
+	})
+	for i := range res {
+		res[i].Rank = i + 1
+	}
+	return res
+}
+
+func round(val float64) float64 {
+	return math.Round(val*100) / 100
+}
@@ -0,0 +1,103 @@
+package admission
+
+type Status string
+
+const (
+	CheckFileRegistration          Status = "Check File Registration"
+	FileApproved                   Status = "File Approved"
+	NeedsRevision                  Status = "Needs Revision"
+	FailedFileApproved             Status = "Failed File Approved"
+	SendDetailCostsRegistration    Status = "Send Detail Costs Registration"
+	DonePayment                    Status = "Done Payment"
+	SendTestLink                   Status = "Send Test Link"
+	TestResult                     Status = "Test Result"
+	FailedTestResult               Status = "Failed Test Result"
+	InterviewScheduled             Status = "Interview Scheduled"
+	InterviewPassed                Status = "Interview Passed"
+	FailedInterview                Status = "Failed Interview"
+	SendDetailCostsHerRegistration Status = "Send Detail Costs Her-Registration"
+	AlreadyPaidHerRegistration     Status = "Already Paid Her-Registration"
+	Finish                         Status = "Finish"
+	Waitlisted                     Status = "Waitlisted"
+	Expired                        Status = "Expired"
+	OfferMade                      Status = "Offer Made"
+	OfferAccepted                  Status = "Offer Accepted"
+	OfferDeclined                  Status = "Offer Declined"
+	Withdrawn                      Status = "Withdrawn"
+)
+
+// Initial is the status every progress starts with when a submission is created.
+const Initial = CheckFileRegistration
+
+// Closed lists the statuses that end an admission, no transition leaves them.
+var Closed = []Status{Finish, FailedFileApproved, FailedTestResult, FailedInterview, Expired, OfferDeclined, Withdrawn}
+
+// Released lists the closed statuses that leave the participant free to apply to the school again,
+// every closed status but the finish.
+var Released = released()
+
+func released() []Status {
+	res := []Status{}
+	for _, val := range Closed {
+		if val != Finish {
+			res = append(res, val)
+		}
+	}
+	return res
+}
+
+// Waiting lists the statuses where the participant has to act, they expire once the
+// step deadline of the admission period has passed.
+var Waiting = []Status{NeedsRevision, SendDetailCostsRegistration, SendTestLink, SendDetailCostsHerRegistration, OfferMade}
+
+// Seated lists the statuses that hold one of the school seats, from the her-registration to the finish.
+var Seated = []Status{SendDetailCostsHerRegistration, AlreadyPaidHerRegistration, OfferMade, OfferAccepted, Finish}
+
+// Responses lists the statuses a student can move its own progress to.
+var Responses = []Status{OfferAccepted, OfferDeclined, Withdrawn}
+
+// Appealable lists the failed statuses a student can appeal against.
+var Appealable = []Status{FailedFileApproved, FailedTestResult}
+
+// Transition is a single allowed move of a progress. Manual transitions can be
+// requested by the school admin, the others are driven by the system (payments, test results).
+type Transition struct {
+	From   Status
+	To     Status
+	Manual bool
+	// Revert marks the move back to the previous stage when a payment is cancelled.
+	Revert bool
+	// Label is published instead of To when the move needs a different wording for the student.
+	Label string
+	// Seat marks the moves that take one of the school seats, they end in the waitlist when the school is full.
+	Seat bool
+}
+
+func IsClosed(status Status) bool {
+	return has(Closed, status)
+}
+
+func IsWaiting(status Status) bool {
+	return has(Waiting, status)
+}
+
+func IsSeated(status Status) bool {
+	return has(Seated, status)
+}
+
+func IsResponse(status Status) bool {
+	return has(Responses, status)
+}
+
+func IsAppealable(status Status) bool {
+	return has(Appealable, status)
+}
+
+func has(list []Status, status Status) bool {
+	for _, val := range list {
+		if val == status {
+			return true
+		}
+	}
+	return false
+}
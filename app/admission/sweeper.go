@@ -0,0 +1,41 @@
+package admission
+
+import (
+	"context"
+	"time"
+
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/helper"
+)
+
+// defaultSweepInterval is used when SWEEPINTERVAL is not configured.
+const defaultSweepInterval = 15 * time.Minute
+
+// Sweeper periodically expires the progresses that missed a step deadline.
+type Sweeper struct {
+	workflow Workflow
+	dep      dependency.Depend
+}
+
+func NewSweeper(workflow Workflow, dep dependency.Depend) *Sweeper {
+	return &Sweeper{workflow: workflow, dep: dep}
+}
+
+// Run sweeps on every interval until the context is cancelled.
+func (s *Sweeper) Run(ctx context.Context) {
+	helper.Every(ctx, s.dep.Config.SweepInterval, defaultSweepInterval, s.Sweep)
+}
+
+func (s *Sweeper) Sweep(ctx context.Context, now time.Time) {
+	expired := 0
+	for _, val := range s.workflow.Expire(ctx, now) {
+		if val.Err != nil {
+			s.dep.Log.Errorf("[ERROR]WHEN EXPIRING PROGRESS %d: %v", val.ProgressID, val.Err)
+			continue
+		}
+		expired++
+	}
+	if expired > 0 {
+		s.dep.Log.Infof("Expired %d progress past their step deadline", expired)
+	}
+}
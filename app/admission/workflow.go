@@ -0,0 +1,663 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"gorm.io/gorm"
+)
+
+type (
+	// Store is the persistence needed by the workflow, it is satisfied by the school repository.
+	Store interface {
+		GetProgressByid(db *gorm.DB, id int) (*entity.Progress, error)
+		LockProgress(db *gorm.DB, id int) (*entity.Progress, error)
+		GetActiveProgressByUid(db *gorm.DB, uid int, schid int) (*entity.Progress, error)
+		UpdateProgress(db *gorm.DB, data entity.Progress) (*entity.Progress, error)
+		GetOtherActiveProgressByUid(db *gorm.DB, uid int, schid int) ([]entity.Progress, error)
+		CreateCart(db *gorm.DB, cart entity.Carts) error
+		GetById(db *gorm.DB, id int) (*entity.School, error)
+		GetPeriodById(db *gorm.DB, id int) (*entity.AdmissionPeriod, error)
+		GetPipeline(db *gorm.DB, schid int) ([]entity.PipelineStep, error)
+		CreateProgressEvent(db *gorm.DB, event entity.ProgressEvent) error
+		CreateNote(db *gorm.DB, note entity.AdmissionNote) error
+		LockQuotas(db *gorm.DB, schid int) ([]entity.Quota, error)
+		CountSeats(db *gorm.DB, schid int, track string) (int, error)
+		GetWaitlist(db *gorm.DB, schid int) ([]entity.Progress, error)
+		DeleteCartBySchool(db *gorm.DB, uid int, schid int) error
+		GetOverdueProgress(db *gorm.DB, now time.Time) ([]entity.Progress, error)
+		HasAppeal(db *gorm.DB, progid int) (bool, error)
+		CreateAppeal(db *gorm.DB, appeal entity.Appeal) (*entity.Appeal, error)
+		GetAppealById(db *gorm.DB, id int) (*entity.Appeal, error)
+		DecideAppeal(db *gorm.DB, appeal entity.Appeal) error
+		GetLastSubmission(db *gorm.DB, uid int, schid int) (*entity.Submission, error)
+		GetLetterTemplate(db *gorm.DB, schid int) (*entity.LetterTemplate, error)
+		GetLetterByProgress(db *gorm.DB, progid int) (*entity.AcceptanceLetter, error)
+		CreateLetter(db *gorm.DB, letter entity.AcceptanceLetter) (*entity.AcceptanceLetter, error)
+	}
+	// Users is satisfied by the user repository.
+	Users interface {
+		GetById(db *gorm.DB, id int) (*entity.User, error)
+		GetParents(db *gorm.DB, studentid int) ([]entity.User, error)
+	}
+	Workflow interface {
+		// UpdateProgress applies a status change requested by a school admin.
+		UpdateProgress(ctx context.Context, id int, change Change) (*entity.Progress, error)
+		// UpdateProgressByUid applies a status driven by the system on the active progress of a student at a school.
+		UpdateProgressByUid(ctx context.Context, uid int, schid int, status Status, reason string) (*entity.Progress, error)
+		// RevertProgressByUid moves a progress waiting on a payment back to the previous stage.
+		RevertProgressByUid(ctx context.Context, uid int, schid int, reason string) (*entity.Progress, error)
+		// Respond applies a status chosen by the student on its own progress, accepting or declining
+		// an offer or withdrawing from the school.
+		Respond(ctx context.Context, uid int, id int, status Status, reason string) (*entity.Progress, error)
+		// ResubmitProgressByUid sends a progress that needs revision back to the file check once the student has fixed it.
+		ResubmitProgressByUid(ctx context.Context, uid int, schid int) (*entity.Progress, error)
+		// UpdateProgresses applies the same change to several progresses of a school, every progress
+		// is moved in its own transaction and the notifications are sent together once all are done.
+		UpdateProgresses(ctx context.Context, schid int, ids []int, change Change) []Result
+		// Promote gives the free seats of a school to its waitlist, in rank order.
+		Promote(ctx context.Context, schid int) error
+		// Expire closes every progress that missed the step deadline of its admission period.
+		Expire(ctx context.Context, now time.Time) []Result
+		// Appeal opens an appeal of a student against a failed progress and tells the school admin.
+		Appeal(ctx context.Context, appeal entity.Appeal) (*entity.Appeal, error)
+		// DecideAppeal records the decision of a school admin, an accepted appeal reopens the progress.
+		DecideAppeal(ctx context.Context, schid int, id int, decision Decision) (*entity.Appeal, error)
+		// IssueLetter returns the acceptance letter of a finished progress, it is generated and stored the first time.
+		IssueLetter(ctx context.Context, prog *entity.Progress) (*entity.AcceptanceLetter, error)
+		Pipeline(ctx context.Context, schid int) (Pipeline, error)
+	}
+	// Change is a status change requested by a school admin.
+	Change struct {
+		Actor  int
+		Status Status
+		// Reason is stored in the progress history.
+		Reason string
+		// Rejection is required when the status rejects the participant.
+		Rejection RejectionReason
+		// Note is shown to the student and sent along with the notification.
+		Note string
+		// Fields lists the submission fields the student has to revise.
+		Fields []string
+		// record runs inside the transaction of the move, it stores what caused the change.
+		record func(db *gorm.DB) error
+	}
+	// Result is the outcome of a single progress in a bulk change, Err is nil when it was moved.
+	Result struct {
+		ProgressID int
+		Progress   *entity.Progress
+		Err        error
+	}
+	workflow struct {
+		store Store
+		users Users
+		dep   dependency.Depend
+		steps map[Status]step
+	}
+	// step holds what happens when a progress enters a status. guard runs before the move,
+	// apply runs inside the database transaction and notify runs once the move is committed.
+	// then runs last and may move other progresses.
+	step struct {
+		guard  func(ctx context.Context, prog *entity.Progress) error
+		apply  func(db *gorm.DB, prog *entity.Progress) error
+		notify func(out *outbox, prog *entity.Progress, change Change, user *entity.User, school *entity.School)
+		then   func(ctx context.Context, prog *entity.Progress, out *outbox)
+	}
+	// outbox collects the notifications of the moves so they are sent in batches once committed.
+	// parents are the linked parents of the student being notified, they get a copy of its mails.
+	outbox struct {
+		student []any
+		admin   []any
+		topics  []string
+		nsq     map[string][][]byte
+		parents []entity.User
+	}
+)
+
+// ErrStale is returned when the progress was moved by another change since it was read.
+var ErrStale = errorr.NewBad("Progress Was Changed Meanwhile, Please Reload")
+
+func NewWorkflow(store Store, users Users, dep dependency.Depend) Workflow {
+	w := &workflow{store: store, users: users, dep: dep}
+	w.steps = map[Status]step{
+		SendDetailCostsRegistration: {
+			apply:  w.createCart("registration"),
+			notify: w.publishCosts("Registration"),
+		},
+		SendTestLink: {
+			guard:  w.requireQuiz,
+			notify: w.publishTestLink,
+		},
+		SendDetailCostsHerRegistration: {
+			apply:  w.createCart("herregistration"),
+			notify: w.publishCosts("Her-Registration"),
+		},
+		Finish: {
+			apply:  w.dropCart,
+			notify: w.publishFinish,
+			then:   w.enroll,
+		},
+		OfferAccepted: {
+			then: w.releaseOthers,
+		},
+		OfferDeclined: {
+			apply: w.dropCart,
+		},
+		Withdrawn: {
+			apply: w.dropCart,
+		},
+		FailedFileApproved: {
+			notify: w.publishRejected,
+		},
+		FailedInterview: {
+			notify: w.publishRejected,
+		},
+		Expired: {
+			apply:  w.dropCart,
+			notify: w.publishExpired,
+		},
+	}
+	return w
+}
+
+func (w *workflow) Pipeline(ctx context.Context, schid int) (Pipeline, error) {
+	steps, err := w.store.GetPipeline(w.dep.Db.WithContext(ctx), schid)
+	if err != nil {
+		return nil, err
+	}
+	return PipelineFromSteps(steps)
+}
+
+func (w *workflow) UpdateProgress(ctx context.Context, id int, change Change) (*entity.Progress, error) {
+	change, err := check(change)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := w.store.GetProgressByid(w.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := w.Pipeline(ctx, int(prog.SchoolID))
+	if err != nil {
+		return nil, err
+	}
+	transition, err := pipeline.Validate(Status(prog.Status), change.Status, true)
+	if err != nil {
+		return nil, err
+	}
+	return w.send(ctx, prog, *transition, change)
+}
+
+func (w *workflow) UpdateProgresses(ctx context.Context, schid int, ids []int, change Change) []Result {
+	res := []Result{}
+	change, err := check(change)
+	var pipeline Pipeline
+	if err == nil {
+		pipeline, err = w.Pipeline(ctx, schid)
+	}
+	out := &outbox{}
+	for _, id := range ids {
+		if err != nil {
+			res = append(res, Result{ProgressID: id, Err: err})
+			continue
+		}
+		prog, err := w.moveInSchool(ctx, schid, pipeline, id, change, out)
+		res = append(res, Result{ProgressID: id, Progress: prog, Err: err})
+	}
+	w.flush(out)
+	return res
+}
+
+func (w *workflow) Expire(ctx context.Context, now time.Time) []Result {
+	res := []Result{}
+	progs, err := w.store.GetOverdueProgress(w.dep.Db.WithContext(ctx), now)
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN GETTING OVERDUE PROGRESS: %v", err)
+		return res
+	}
+	pipelines := map[uint]Pipeline{}
+	out := &outbox{}
+	for i := range progs {
+		prog := &progs[i]
+		pipeline, ok := pipelines[prog.SchoolID]
+		if !ok {
+			if pipeline, err = w.Pipeline(ctx, int(prog.SchoolID)); err != nil {
+				res = append(res, Result{ProgressID: int(prog.ID), Err: err})
+				continue
+			}
+			pipelines[prog.SchoolID] = pipeline
+		}
+		transition, err := pipeline.Validate(Status(prog.Status), Expired, false)
+		if err != nil {
+			res = append(res, Result{ProgressID: int(prog.ID), Err: err})
+			continue
+		}
+		moved, err := w.move(ctx, prog, *transition, Change{Status: Expired, Reason: "Step deadline passed"}, out)
+		res = append(res, Result{ProgressID: int(prog.ID), Progress: moved, Err: err})
+	}
+	w.flush(out)
+	return res
+}
+
+func (w *workflow) moveInSchool(ctx context.Context, schid int, pipeline Pipeline, id int, change Change, out *outbox) (*entity.Progress, error) {
+	prog, err := w.store.GetProgressByid(w.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if int(prog.SchoolID) != schid {
+		return nil, errorr.NewBad("Data Not Found")
+	}
+	transition, err := pipeline.Validate(Status(prog.Status), change.Status, true)
+	if err != nil {
+		return nil, err
+	}
+	return w.move(ctx, prog, *transition, change, out)
+}
+
+// check validates the extra data a status change needs and drops what the status does not use.
+func check(change Change) (Change, error) {
+	if IsRejection(change.Status) && !change.Rejection.Valid() {
+		return change, errorr.NewBad("Rejection reason is required")
+	}
+	if !IsRejection(change.Status) {
+		change.Rejection = ""
+	}
+	if change.Status != NeedsRevision {
+		change.Fields = nil
+	} else if _, err := JoinRevision(change.Fields); err != nil {
+		return change, err
+	}
+	return change, nil
+}
+
+func (w *workflow) UpdateProgressByUid(ctx context.Context, uid int, schid int, status Status, reason string) (*entity.Progress, error) {
+	prog, err := w.store.GetActiveProgressByUid(w.dep.Db.WithContext(ctx), uid, schid)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := w.Pipeline(ctx, schid)
+	if err != nil {
+		return nil, err
+	}
+	transition, err := pipeline.Validate(Status(prog.Status), status, false)
+	if err != nil {
+		return nil, err
+	}
+	return w.send(ctx, prog, *transition, Change{Status: status, Reason: reason})
+}
+
+func (w *workflow) RevertProgressByUid(ctx context.Context, uid int, schid int, reason string) (*entity.Progress, error) {
+	prog, err := w.store.GetActiveProgressByUid(w.dep.Db.WithContext(ctx), uid, schid)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := w.Pipeline(ctx, schid)
+	if err != nil {
+		return nil, err
+	}
+	transition, err := pipeline.Revert(Status(prog.Status))
+	if err != nil {
+		return nil, err
+	}
+	return w.send(ctx, prog, *transition, Change{Status: transition.To, Reason: reason})
+}
+
+func (w *workflow) Respond(ctx context.Context, uid int, id int, status Status, reason string) (*entity.Progress, error) {
+	if !IsResponse(status) {
+		return nil, errorr.NewBad("Status Not Available")
+	}
+	prog, err := w.store.GetProgressByid(w.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if int(prog.UserID) != uid {
+		return nil, errorr.NewBad("Data Not Found")
+	}
+	pipeline, err := w.Pipeline(ctx, int(prog.SchoolID))
+	if err != nil {
+		return nil, err
+	}
+	transition, err := pipeline.Validate(Status(prog.Status), status, false)
+	if err != nil {
+		return nil, err
+	}
+	return w.send(ctx, prog, *transition, Change{Actor: uid, Status: status, Reason: reason})
+}
+
+func (w *workflow) ResubmitProgressByUid(ctx context.Context, uid int, schid int) (*entity.Progress, error) {
+	prog, err := w.store.GetActiveProgressByUid(w.dep.Db.WithContext(ctx), uid, schid)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := w.Pipeline(ctx, schid)
+	if err != nil {
+		return nil, err
+	}
+	transition, err := pipeline.Validate(Status(prog.Status), CheckFileRegistration, false)
+	if err != nil {
+		return nil, err
+	}
+	return w.send(ctx, prog, *transition, Change{Actor: uid, Status: CheckFileRegistration, Reason: "Submission revised"})
+}
+
+// send moves a single progress and sends its notifications right away.
+func (w *workflow) send(ctx context.Context, prog *entity.Progress, transition Transition, change Change) (*entity.Progress, error) {
+	out := &outbox{}
+	res, err := w.move(ctx, prog, transition, change, out)
+	if err != nil {
+		return nil, err
+	}
+	w.flush(out)
+	return res, nil
+}
+
+// move applies the transition and appends it to the progress history, the actor is 0 for the system.
+func (w *workflow) move(ctx context.Context, prog *entity.Progress, transition Transition, change Change, out *outbox) (*entity.Progress, error) {
+	step := w.steps[transition.To]
+	if step.guard != nil {
+		if err := step.guard(ctx, prog); err != nil {
+			return nil, err
+		}
+	}
+	var res *entity.Progress
+	err := w.dep.Db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		// the transition was checked on a progress read before the transaction, another change
+		// may have moved it since then
+		current, err := w.store.LockProgress(db, int(prog.ID))
+		if err != nil {
+			return err
+		}
+		if current.Status != string(transition.From) {
+			return ErrStale
+		}
+		if transition.To == SendDetailCostsRegistration && prog.PeriodID != 0 {
+			period, err := w.store.GetPeriodById(db, int(prog.PeriodID))
+			if err != nil {
+				return err
+			}
+			transition = transition.Waive(period)
+			step = w.steps[transition.To]
+		}
+		if transition.Seat {
+			full, err := w.full(db, prog)
+			if err != nil {
+				return err
+			}
+			if full && transition.From == Waitlisted {
+				return ErrNoSeat
+			}
+			if full {
+				transition = *Transition{From: transition.From, To: Waitlisted}.labeled()
+				step = w.steps[Waitlisted]
+			}
+		}
+		if step.apply != nil {
+			if err := step.apply(db, prog); err != nil {
+				return err
+			}
+		}
+		fields, _ := JoinRevision(change.Fields)
+		data := entity.Progress{ID: prog.ID, Status: string(transition.To), RejectionReason: string(change.Rejection), RevisionFields: fields}
+		if transition.To == Waitlisted {
+			now := time.Now()
+			data.WaitlistedAt = &now
+		}
+		res, err = w.store.UpdateProgress(db, data)
+		if err != nil {
+			return err
+		}
+		if change.record != nil {
+			if err := change.record(db); err != nil {
+				return err
+			}
+		}
+		if change.Note != "" {
+			if err := w.store.CreateNote(db, entity.AdmissionNote{ProgressID: prog.ID, UserID: uint(change.Actor), Note: change.Note}); err != nil {
+				return err
+			}
+		}
+		return w.store.CreateProgressEvent(db, entity.ProgressEvent{ProgressID: prog.ID, FromStatus: prog.Status, ToStatus: string(transition.To), ActorID: uint(change.Actor), Reason: change.Reason})
+	})
+	if err != nil {
+		return nil, err
+	}
+	w.notify(ctx, out, res, transition, change, step)
+	if IsSeated(Status(prog.Status)) && !IsSeated(transition.To) {
+		if err := w.promote(ctx, int(prog.SchoolID), out); err != nil {
+			w.dep.Log.Errorf("[ERROR]WHEN PROMOTING WAITLIST: %v", err)
+		}
+	}
+	if step.then != nil {
+		step.then(ctx, res, out)
+	}
+	return res, nil
+}
+
+// full reports whether the school has no seat left for the participant, it must run inside
+// the transaction of the move so the quotas stay locked until the seat is taken.
+func (w *workflow) full(db *gorm.DB, prog *entity.Progress) (bool, error) {
+	quotas, err := w.store.LockQuotas(db, int(prog.SchoolID))
+	if err != nil {
+		return false, err
+	}
+	used := map[string]int{}
+	for _, val := range quotas {
+		if val.Track != "" && val.Track != prog.Track {
+			continue
+		}
+		if used[val.Track], err = w.store.CountSeats(db, int(prog.SchoolID), val.Track); err != nil {
+			return false, err
+		}
+	}
+	return Full(quotas, used, prog.Track), nil
+}
+
+func (w *workflow) Promote(ctx context.Context, schid int) error {
+	out := &outbox{}
+	err := w.promote(ctx, schid, out)
+	w.flush(out)
+	return err
+}
+
+func (w *workflow) promote(ctx context.Context, schid int, out *outbox) error {
+	pipeline, err := w.Pipeline(ctx, schid)
+	if err != nil {
+		return err
+	}
+	transition, err := pipeline.Validate(Waitlisted, pipeline.Seat(), false)
+	if err != nil {
+		return err
+	}
+	waitlist, err := w.store.GetWaitlist(w.dep.Db.WithContext(ctx), schid)
+	if err != nil {
+		return err
+	}
+	for i := range waitlist {
+		_, err := w.move(ctx, &waitlist[i], *transition, Change{Status: transition.To, Reason: "Seat available"}, out)
+		if err != nil && err != ErrNoSeat && err != ErrStale {
+			return err
+		}
+	}
+	return nil
+}
+
+// notify always tells the student about the new status, the school admin is only told
+// about moves it did not make itself.
+func (w *workflow) notify(ctx context.Context, out *outbox, prog *entity.Progress, transition Transition, change Change, step step) {
+	user, err := w.users.GetById(w.dep.Db.WithContext(ctx), int(prog.UserID))
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN GETTING USER DATA: %v", err)
+		user = &entity.User{}
+	}
+	out.parents = w.parents(ctx, prog)
+	defer func() { out.parents = nil }()
+	out.toStudent(user, map[string]any{"type": "admission", "status": transition.Label, "progress_id": prog.ID})
+	if !transition.Manual {
+		out.admin = append(out.admin, map[string]any{"progress_id": prog.ID, "status": transition.Label})
+	}
+	if step.notify == nil {
+		return
+	}
+	school, err := w.store.GetById(w.dep.Db.WithContext(ctx), int(prog.SchoolID))
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN GETTING SCHOOL DATA: %v", err)
+		school = &entity.School{}
+	}
+	step.notify(out, prog, change, user, school)
+}
+
+// parents returns the linked parents of the student of the progress, they are left out of the
+// notifications when they cannot be read.
+func (w *workflow) parents(ctx context.Context, prog *entity.Progress) []entity.User {
+	parents, err := w.users.GetParents(w.dep.Db.WithContext(ctx), int(prog.UserID))
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN GETTING PARENTS DATA: %v", err)
+		return nil
+	}
+	return parents
+}
+
+// toStudent queues a Pusher event for the student and the same event for each of its parents,
+// the event of a parent names the student it is about.
+func (o *outbox) toStudent(user *entity.User, data map[string]any) {
+	data["username"] = user.Username
+	o.student = append(o.student, data)
+	for _, parent := range o.parents {
+		copied := map[string]any{}
+		for key, val := range data {
+			copied[key] = val
+		}
+		copied["username"] = parent.Username
+		copied["student"] = user.Username
+		o.student = append(o.student, copied)
+	}
+}
+
+// mailTopics are the NSQ topics sent as mails to the student, a copy goes to each of its parents.
+var mailTopics = map[string]bool{"8": true, "11": true, "12": true, "13": true}
+
+func (o *outbox) publish(topics []string, data map[string]any) {
+	encodeddata, _ := json.Marshal(data)
+	if o.nsq == nil {
+		o.nsq = map[string][][]byte{}
+	}
+	for _, topic := range topics {
+		if _, ok := o.nsq[topic]; !ok {
+			o.topics = append(o.topics, topic)
+		}
+		o.nsq[topic] = append(o.nsq[topic], encodeddata)
+		if !mailTopics[topic] {
+			continue
+		}
+		for _, parent := range o.parents {
+			o.nsq[topic] = append(o.nsq[topic], ParentMail(data, parent))
+		}
+	}
+}
+
+// ParentMail readdresses a mail of a student to one of its parents, the content stays about the student.
+func ParentMail(data map[string]any, parent entity.User) []byte {
+	copied := map[string]any{}
+	for key, val := range data {
+		copied[key] = val
+	}
+	copied["email"] = parent.Email
+	copied["parent"] = parent.FirstName + " " + parent.SureName
+	encodeddata, _ := json.Marshal(copied)
+	return encodeddata
+}
+
+// flush sends the collected Pusher events in batches and the NSQ messages from a single goroutine.
+func (w *workflow) flush(out *outbox) {
+	w.broadcast(out.student, 2)
+	w.broadcast(out.admin, 3)
+	if len(out.topics) == 0 {
+		return
+	}
+	go func() {
+		for _, topic := range out.topics {
+			if err := w.dep.Nsq.MultiPublish(topic, out.nsq[topic]); err != nil {
+				w.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
+			}
+		}
+	}()
+}
+
+func (w *workflow) broadcast(data []any, event int) {
+	if len(data) == 0 {
+		return
+	}
+	if err := w.dep.Pusher.PublishBatch(data, event); err != nil {
+		w.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
+	}
+}
+
+func (w *workflow) createCart(typee string) func(db *gorm.DB, prog *entity.Progress) error {
+	return func(db *gorm.DB, prog *entity.Progress) error {
+		return w.store.CreateCart(db, entity.Carts{UserID: prog.UserID, SchoolID: prog.SchoolID, Type: typee})
+	}
+}
+
+// releaseOthers withdraws the student from the other schools once it has settled on one, every progress
+// is moved on its own so the seat it held goes to the waitlist of its school.
+func (w *workflow) releaseOthers(ctx context.Context, prog *entity.Progress, out *outbox) {
+	others, err := w.store.GetOtherActiveProgressByUid(w.dep.Db.WithContext(ctx), int(prog.UserID), int(prog.SchoolID))
+	if err != nil {
+		w.dep.Log.Errorf("[ERROR]WHEN GETTING OTHER PROGRESS: %v", err)
+		return
+	}
+	for i := range others {
+		other := &others[i]
+		pipeline, err := w.Pipeline(ctx, int(other.SchoolID))
+		if err == nil {
+			var transition *Transition
+			if transition, err = pipeline.Validate(Status(other.Status), Withdrawn, false); err == nil {
+				_, err = w.move(ctx, other, *transition, Change{Status: Withdrawn, Reason: "Enrolled at another school"}, out)
+			}
+		}
+		if err != nil {
+			w.dep.Log.Errorf("[ERROR]WHEN RELEASING PROGRESS %d: %v", other.ID, err)
+		}
+	}
+}
+
+func (w *workflow) dropCart(db *gorm.DB, prog *entity.Progress) error {
+	return w.store.DeleteCartBySchool(db, int(prog.UserID), int(prog.SchoolID))
+}
+
+func (w *workflow) requireQuiz(ctx context.Context, prog *entity.Progress) error {
+	school, err := w.store.GetById(w.dep.Db.WithContext(ctx), int(prog.SchoolID))
+	if err != nil {
+		return err
+	}
+	if school.QuizLinkPub == "" {
+		return errorr.NewBad("School has not created the test yet")
+	}
+	return nil
+}
+
+func (w *workflow) publishCosts(typecost string) func(out *outbox, prog *entity.Progress, change Change, user *entity.User, school *entity.School) {
+	return func(out *outbox, prog *entity.Progress, change Change, user *entity.User, school *entity.School) {
+		out.publish([]string{"11"}, map[string]any{"email": user.Email, "name": user.FirstName + " " + user.SureName, "school": school.Name, "type": typecost, "school_id": school.ID})
+	}
+}
+
+func (w *workflow) publishTestLink(out *outbox, prog *entity.Progress, change Change, user *entity.User, school *entity.School) {
+	out.publish([]string{"8"}, map[string]any{"email": user.Email, "name": user.FirstName + " " + user.SureName, "school": school.Name, "test": school.QuizLinkPub})
+}
+
+func (w *workflow) publishFinish(out *outbox, prog *entity.Progress, change Change, user *entity.User, school *entity.School) {
+	out.publish([]string{"12", "14"}, map[string]any{"email": user.Email, "name": user.FirstName + " " + user.SureName, "school": school.Name, "user_id": int(user.ID), "school_id": int(school.ID)})
+}
+
+func (w *workflow) publishRejected(out *outbox, prog *entity.Progress, change Change, user *entity.User, school *entity.School) {
+	out.publish([]string{"13"}, map[string]any{"email": user.Email, "name": user.FirstName + " " + user.SureName, "school": school.Name, "reason": change.Rejection.Label(), "reason_code": string(change.Rejection), "note": change.Note})
+}
+
+func (w *workflow) publishExpired(out *outbox, prog *entity.Progress, change Change, user *entity.User, school *entity.School) {
+	out.publish([]string{"13"}, map[string]any{"email": user.Email, "name": user.FirstName + " " + user.SureName, "school": school.Name, "reason": "Batas Waktu Tahapan Terlewati", "reason_code": "expired"})
+}
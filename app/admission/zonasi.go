@@ -0,0 +1,47 @@
+package admission
+
+import (
+	"math"
+	"sort"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/pkg"
+)
+
+// RankByDistance orders the applicants of a school by their straight-line distance to it. Applicants at
+// the same distance, rounded to the meter, keep the order they registered in, applicants whose address
+// could not be located come last.
+func RankByDistance(school pkg.Coordinate, applicants []entity.Applicant) []entity.ResZonasiRank {
+	res := []entity.ResZonasiRank{}
+	for _, val := range applicants {
+		rank := entity.ResZonasiRank{
+			ProgressId:   int(val.ProgressID),
+			SubmissionId: int(val.SubmissionID),
+			UserId:       int(val.UserID),
+			StudentName:  val.StudentName,
+			Track:        val.Track,
+			Status:       val.Status,
+		}
+		if val.Latitude != nil && val.Longitude != nil {
+			distance := math.Round(pkg.Distance(school, pkg.Coordinate{Lat: *val.Latitude, Lng: *val.Longitude})*1000) / 1000
+			rank.Distance = &distance
+		}
+		res = append(res, rank)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		a, b := res[i].Distance, res[j].Distance
+		switch {
+		case a != nil && b == nil:
+			return true
+		case a == nil && b != nil:
+			return false
+		case a != nil && *a != *b:
+			return *a < *b
+		}
+		return res[i].SubmissionId < res[j].SubmissionId
+	})
+	for i := range res {
+		res[i].Rank = i + 1
+	}
+	return res
+}
@@ -0,0 +1,297 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/helper"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// Fields never written to the log, whatever the resource.
+var redacted = map[string]bool{"password": true, "token": true, "refresh_token": true, "secret": true}
+
+// Fields every write changes, they would show up in every diff.
+var ignored = map[string]bool{"CreatedAt": true, "UpdatedAt": true, "DeletedAt": true, "created_at": true, "updated_at": true, "deleted_at": true}
+
+type (
+	// Change is what a service knows about the resource a request acts on, Before is nil for
+	// a creation and After is nil for a deletion.
+	Change struct {
+		Type   string
+		ID     any
+		Before any
+		After  any
+	}
+	// Filter narrows the entries returned by Query, School is set for the owner of a school.
+	Filter struct {
+		School       int
+		ActorID      int
+		Action       string
+		ResourceType string
+		ResourceID   string
+		From         time.Time
+		To           time.Time
+		Limit        int
+		Offset       int
+	}
+	// Store keeps the entries, it is satisfied by the gorm store.
+	Store interface {
+		Create(db *gorm.DB, entry entity.AuditEntry) error
+		Find(db *gorm.DB, filter Filter) ([]entity.AuditEntry, int, error)
+	}
+	Log interface {
+		// Middleware writes an entry for every request changing something, the services add the
+		// resource and its diff through Track.
+		Middleware(next echo.HandlerFunc) echo.HandlerFunc
+		// Record writes an entry of its own, the actor, the ip and the request id are taken from
+		// the request when the entry has none.
+		Record(ctx context.Context, entry entity.AuditEntry) error
+		Query(ctx context.Context, filter Filter) ([]entity.AuditEntry, int, error)
+	}
+	log struct {
+		store Store
+		dep   dependency.Depend
+	}
+	// request is the entry of a request being handled, kept in the context of the request.
+	request struct {
+		entry entity.AuditEntry
+		c     echo.Context
+	}
+	key struct{}
+)
+
+func NewLog(store Store, dep dependency.Depend) Log {
+	return &log{store: store, dep: dep}
+}
+
+func (l *log) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		switch c.Request().Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return next(c)
+		}
+		req := &request{c: c}
+		c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), key{}, req)))
+		err := next(c)
+		entry := req.fill(req.entry)
+		entry.Action = c.Request().Method + " " + c.Path()
+		if entry.ResourceType == "" {
+			entry.ResourceType, entry.ResourceID = resourceOf(c)
+		}
+		entry.Status = c.Response().Status
+		if err != nil {
+			entry.Status = http.StatusInternalServerError
+			if he, ok := err.(*echo.HTTPError); ok {
+				entry.Status = he.Code
+			}
+		}
+		// the response is already sent, a failed write is only logged
+		l.write(l.dep.Db, entry)
+		return err
+	}
+}
+
+func (l *log) Record(ctx context.Context, entry entity.AuditEntry) error {
+	if req, ok := requestOf(ctx); ok {
+		entry = req.fill(entry)
+	}
+	if err := l.write(l.dep.Db.WithContext(ctx), entry); err != nil {
+		l.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	return nil
+}
+
+func (l *log) Query(ctx context.Context, filter Filter) ([]entity.AuditEntry, int, error) {
+	res, total, err := l.store.Find(l.dep.Db.WithContext(ctx), filter)
+	if err != nil {
+		l.dep.PromErr["error"] = err.Error()
+		return nil, 0, err
+	}
+	return res, total, nil
+}
+
+func (l *log) write(db *gorm.DB, entry entity.AuditEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	if err := l.store.Create(db, entry); err != nil {
+		l.dep.Log.Errorf("[ERROR]WHEN WRITING AUDIT ENTRY %s %s/%s, Err : %v", entry.Action, entry.ResourceType, entry.ResourceID, err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// fill completes the entry with what the request knows, the actor is known once the token is read.
+func (r *request) fill(entry entity.AuditEntry) entity.AuditEntry {
+	if token, ok := r.c.Get("user").(*jwt.Token); ok && entry.ActorID == 0 {
+		entry.ActorID = uint(helper.GetUid(token))
+		entry.Role = helper.GetRole(token)
+	}
+	if entry.SchoolID == nil {
+		entry.SchoolID = r.entry.SchoolID
+	}
+	if entry.IP == "" {
+		entry.IP = r.c.RealIP()
+	}
+	if entry.RequestID == "" {
+		entry.RequestID = r.c.Response().Header().Get(echo.HeaderXRequestID)
+	}
+	return entry
+}
+
+// Track tells the entry of the request which resource it changed and how.
+func Track(ctx context.Context, change Change) {
+	req, ok := requestOf(ctx)
+	if !ok {
+		return
+	}
+	req.entry.ResourceType = change.Type
+	if change.ID != nil {
+		req.entry.ResourceID = fmt.Sprint(change.ID)
+	}
+	req.entry.Before, req.entry.After = Diff(change.Before, change.After)
+}
+
+// AtSchool tells the entry of the request the school it acts at.
+func AtSchool(ctx context.Context, id uint) {
+	if req, ok := requestOf(ctx); ok && id != 0 {
+		req.entry.SchoolID = &id
+	}
+}
+
+// requestOf returns the request ctx belongs to, the jobs and the tests have none.
+func requestOf(ctx context.Context) (*request, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	req, ok := ctx.Value(key{}).(*request)
+	return req, ok
+}
+
+// Diff returns the fields that differ between before and after, a missing side is returned whole.
+func Diff(before any, after any) (json.RawMessage, json.RawMessage) {
+	old, cur := fields(before), fields(after)
+	if old == nil || cur == nil {
+		return encode(old), encode(cur)
+	}
+	for name, val := range old {
+		if reflect.DeepEqual(val, cur[name]) {
+			delete(old, name)
+			delete(cur, name)
+		}
+	}
+	for name := range cur {
+		if _, ok := old[name]; !ok {
+			old[name] = nil
+		}
+	}
+	return encode(old), encode(cur)
+}
+
+// fields flattens a value to its json fields, without the ones never logged. Only the columns of
+// the resource are kept, the relations loaded along are left out. A list, like the quotas of a
+// school, is kept whole under items.
+func fields(val any) map[string]any {
+	if val == nil || (reflect.ValueOf(val).Kind() == reflect.Ptr && reflect.ValueOf(val).IsNil()) {
+		return nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil
+	}
+	var res any
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil
+	}
+	switch res := res.(type) {
+	case map[string]any:
+		return columnsOf(res)
+	case []any:
+		for i, item := range res {
+			if record, ok := item.(map[string]any); ok {
+				res[i] = columnsOf(record)
+			}
+		}
+		return map[string]any{"items": res}
+	}
+	return map[string]any{"value": res}
+}
+
+// columnsOf drops the fields never logged and the relations, a list of plain values is a column.
+func columnsOf(record map[string]any) map[string]any {
+	for name, field := range record {
+		switch field := field.(type) {
+		case map[string]any:
+			delete(record, name)
+			continue
+		case []any:
+			for _, item := range field {
+				switch item.(type) {
+				case map[string]any, []any:
+					delete(record, name)
+				}
+			}
+		}
+		if isRedacted(name) || ignored[name] {
+			delete(record, name)
+		}
+	}
+	return record
+}
+
+// isRedacted also matches the fields holding one, like token_hash.
+func isRedacted(name string) bool {
+	name = strings.ToLower(name)
+	for key := range redacted {
+		if strings.Contains(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Encode returns val the way the entries keep it, for the entries written with Record.
+func Encode(val any) json.RawMessage {
+	return encode(fields(val))
+}
+
+func encode(val map[string]any) json.RawMessage {
+	if len(val) == 0 {
+		return nil
+	}
+	data, _ := json.Marshal(val)
+	return data
+}
+
+// resourceOf names the resource of a request from its route, /admin/members/:id is a member.
+func resourceOf(c echo.Context) (string, string) {
+	kind := ""
+	for _, part := range strings.Split(strings.Trim(c.Path(), "/"), "/") {
+		if part != "" && part != "admin" && !strings.HasPrefix(part, ":") {
+			kind = part
+			break
+		}
+	}
+	id := ""
+	if names := c.ParamNames(); len(names) > 0 {
+		id = c.Param(names[len(names)-1])
+		for _, name := range names {
+			if name == "id" {
+				id = c.Param(name)
+			}
+		}
+	}
+	return kind, id
+}
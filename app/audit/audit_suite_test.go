@@ -0,0 +1,180 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/education-hub/BE/app/audit"
+	"github.com/education-hub/BE/app/audit/mocks"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestAudit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Suite")
+}
+
+type fee struct {
+	ID          uint   `json:"id"`
+	Description string `json:"description"`
+	Price       int    `json:"price"`
+	Password    string `json:"password"`
+	School      struct {
+		Name string `json:"name"`
+	} `json:"school"`
+}
+
+var _ = Describe("audit", func() {
+	var Mock *mocks.Store
+	var Log audit.Log
+	var e *echo.Echo
+	BeforeEach(func() {
+		Mock = mocks.NewStore(GinkgoT())
+		Log = audit.NewLog(Mock, dependency.Depend{
+			Db:      &gorm.DB{Config: &gorm.Config{}, Statement: &gorm.Statement{}},
+			Log:     logrus.New(),
+			PromErr: make(map[string]string, 1),
+		})
+		e = echo.New()
+		e.Use(middleware.RequestID())
+		e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				c.Set("user", &jwt.Token{Claims: jwt.MapClaims{"id": float64(5), "role": "administrator"}})
+				return next(c)
+			}
+		})
+		e.Use(Log.Middleware)
+	})
+	serve := func(method string, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	Context("Middleware", func() {
+		When("Request Hanya Membaca", func() {
+			It("Tidak Akan Dicatat", func() {
+				e.GET("/schools/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+				rec := serve(http.MethodGet, "/schools/1")
+				Expect(rec.Code).To(Equal(http.StatusOK))
+			})
+		})
+		When("Service Mencatat Perubahan", func() {
+			It("Akan Mencatat Pelaku Dan Selisih Data", func() {
+				var entry entity.AuditEntry
+				Mock.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					entry = args.Get(1).(entity.AuditEntry)
+				}).Return(nil).Once()
+				e.PUT("/admin/payments/:id", func(c echo.Context) error {
+					ctx := c.Request().Context()
+					audit.AtSchool(ctx, 3)
+					audit.Track(ctx, audit.Change{Type: "payment", ID: 7, Before: fee{ID: 7, Description: "SPP", Price: 100, Password: "lama"}, After: fee{ID: 7, Description: "SPP", Price: 200, Password: "baru"}})
+					return c.NoContent(http.StatusOK)
+				})
+				rec := serve(http.MethodPut, "/admin/payments/7")
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(entry.ActorID).To(Equal(uint(5)))
+				Expect(entry.Role).To(Equal("administrator"))
+				Expect(*entry.SchoolID).To(Equal(uint(3)))
+				Expect(entry.Action).To(Equal("PUT /admin/payments/:id"))
+				Expect(entry.ResourceType).To(Equal("payment"))
+				Expect(entry.ResourceID).To(Equal("7"))
+				Expect(entry.Before).To(MatchJSON(`{"price":100}`))
+				Expect(entry.After).To(MatchJSON(`{"price":200}`))
+				Expect(entry.Status).To(Equal(http.StatusOK))
+				Expect(entry.IP).To(Equal("10.0.0.1"))
+				Expect(entry.RequestID).To(Equal(rec.Header().Get(echo.HeaderXRequestID)))
+			})
+		})
+		When("Request Ditolak", func() {
+			It("Akan Mencatat Resource Dari Route Dan Status Error", func() {
+				Mock.On("Create", mock.Anything, mock.MatchedBy(func(entry entity.AuditEntry) bool {
+					return entry.ResourceType == "members" && entry.ResourceID == "9" && entry.Status == http.StatusForbidden && entry.Before == nil
+				})).Return(nil).Once()
+				e.DELETE("/admin/members/:id", func(c echo.Context) error {
+					return echo.NewHTTPError(http.StatusForbidden, "Forbidden")
+				})
+				rec := serve(http.MethodDelete, "/admin/members/9")
+				Expect(rec.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+		When("Gagal Menyimpan Entri", func() {
+			It("Response Tetap Dikirim", func() {
+				Mock.On("Create", mock.Anything, mock.Anything).Return(errors.New("Database Down")).Once()
+				e.POST("/admin/payments", func(c echo.Context) error { return c.NoContent(http.StatusCreated) })
+				rec := serve(http.MethodPost, "/admin/payments")
+				Expect(rec.Code).To(Equal(http.StatusCreated))
+			})
+		})
+	})
+	Context("Record", func() {
+		When("Di Luar Request", func() {
+			It("Akan Mencatat Entri Apa Adanya", func() {
+				Mock.On("Create", mock.Anything, mock.MatchedBy(func(entry entity.AuditEntry) bool {
+					return entry.Action == "login_lockout" && entry.ActorID == 0 && !entry.CreatedAt.IsZero()
+				})).Return(nil).Once()
+				err := Log.Record(context.Background(), entity.AuditEntry{Action: "login_lockout"})
+				Expect(err).Should(BeNil())
+			})
+		})
+		When("Gagal Menyimpan Entri", func() {
+			It("Akan Mengembalikan Error", func() {
+				Mock.On("Create", mock.Anything, mock.Anything).Return(errors.New("Database Down")).Once()
+				err := Log.Record(context.Background(), entity.AuditEntry{Action: "login_unlock"})
+				Expect(err).To(Equal(errorr.NewInternal("Internal Server Error")))
+			})
+		})
+	})
+	Context("Diff", func() {
+		When("Resource Baru Dibuat", func() {
+			It("Hanya Ada Data Sesudah Tanpa Field Rahasia", func() {
+				before, after := audit.Diff(nil, &fee{ID: 1, Description: "SPP", Price: 100, Password: "rahasia"})
+				Expect(before).To(BeNil())
+				Expect(after).To(MatchJSON(`{"id":1,"description":"SPP","price":100}`))
+			})
+		})
+		When("Field Baru Muncul", func() {
+			It("Data Sebelum Bernilai Null", func() {
+				before, after := audit.Diff(map[string]any{"status": "pending"}, map[string]any{"status": "paid", "paid_at": "2023-06-01"})
+				Expect(before).To(MatchJSON(`{"status":"pending","paid_at":null}`))
+				Expect(after).To(MatchJSON(`{"status":"paid","paid_at":"2023-06-01"}`))
+			})
+		})
+		When("Resource Berupa Daftar", func() {
+			It("Daftar Dicatat Utuh", func() {
+				before, after := audit.Diff([]fee{{Description: "SPP", Price: 100}}, []fee{{Description: "SPP", Price: 100}, {Description: "Seragam", Price: 50}})
+				Expect(before).To(MatchJSON(`{"items":[{"id":0,"description":"SPP","price":100}]}`))
+				Expect(after).To(MatchJSON(`{"items":[{"id":0,"description":"SPP","price":100},{"id":0,"description":"Seragam","price":50}]}`))
+			})
+		})
+		When("Field Menyimpan Token", func() {
+			It("Field Tidak Dicatat", func() {
+				_, after := audit.Diff(nil, map[string]any{"Email": "a@b.c", "TokenHash": "abc", "Stages": []string{"interview"}})
+				Expect(after).To(MatchJSON(`{"Email":"a@b.c","Stages":["interview"]}`))
+			})
+		})
+		When("Tidak Ada Perubahan", func() {
+			It("Akan Kosong", func() {
+				before, after := audit.Diff(fee{Price: 1}, fee{Price: 1})
+				Expect(before).To(BeNil())
+				Expect(after).To(BeNil())
+				Expect(json.Valid(audit.Encode(map[string]any{"failures": 4}))).To(BeTrue())
+			})
+		})
+	})
+})
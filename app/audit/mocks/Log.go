@@ -0,0 +1,98 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	audit "github.com/education-hub/BE/app/audit"
+
+	echo "github.com/labstack/echo/v4"
+
+	entities "github.com/education-hub/BE/app/entities"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Log is an autogenerated mock type for the Log type
+type Log struct {
+	mock.Mock
+}
+
+// Middleware provides a mock function with given fields: next
+func (_m *Log) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	ret := _m.Called(next)
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func(echo.HandlerFunc) echo.HandlerFunc); ok {
+		r0 = rf(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// Query provides a mock function with given fields: ctx, filter
+func (_m *Log) Query(ctx context.Context, filter audit.Filter) ([]entities.AuditEntry, int, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []entities.AuditEntry
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter) ([]entities.AuditEntry, int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter) []entities.AuditEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Filter) int); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, audit.Filter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Record provides a mock function with given fields: ctx, entry
+func (_m *Log) Record(ctx context.Context, entry entities.AuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.AuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewLog interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLog creates a new instance of Log. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLog(t mockConstructorTestingTNewLog) *Log {
+	mock := &Log{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	audit "github.com/education-hub/BE/app/audit"
+	entities "github.com/education-hub/BE/app/entities"
+
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Store is an autogenerated mock type for the Store type
+type Store struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: db, entry
+func (_m *Store) Create(db *gorm.DB, entry entities.AuditEntry) error {
+	ret := _m.Called(db, entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.AuditEntry) error); ok {
+		r0 = rf(db, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: db, filter
+func (_m *Store) Find(db *gorm.DB, filter audit.Filter) ([]entities.AuditEntry, int, error) {
+	ret := _m.Called(db, filter)
+
+	var r0 []entities.AuditEntry
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, audit.Filter) ([]entities.AuditEntry, int, error)); ok {
+		return rf(db, filter)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, audit.Filter) []entities.AuditEntry); ok {
+		r0 = rf(db, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, audit.Filter) int); ok {
+		r1 = rf(db, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(*gorm.DB, audit.Filter) error); ok {
+		r2 = rf(db, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewStore(t mockConstructorTestingTNewStore) *Store {
+	mock := &Store{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
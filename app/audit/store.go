@@ -0,0 +1,61 @@
+package audit
+
+import (
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type gormStore struct {
+	log *logrus.Logger
+}
+
+func NewGormStore(log *logrus.Logger) Store {
+	return &gormStore{log: log}
+}
+
+func (g *gormStore) Create(db *gorm.DB, entry entity.AuditEntry) error {
+	if err := db.Create(&entry).Error; err != nil {
+		g.log.Errorf("[ERROR]WHEN CREATING AUDIT ENTRY, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// Find returns the newest entries first along with the number of entries matching the filter.
+func (g *gormStore) Find(db *gorm.DB, filter Filter) ([]entity.AuditEntry, int, error) {
+	query := db.Model(&entity.AuditEntry{})
+	if filter.School != 0 {
+		query = query.Where("school_id=?", filter.School)
+	}
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id=?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action=?", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type=?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id=?", filter.ResourceID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at>=?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at<?", filter.To)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		g.log.Errorf("[ERROR]WHEN COUNTING AUDIT ENTRIES, Error: %v", err)
+		return nil, 0, errorr.NewInternal("Internal Server Error")
+	}
+	res := []entity.AuditEntry{}
+	if err := query.Order("created_at DESC, id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&res).Error; err != nil {
+		g.log.Errorf("[ERROR]WHEN FINDING AUDIT ENTRIES, Error: %v", err)
+		return nil, 0, errorr.NewInternal("Internal Server Error")
+	}
+	return res, int(total), nil
+}
@@ -0,0 +1,141 @@
+package authz
+
+import (
+	"context"
+
+	"github.com/education-hub/BE/app/audit"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"gorm.io/gorm"
+)
+
+// Action is what a member may do at its school, the role of the member grants a set of them.
+type Action string
+
+const (
+	ViewSchool      Action = "view_school"
+	ManageSchool    Action = "manage_school"
+	ManageContent   Action = "manage_content"
+	ViewAdmission   Action = "view_admission"
+	ManageAdmission Action = "manage_admission"
+	ManageFinance   Action = "manage_finance"
+	ViewAudit       Action = "view_audit"
+)
+
+// Roles lists the actions of every member role.
+var Roles = map[string][]Action{
+	"owner":      {ViewSchool, ManageSchool, ManageContent, ViewAdmission, ManageAdmission, ManageFinance, ViewAudit},
+	"admissions": {ViewSchool, ViewAdmission, ManageAdmission},
+	"finance":    {ViewSchool, ViewAdmission, ManageFinance},
+	"content":    {ViewSchool, ManageContent},
+}
+
+// Kind is the type of a resource, every kind but School is resolved to the school it belongs to.
+type Kind string
+
+const (
+	School          Kind = "school"
+	Achievement     Kind = "achievement"
+	Extracurricular Kind = "extracurricular"
+	Faq             Kind = "faq"
+	Payment         Kind = "payment"
+)
+
+var models = map[Kind]any{
+	Achievement:     &entity.Achievement{},
+	Extracurricular: &entity.Extracurricular{},
+	Faq:             &entity.Faq{},
+	Payment:         &entity.Payment{},
+}
+
+// Resource is what the user acts on.
+type Resource struct {
+	Kind Kind
+	ID   int
+}
+
+// MySchool is the school the user works at, whichever it is.
+var MySchool = Resource{}
+
+// ErrForbidden is returned whenever the user may not act on the resource.
+var ErrForbidden = errorr.NewForbidden("You are not allowed to access this resource")
+
+type (
+	// Store resolves members and resources, it is satisfied by the school repository.
+	Store interface {
+		GetMember(db *gorm.DB, uid int) (*entity.SchoolMember, error)
+		GetSchoolIdOf(db *gorm.DB, model any, id int) (int, error)
+	}
+	Authorizer interface {
+		// Can returns the school of the user once its role there grants the action and the resource belongs to that school.
+		Can(ctx context.Context, uid int, action Action, res Resource) (*entity.School, error)
+	}
+	authorizer struct {
+		store Store
+		dep   dependency.Depend
+	}
+)
+
+func NewAuthorizer(store Store, dep dependency.Depend) Authorizer {
+	return &authorizer{store: store, dep: dep}
+}
+
+func On(kind Kind, id int) Resource {
+	return Resource{Kind: kind, ID: id}
+}
+
+func Allowed(role string, action Action) bool {
+	for _, val := range Roles[role] {
+		if val == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Owner makes sure a student only reaches its own data, owner is the user the resource was created for.
+func Owner(uid int, owner uint) error {
+	if int(owner) != uid {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (a *authorizer) Can(ctx context.Context, uid int, action Action, res Resource) (*entity.School, error) {
+	schid, err := a.resolve(ctx, res)
+	if err != nil {
+		a.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	member, err := a.store.GetMember(a.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		a.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if !Allowed(member.Role, action) {
+		a.dep.PromErr["error"] = "Member role " + member.Role + " lacks " + string(action)
+		return nil, ErrForbidden
+	}
+	if res != MySchool && int(member.SchoolID) != schid {
+		a.dep.PromErr["error"] = "Resource does not belong to the school of the member"
+		return nil, ErrForbidden
+	}
+	audit.AtSchool(ctx, member.SchoolID)
+	return &member.School, nil
+}
+
+// resolve returns the school owning the resource.
+func (a *authorizer) resolve(ctx context.Context, res Resource) (int, error) {
+	switch res.Kind {
+	case "":
+		return 0, nil
+	case School:
+		return res.ID, nil
+	}
+	model, ok := models[res.Kind]
+	if !ok {
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	return a.store.GetSchoolIdOf(a.dep.Db.WithContext(ctx), model, res.ID)
+}
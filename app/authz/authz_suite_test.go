@@ -0,0 +1,269 @@
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/education-hub/BE/app/authz"
+	"github.com/education-hub/BE/app/authz/mocks"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestAuthz(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Authz Suite")
+}
+
+// route is how a route of routes.go is authorized, the resource id of the spec is always 7.
+// A route without action is public or reaches the data of the caller, which authz.Owner guards.
+type route struct {
+	path   string
+	action authz.Action
+	res    authz.Resource
+}
+
+var routes = []route{
+	{path: "GET /prometheus"},
+	{path: "POST /login"},
+	{path: "POST /login/2fa"},
+	{path: "GET /oauth/google"},
+	{path: "POST /oauth/google"},
+	{path: "POST /refresh"},
+	{path: "POST /register"},
+	{path: "GET /verify/:verifcode"},
+	{path: "GET /updateverif/:verifcode"},
+	{path: "POST /verify/resend"},
+	{path: "POST /forgot"},
+	{path: "POST /reset/:token"},
+	{path: "GET /getcaptcha"},
+	{path: "POST /verifycaptcha"},
+	{path: "GET /quiz/:url"},
+	{path: "GET /quiz/cron/:token"},
+	{path: "GET /schools"},
+	{path: "GET /schools/search"},
+	{path: "GET /schools/:id"},
+	{path: "GET /gmeet"},
+	{path: "GET /letters/:reference"},
+	{path: "POST /notif"},
+	{path: "GET /quiz/set/:token"},
+	{path: "POST /logout"},
+	{path: "POST /logout/all"},
+	{path: "PUT /users"},
+	{path: "DELETE /users"},
+	{path: "GET /users"},
+	{path: "GET /users/export"},
+	{path: "POST /users/2fa"},
+	{path: "PUT /users/2fa"},
+	{path: "POST /users/2fa/disable"},
+	{path: "POST /users/2fa/recovery-codes"},
+	{path: "DELETE /users/:id/2fa"},
+	{path: "DELETE /lockouts/:username"},
+	{path: "GET /audit"},
+	{path: "GET /progresses/:id", action: authz.ViewAdmission, res: authz.On(authz.School, 7)},
+	{path: "GET /progresses/:id/timeline", action: authz.ViewAdmission, res: authz.On(authz.School, 7)},
+	{path: "GET /submissions/:id/pdf", action: authz.ViewAdmission, res: authz.On(authz.School, 7)},
+	{path: "DELETE /parent-links/:id"},
+	{path: "GET /transactions"},
+	{path: "GET /transactions/:id"},
+	{path: "POST /transactions/checkout"},
+	{path: "POST /school/register"},
+	{path: "POST /school/register/profile"},
+	{path: "PUT /school/register/:id"},
+	{path: "GET /users/progress"},
+	{path: "GET /users/student-profile"},
+	{path: "PUT /users/student-profile"},
+	{path: "PUT /users/progress/:id/offer"},
+	{path: "PUT /users/progress/:id/withdraw"},
+	{path: "GET /users/progress/:id/letter"},
+	{path: "POST /reviews"},
+	{path: "POST /appeals"},
+	{path: "GET /appeals"},
+	{path: "GET /users/parents"},
+	{path: "PUT /users/parents/:id"},
+	{path: "POST /parents/children"},
+	{path: "GET /parents/children"},
+	{path: "GET /parents/children/:child/progress"},
+	{path: "GET /parents/children/:child/progress/:id/timeline"},
+	{path: "GET /parents/children/:child/transactions"},
+	{path: "GET /parents/children/:child/transactions/:id"},
+	{path: "POST /parents/children/:child/transactions/checkout"},
+	{path: "GET /admin/school", action: authz.ViewSchool, res: authz.MySchool},
+	{path: "GET /admin/admission", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/admission/:id", action: authz.ViewAdmission, res: authz.On(authz.School, 7)},
+	{path: "GET /quiz", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /file/:fname", action: authz.ViewAdmission, res: authz.On(authz.School, 7)},
+	{path: "GET /admin/pipeline", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/quotas", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/waitlist", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/zonasi", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/selection/criteria", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/selection", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/selection/export", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/periods", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/appeals", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/letter-template", action: authz.ViewAdmission, res: authz.MySchool},
+	{path: "GET /admin/members", action: authz.ViewSchool, res: authz.MySchool},
+	{path: "GET /admin/members/invitations", action: authz.ManageSchool, res: authz.MySchool},
+	{path: "GET /admin/audit", action: authz.ViewAudit, res: authz.MySchool},
+	{path: "POST /school"},
+	{path: "PUT /progresses", action: authz.ManageAdmission, res: authz.MySchool},
+	{path: "PUT /progresses/:id", action: authz.ManageAdmission, res: authz.On(authz.School, 7)},
+	{path: "DELETE /progresses/:id", action: authz.ManageAdmission, res: authz.On(authz.School, 7)},
+	{path: "POST /progresses/:id/notes", action: authz.ManageAdmission, res: authz.On(authz.School, 7)},
+	{path: "DELETE /school/:id", action: authz.ManageSchool, res: authz.On(authz.School, 7)},
+	{path: "PUT /school", action: authz.ManageSchool, res: authz.MySchool},
+	{path: "POST /achievements", action: authz.ManageContent, res: authz.On(authz.School, 7)},
+	{path: "PUT /achievements", action: authz.ManageContent, res: authz.On(authz.Achievement, 7)},
+	{path: "DELETE /achievements/:id", action: authz.ManageContent, res: authz.On(authz.Achievement, 7)},
+	{path: "POST /faqs", action: authz.ManageContent, res: authz.On(authz.School, 7)},
+	{path: "PUT /faqs", action: authz.ManageContent, res: authz.On(authz.Faq, 7)},
+	{path: "DELETE /faqs/:id", action: authz.ManageContent, res: authz.On(authz.Faq, 7)},
+	{path: "POST /extracurriculars", action: authz.ManageContent, res: authz.On(authz.School, 7)},
+	{path: "PUT /extracurriculars", action: authz.ManageContent, res: authz.On(authz.Extracurricular, 7)},
+	{path: "DELETE /extracurriculars/:id", action: authz.ManageContent, res: authz.On(authz.Extracurricular, 7)},
+	{path: "POST /gmeet", action: authz.ManageContent, res: authz.MySchool},
+	{path: "POST /payments", action: authz.ManageFinance, res: authz.On(authz.School, 7)},
+	{path: "PUT /payments", action: authz.ManageFinance, res: authz.On(authz.Payment, 7)},
+	{path: "DELETE /payments/:id", action: authz.ManageFinance, res: authz.On(authz.Payment, 7)},
+	{path: "POST /quiz", action: authz.ManageAdmission, res: authz.On(authz.School, 7)},
+	{path: "PUT /admin/pipeline", action: authz.ManageAdmission, res: authz.MySchool},
+	{path: "PUT /admin/quotas", action: authz.ManageAdmission, res: authz.MySchool},
+	{path: "PUT /admin/selection/criteria", action: authz.ManageAdmission, res: authz.MySchool},
+	{path: "POST /admin/selection/advance", action: authz.ManageAdmission, res: authz.MySchool},
+	{path: "PUT /admin/appeals/:id", action: authz.ManageAdmission, res: authz.MySchool},
+	{path: "PUT /admin/letter-template", action: authz.ManageAdmission, res: authz.MySchool},
+	{path: "POST /admin/periods", action: authz.ManageAdmission, res: authz.MySchool},
+	{path: "PUT /admin/periods/:id", action: authz.ManageAdmission, res: authz.On(authz.School, 7)},
+	{path: "DELETE /admin/periods/:id", action: authz.ManageAdmission, res: authz.On(authz.School, 7)},
+	{path: "POST /admin/members/invitations", action: authz.ManageSchool, res: authz.MySchool},
+	{path: "DELETE /admin/members/invitations/:id", action: authz.ManageSchool, res: authz.On(authz.School, 7)},
+	{path: "POST /admin/invitations/:token"},
+	{path: "PUT /admin/members/:id", action: authz.ManageSchool, res: authz.On(authz.School, 7)},
+	{path: "DELETE /admin/members/:id", action: authz.ManageSchool, res: authz.On(authz.School, 7)},
+}
+
+// registered lists the routes of routes.go with the prefix of their group.
+func registered() []string {
+	src, err := os.ReadFile("../routes/routes.go")
+	Expect(err).Should(BeNil())
+	prefix := map[string]string{"ro": ""}
+	for _, val := range regexp.MustCompile(`(\w+) := (\w+)\.Group\("([^"]*)"`).FindAllStringSubmatch(string(src), -1) {
+		prefix[val[1]] = prefix[val[2]] + val[3]
+	}
+	res := []string{}
+	for _, val := range regexp.MustCompile(`(\w+)\.(GET|POST|PUT|DELETE)\("([^"]*)"`).FindAllStringSubmatch(string(src), -1) {
+		res = append(res, val[2]+" "+prefix[val[1]]+val[3])
+	}
+	return res
+}
+
+// lacking returns a role without the action, or an empty one when every role has it.
+func lacking(action authz.Action) string {
+	for role := range authz.Roles {
+		if !authz.Allowed(role, action) {
+			return role
+		}
+	}
+	return ""
+}
+
+var _ = Describe("authz", func() {
+	var Mock *mocks.Store
+	var Authorizer authz.Authorizer
+	var ctx context.Context
+	BeforeEach(func() {
+		Mock = mocks.NewStore(GinkgoT())
+		Authorizer = authz.NewAuthorizer(Mock, dependency.Depend{
+			Db:      &gorm.DB{Config: &gorm.Config{}, Statement: &gorm.Statement{}},
+			PromErr: make(map[string]string, 1),
+		})
+		ctx = context.Background()
+	})
+	// resolvesTo makes the resource of the spec belong to the school.
+	resolvesTo := func(res authz.Resource, schid int) {
+		if res.Kind != "" && res.Kind != authz.School {
+			Mock.On("GetSchoolIdOf", mock.Anything, mock.Anything, res.ID).Return(schid, nil).Once()
+		}
+	}
+	asMember := func(role string, schid uint) {
+		member := entity.SchoolMember{SchoolID: schid, Role: role}
+		member.School.ID = schid
+		Mock.On("GetMember", mock.Anything, 1).Return(&member, nil).Once()
+	}
+	Context("Setiap Route Memiliki Aturan", func() {
+		It("Akan Mencakup Semua Route Di routes.go", func() {
+			table := map[string]bool{}
+			for _, val := range routes {
+				table[val.path] = true
+			}
+			paths := registered()
+			Expect(paths).ShouldNot(BeEmpty())
+			for _, val := range paths {
+				Expect(table).To(HaveKey(val), "route %s has no authorization rule", val)
+			}
+			Expect(paths).To(HaveLen(len(routes)))
+		})
+	})
+	entries := []TableEntry{}
+	for _, val := range routes {
+		if val.action != "" {
+			entries = append(entries, Entry(val.path, val))
+		}
+	}
+	DescribeTable("Pengurus Sekolah",
+		func(r route) {
+			By("pemilik sekolah diizinkan")
+			resolvesTo(r.res, 7)
+			asMember("owner", 7)
+			schooldata, err := Authorizer.Can(ctx, 1, r.action, r.res)
+			Expect(err).Should(BeNil())
+			Expect(int(schooldata.ID)).To(Equal(7))
+
+			if r.res != authz.MySchool {
+				By("pengurus sekolah lain ditolak")
+				resolvesTo(r.res, 7)
+				asMember("owner", 9)
+				_, err = Authorizer.Can(ctx, 1, r.action, r.res)
+				Expect(err).To(Equal(authz.ErrForbidden))
+			}
+
+			if role := lacking(r.action); role != "" {
+				By("peran tanpa izin ditolak")
+				resolvesTo(r.res, 7)
+				asMember(role, 7)
+				_, err = Authorizer.Can(ctx, 1, r.action, r.res)
+				Expect(err).To(Equal(authz.ErrForbidden))
+			}
+		},
+		entries,
+	)
+	Context("Data Milik Siswa", func() {
+		It("Akan Mengembalikan Erorr Untuk Siswa Lain", func() {
+			Expect(authz.Owner(1, 1)).Should(BeNil())
+			Expect(authz.Owner(1, 2)).To(Equal(authz.ErrForbidden))
+		})
+	})
+	Context("Resource Tidak Ditemukan", func() {
+		It("Akan Mengembalikan Erorr", func() {
+			Mock.On("GetSchoolIdOf", mock.Anything, mock.Anything, 7).Return(0, errorr.NewBad("Data Not Found")).Once()
+			_, err := Authorizer.Can(ctx, 1, authz.ManageContent, authz.On(authz.Faq, 7))
+			Expect(err).To(Equal(errorr.NewBad("Data Not Found")))
+		})
+	})
+	Context("Pengguna Bukan Pengurus Sekolah", func() {
+		It("Akan Mengembalikan Erorr", func() {
+			Mock.On("GetMember", mock.Anything, 1).Return(nil, errors.New("Data Not Found")).Once()
+			_, err := Authorizer.Can(ctx, 1, authz.ViewSchool, authz.MySchool)
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+})
@@ -0,0 +1,80 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "github.com/education-hub/BE/app/entities"
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Store is an autogenerated mock type for the Store type
+type Store struct {
+	mock.Mock
+}
+
+// GetMember provides a mock function with given fields: db, uid
+func (_m *Store) GetMember(db *gorm.DB, uid int) (*entities.SchoolMember, error) {
+	ret := _m.Called(db, uid)
+
+	var r0 *entities.SchoolMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.SchoolMember, error)); ok {
+		return rf(db, uid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.SchoolMember); ok {
+		r0 = rf(db, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SchoolMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchoolIdOf provides a mock function with given fields: db, model, id
+func (_m *Store) GetSchoolIdOf(db *gorm.DB, model interface{}, id int) (int, error) {
+	ret := _m.Called(db, model, id)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, interface{}, int) (int, error)); ok {
+		return rf(db, model, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, interface{}, int) int); ok {
+		r0 = rf(db, model, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, interface{}, int) error); ok {
+		r1 = rf(db, model, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewStore(t mockConstructorTestingTNewStore) *Store {
+	mock := &Store{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type (
+	// AuditEntry records who changed what, Before and After only hold the fields that changed.
+	// SchoolID is the school the change was made at, the owner of the school reads its entries.
+	AuditEntry struct {
+		ID           uint            `gorm:"primaryKey;autoIncrement;not null" json:"id"`
+		ActorID      uint            `gorm:"not null;index" json:"actor_id"`
+		Role         string          `gorm:"type:varchar(20);not null" json:"role"`
+		SchoolID     *uint           `gorm:"index" json:"school_id,omitempty"`
+		Action       string          `gorm:"type:varchar(100);not null;index" json:"action"`
+		ResourceType string          `gorm:"type:varchar(50);not null;index:idx_audit_resource" json:"resource_type"`
+		ResourceID   string          `gorm:"type:varchar(64);index:idx_audit_resource" json:"resource_id,omitempty"`
+		Before       json.RawMessage `gorm:"type:text" json:"before,omitempty"`
+		After        json.RawMessage `gorm:"type:text" json:"after,omitempty"`
+		Status       int             `gorm:"not null" json:"status"`
+		IP           string          `gorm:"type:varchar(45)" json:"ip,omitempty"`
+		RequestID    string          `gorm:"type:varchar(64);index" json:"request_id,omitempty"`
+		CreatedAt    time.Time       `gorm:"index" json:"created_at"`
+	}
+	ReqAuditQuery struct {
+		ActorID      int    `query:"actor_id"`
+		Action       string `query:"action"`
+		ResourceType string `query:"resource_type"`
+		ResourceID   string `query:"resource_id"`
+		From         string `query:"from"`
+		To           string `query:"to"`
+		Page         int    `query:"page"`
+		Limit        int    `query:"limit"`
+	}
+)
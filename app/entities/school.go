@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -31,6 +33,8 @@ type (
 		QuizLinkPub      string `gorm:"type:varchar(150);default:"`
 		QuizLinkPreview  string `gorm:"type:varchar(150);default:"`
 		QuizLinkResult   string `gorm:"type:varchar(150);default:"`
+		Latitude         *float64
+		Longitude        *float64
 		Achievements     []Achievement
 		Extracurriculars []Extracurricular
 		Faqs             []Faq
@@ -40,6 +44,244 @@ type (
 		Progresses       []Progress
 		Reviews          []Reviews
 		Carts            []Carts
+		PipelineSteps    []PipelineStep
+		Quotas           []Quota
+		Periods          []AdmissionPeriod
+		Criteria         []SelectionCriterion
+	}
+	PipelineStep struct {
+		ID       uint   `gorm:"primaryKey;autoIncrement;not null"`
+		SchoolID uint   `gorm:"not null"`
+		Position int    `gorm:"not null"`
+		Stage    string `gorm:"type:varchar(50);not null"`
+	}
+	ReqUpdatePipeline struct {
+		Stages []string `json:"stages"`
+	}
+	ResPipeline struct {
+		Stages   []string `json:"stages"`
+		Statuses []string `json:"statuses"`
+	}
+	Quota struct {
+		ID       uint   `gorm:"primaryKey;autoIncrement;not null"`
+		SchoolID uint   `gorm:"not null;index"`
+		Track    string `gorm:"type:varchar(20)"`
+		Capacity int    `gorm:"not null"`
+	}
+	// SelectionCriterion is one weighted criterion of the scored selection of a school.
+	SelectionCriterion struct {
+		ID        uint    `gorm:"primaryKey;autoIncrement;not null"`
+		SchoolID  uint    `gorm:"not null;index"`
+		Criterion string  `gorm:"type:varchar(20);not null"`
+		Weight    float64 `gorm:"not null"`
+	}
+	AdmissionPeriod struct {
+		ID       uint      `gorm:"primaryKey;autoIncrement;not null"`
+		SchoolID uint      `gorm:"not null;index"`
+		Name     string    `gorm:"type:varchar(100);not null"`
+		OpenAt   time.Time `gorm:"not null"`
+		CloseAt  time.Time `gorm:"not null"`
+		// StepDeadline is the number of days a participant has to complete a step, 0 means no deadline.
+		StepDeadline int
+		// RegistrationFee replaces the default registration fee when it is set, 0 makes the registration free.
+		RegistrationFee *int
+	}
+	ReqAdmissionPeriod struct {
+		Name            string    `json:"name" validate:"required,max=100"`
+		OpenAt          time.Time `json:"open_at" validate:"required"`
+		CloseAt         time.Time `json:"close_at" validate:"required,gtfield=OpenAt"`
+		StepDeadline    int       `json:"step_deadline" validate:"min=0"`
+		RegistrationFee *int      `json:"registration_fee" validate:"omitempty,min=0"`
+	}
+	ResAdmissionPeriod struct {
+		Id              int       `json:"id"`
+		Name            string    `json:"name"`
+		OpenAt          time.Time `json:"open_at"`
+		CloseAt         time.Time `json:"close_at"`
+		StepDeadline    int       `json:"step_deadline"`
+		RegistrationFee *int      `json:"registration_fee"`
+		Open            bool      `json:"open"`
+	}
+	ReqQuota struct {
+		Track    string `json:"track" validate:"omitempty,oneof=zonasi prestasi afirmasi transfer"`
+		Capacity int    `json:"capacity" validate:"min=0"`
+	}
+	ReqUpdateQuota struct {
+		Quotas []ReqQuota `json:"quotas" validate:"dive"`
+	}
+	// Appeal is opened by a student against a failed progress, once per progress.
+	Appeal struct {
+		ID            uint   `gorm:"primaryKey;autoIncrement;not null"`
+		ProgressID    uint   `gorm:"not null;uniqueIndex"`
+		UserID        uint   `gorm:"not null;index"`
+		SchoolID      uint   `gorm:"not null;index"`
+		FromStatus    string `gorm:"type:varchar(50)"`
+		Justification string `gorm:"type:text;not null"`
+		Attachments   string `gorm:"type:text"`
+		Status        string `gorm:"type:varchar(20);not null"`
+		ReopenStatus  string `gorm:"type:varchar(50)"`
+		Response      string `gorm:"type:varchar(500)"`
+		DecidedBy     uint
+		DecidedAt     *time.Time
+		CreatedAt     time.Time
+	}
+	// LetterTemplate is the wording of the acceptance letter of a school, the body may use the placeholders
+	// {student_name}, {nisn}, {school_name}, {npsn}, {date} and {reference}.
+	LetterTemplate struct {
+		ID          uint   `gorm:"primaryKey;autoIncrement;not null"`
+		SchoolID    uint   `gorm:"not null;uniqueIndex"`
+		Title       string `gorm:"type:varchar(100);not null"`
+		Body        string `gorm:"type:text;not null"`
+		SignerName  string `gorm:"type:varchar(100);not null"`
+		SignerTitle string `gorm:"type:varchar(100);not null"`
+	}
+	// AcceptanceLetter is issued once for a progress that reaches Finish, the reference printed on it lets anyone verify it.
+	AcceptanceLetter struct {
+		ID          uint   `gorm:"primaryKey;autoIncrement;not null"`
+		ProgressID  uint   `gorm:"not null;uniqueIndex"`
+		UserID      uint   `gorm:"not null;index"`
+		SchoolID    uint   `gorm:"not null;index"`
+		Reference   string `gorm:"type:varchar(40);not null;uniqueIndex"`
+		StudentName string `gorm:"type:varchar(255);not null"`
+		NISN        string `gorm:"type:varchar(255);not null"`
+		SchoolName  string `gorm:"type:varchar(150);not null"`
+		Npsn        string `gorm:"type:varchar(12);not null"`
+		File        string `gorm:"type:varchar(150);not null"`
+		IssuedAt    time.Time
+	}
+	ReqLetterTemplate struct {
+		Title       string `json:"title" validate:"required,max=100"`
+		Body        string `json:"body" validate:"required,max=3000"`
+		SignerName  string `json:"signer_name" validate:"max=100"`
+		SignerTitle string `json:"signer_title" validate:"required,max=100"`
+	}
+	ResLetterTemplate struct {
+		Title       string `json:"title"`
+		Body        string `json:"body"`
+		SignerName  string `json:"signer_name"`
+		SignerTitle string `json:"signer_title"`
+	}
+	ResLetterVerification struct {
+		Reference   string    `json:"reference"`
+		StudentName string    `json:"student_name"`
+		NISN        string    `json:"nisn"`
+		SchoolName  string    `json:"school_name"`
+		Npsn        string    `json:"npsn"`
+		IssuedAt    time.Time `json:"issued_at"`
+	}
+	// SchoolMember is a staff account of a school, a user works at a single school and its role
+	// decides what it may manage there.
+	SchoolMember struct {
+		ID        uint   `gorm:"primaryKey;autoIncrement;not null"`
+		SchoolID  uint   `gorm:"not null;index"`
+		UserID    uint   `gorm:"not null;uniqueIndex"`
+		Role      string `gorm:"type:varchar(20);not null"`
+		CreatedAt time.Time
+		School    School
+		User      User
+	}
+	// SchoolInvitation is sent by email to a future member, only the hash of its token is stored.
+	SchoolInvitation struct {
+		ID        uint   `gorm:"primaryKey;autoIncrement;not null"`
+		SchoolID  uint   `gorm:"not null;index"`
+		Email     string `gorm:"type:varchar(255);not null"`
+		Role      string `gorm:"type:varchar(20);not null"`
+		TokenHash string `gorm:"type:varchar(64);not null;uniqueIndex"`
+		InvitedBy uint   `gorm:"not null"`
+		ExpiresAt time.Time
+		CreatedAt time.Time
+		School    School
+	}
+	ReqInviteMember struct {
+		Email string `json:"email" validate:"required,email"`
+		Role  string `json:"role" validate:"required,oneof=owner admissions finance content"`
+	}
+	ReqUpdateMember struct {
+		Role string `json:"role" validate:"required,oneof=owner admissions finance content"`
+	}
+	ResMember struct {
+		ID        int       `json:"id"`
+		UserID    int       `json:"user_id"`
+		Username  string    `json:"username"`
+		Name      string    `json:"name"`
+		Email     string    `json:"email"`
+		Role      string    `json:"role"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	ResInvitation struct {
+		ID        int       `json:"id"`
+		Email     string    `json:"email"`
+		Role      string    `json:"role"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	ReqCreateAppeal struct {
+		ProgressId    int    `form:"progress_id" validate:"required"`
+		Justification string `form:"justification" validate:"required,min=20,max=2000"`
+		// Attachments are the names of the uploaded files, they are set by the handler and never bound.
+		Attachments []string `form:"-"`
+	}
+	ReqRespondOffer struct {
+		Decision string `json:"decision" validate:"required,oneof=accept decline"`
+		Reason   string `json:"reason" validate:"max=255"`
+	}
+	ReqWithdraw struct {
+		Reason string `json:"reason" validate:"max=255"`
+	}
+	ReqDecideAppeal struct {
+		Decision       string `json:"decision" validate:"required,oneof=accept deny"`
+		ProgressStatus string `json:"progress_status"`
+		Reason         string `json:"reason" validate:"max=500"`
+	}
+	ResAppeal struct {
+		Id            int        `json:"id"`
+		ProgressId    int        `json:"progress_id"`
+		UserId        int        `json:"user_id"`
+		FromStatus    string     `json:"from_status"`
+		Justification string     `json:"justification"`
+		Attachments   []string   `json:"attachments"`
+		Status        string     `json:"status"`
+		ReopenStatus  string     `json:"reopen_status,omitempty"`
+		Response      string     `json:"response,omitempty"`
+		CreatedAt     time.Time  `json:"created_at"`
+		DecidedAt     *time.Time `json:"decided_at,omitempty"`
+	}
+	ReqSelectionCriterion struct {
+		Criterion string  `json:"criterion" validate:"required,oneof=test_score report_average distance achievements"`
+		Weight    float64 `json:"weight" validate:"gt=0,lte=100"`
+	}
+	ReqUpdateSelection struct {
+		Criteria []ReqSelectionCriterion `json:"criteria" validate:"required,min=1,max=4,dive"`
+	}
+	ResSelectionCriterion struct {
+		Criterion string  `json:"criterion"`
+		Weight    float64 `json:"weight"`
+	}
+	ReqAdvanceSelection struct {
+		Top    int    `json:"top" validate:"required,min=1,max=100"`
+		Action string `json:"action" validate:"required,oneof=approve send_test_link finish"`
+		Note   string `json:"note" validate:"max=500"`
+	}
+	ResSelectionRank struct {
+		Rank         int                `json:"rank"`
+		ProgressId   int                `json:"progress_id"`
+		SubmissionId int                `json:"submission_id"`
+		UserId       int                `json:"user_id"`
+		StudentName  string             `json:"student_name"`
+		Status       string             `json:"progress_status"`
+		Score        float64            `json:"score"`
+		Scores       map[string]float64 `json:"scores"`
+	}
+	ResQuota struct {
+		Track    string `json:"track"`
+		Capacity int    `json:"capacity"`
+		Used     int    `json:"used"`
+	}
+	ResWaitlist struct {
+		Rank         int       `json:"rank"`
+		ProgressId   int       `json:"progress_id"`
+		UserId       int       `json:"user_id"`
+		Track        string    `json:"track"`
+		WaitlistedAt time.Time `json:"waitlisted_at"`
 	}
 
 	Submission struct {
@@ -63,9 +305,44 @@ type (
 		ParentSignature  string `gorm:"type:varchar(255);not null"`
 		StudentSignature string `gorm:"type:varchar(255);not null"`
 		Date             string `gorm:"type:varchar(255);not null"`
+		Track            string `gorm:"type:varchar(20)"`
+		PeriodID         uint
+		StudentLatitude  *float64
+		StudentLongitude *float64
+		ReportAverage    *float64
+		Achievements     int
+		Snapshot         string `gorm:"type:text"`
 		School           School
 		User             User
 	}
+	ReqReviseSubmission struct {
+		StudentPhoto     string
+		StudentName      string `form:"student_name"`
+		PlaceDate        string `form:"place_date"`
+		Gender           string `form:"gender"`
+		Religion         string `form:"religion"`
+		GraduationFrom   string `form:"graduation_from"`
+		NISN             string `form:"nisn"`
+		StudentProvince  string `form:"student_province"`
+		StudentDistrict  string `form:"student_district"`
+		StudentVillage   string `form:"student_village"`
+		StudentZipCode   string `form:"student_zip_code"`
+		StudentCity      string `form:"student_city"`
+		StudentDetail    string `form:"student_detail"`
+		ParentProvince   string `form:"parent_province"`
+		ParentDistrict   string `form:"parent_district"`
+		ParentVillage    string `form:"parent_village"`
+		ParentZipCode    string `form:"parent_zip_code"`
+		ParentCity       string `form:"parent_city"`
+		ParentDetail     string `form:"parent_detail"`
+		ParentName       string `form:"parent_name"`
+		ParentGender     string `form:"parent_gender"`
+		ParentJob        string `form:"parent_job"`
+		ParentReligion   string `form:"parent_religion"`
+		ParentPhone      string `form:"parent_phone"`
+		ParentSignature  string
+		StudentSignature string
+	}
 	ReqAdressSubmission struct {
 		Province string `json:"province" `
 		District string `json:"district" `
@@ -89,45 +366,154 @@ type (
 		ResultLink string       `json:"result_link"`
 		Data       []ReqAddQuiz `json:"data"`
 	}
+	// ReqProfileSubmission registers at a school with the student profile, the school specific values
+	// replace the profile ones when they are given.
+	ReqProfileSubmission struct {
+		SchoolID      int      `json:"school_id" validate:"required"`
+		Track         string   `json:"track" validate:"omitempty,oneof=zonasi prestasi afirmasi transfer"`
+		ReportAverage *float64 `json:"report_average" validate:"omitempty,min=0,max=100"`
+		Achievements  *int     `json:"achievements" validate:"omitempty,min=0,max=50"`
+	}
+	// SubmissionSnapshot is stored with a submission as it was sent, the submission fields may be revised later.
+	SubmissionSnapshot struct {
+		ProfileID        uint        `json:"profile_id,omitempty"`
+		StudentData      StudentData `json:"student_data"`
+		ParentData       ParentData  `json:"parent_data"`
+		StudentSignature string      `json:"student_signature"`
+		ParentSignature  string      `json:"parent_signature"`
+		Track            string      `json:"track,omitempty"`
+		ReportAverage    *float64    `json:"report_average,omitempty"`
+		Achievements     int         `json:"achievements"`
+		SubmittedAt      time.Time   `json:"submitted_at"`
+	}
 	ReqCreateSubmission struct {
 		UserID           uint
-		SchoolID         int    `form:"school_id" validate:"required"`
-		StudentPhoto     string `form:"student_photo" validate:"required"`
-		StudentName      string `form:"student_name" validate:"required"`
-		PlaceDate        string `form:"place_date" validate:"required"`
-		Gender           string `form:"gender" validate:"required"`
-		Religion         string `form:"religion" validate:"required"`
-		GraduationFrom   string `form:"graduation_from" validate:"required"`
-		NISN             string `form:"nisn" validate:"required"`
-		StudentProvince  string `form:"student_province" validate:"required"`
-		StudentDistrict  string `form:"student_district" validate:"required"`
-		StudentVillage   string `form:"student_village" validate:"required"`
-		StudentZipCode   string `form:"student_zip_code" validate:"required"`
-		StudentCity      string `form:"student_city" validate:"required"`
-		StudentDetail    string `form:"student_detail" validate:"required"`
-		ParentProvince   string `form:"parent_province" validate:"required"`
-		ParentDistrict   string `form:"parent_district" validate:"required"`
-		ParentVillage    string `form:"parent_village" validate:"required"`
-		ParentZipCode    string `form:"parent_zip_code" validate:"required"`
-		ParentCity       string `form:"parent_city" validate:"required"`
-		ParentDetail     string `form:"parent_detail" validate:"required"`
-		ParentName       string `form:"parent_name" validate:"required"`
-		ParentGender     string `form:"parent_gender" validate:"required"`
-		ParentJob        string `form:"parent_job" validate:"required"`
-		ParentReligion   string `form:"parent_religion" validate:"required"`
-		ParentPhone      string `form:"parent_phone" validate:"required"`
-		ParentSignature  string `form:"parent_signature" validate:"required"`
-		StudentSignature string `form:"student_signature" validate:"required"`
-		Date             string `form:"date"`
+		SchoolID         int      `form:"school_id" validate:"required"`
+		StudentPhoto     string   `form:"student_photo" validate:"required"`
+		StudentName      string   `form:"student_name" validate:"required"`
+		PlaceDate        string   `form:"place_date" validate:"required"`
+		Gender           string   `form:"gender" validate:"required"`
+		Religion         string   `form:"religion" validate:"required"`
+		GraduationFrom   string   `form:"graduation_from" validate:"required"`
+		NISN             string   `form:"nisn" validate:"required"`
+		StudentProvince  string   `form:"student_province" validate:"required"`
+		StudentDistrict  string   `form:"student_district" validate:"required"`
+		StudentVillage   string   `form:"student_village" validate:"required"`
+		StudentZipCode   string   `form:"student_zip_code" validate:"required"`
+		StudentCity      string   `form:"student_city" validate:"required"`
+		StudentDetail    string   `form:"student_detail" validate:"required"`
+		ParentProvince   string   `form:"parent_province" validate:"required"`
+		ParentDistrict   string   `form:"parent_district" validate:"required"`
+		ParentVillage    string   `form:"parent_village" validate:"required"`
+		ParentZipCode    string   `form:"parent_zip_code" validate:"required"`
+		ParentCity       string   `form:"parent_city" validate:"required"`
+		ParentDetail     string   `form:"parent_detail" validate:"required"`
+		ParentName       string   `form:"parent_name" validate:"required"`
+		ParentGender     string   `form:"parent_gender" validate:"required"`
+		ParentJob        string   `form:"parent_job" validate:"required"`
+		ParentReligion   string   `form:"parent_religion" validate:"required"`
+		ParentPhone      string   `form:"parent_phone" validate:"required"`
+		ParentSignature  string   `form:"parent_signature" validate:"required"`
+		StudentSignature string   `form:"student_signature" validate:"required"`
+		Date             string   `form:"date"`
+		Track            string   `form:"track" validate:"omitempty,oneof=zonasi prestasi afirmasi transfer"`
+		ReportAverage    *float64 `form:"report_average" validate:"omitempty,min=0,max=100"`
+		Achievements     int      `form:"achievements" validate:"min=0,max=50"`
 	}
 	Progress struct {
-		ID        uint `gorm:"primaryKey;autoIncrement;not null"`
-		UserID    uint
-		SchoolID  uint
-		Status    string
-		DeletedAt gorm.DeletedAt `gorm:"index"`
-		School    School
-		User      User
+		ID              uint `gorm:"primaryKey;autoIncrement;not null"`
+		UserID          uint
+		SchoolID        uint
+		Status          string
+		RejectionReason string `gorm:"type:varchar(50)"`
+		RevisionFields  string `gorm:"type:varchar(255)"`
+		Track           string `gorm:"type:varchar(20)"`
+		WaitlistedAt    *time.Time
+		PeriodID        uint `gorm:"index"`
+		UpdatedAt       time.Time
+		DeletedAt       gorm.DeletedAt `gorm:"index"`
+		School          School
+		User            User
+		Events          []ProgressEvent
+		Notes           []AdmissionNote
+	}
+	AdmissionNote struct {
+		ID         uint   `gorm:"primaryKey;autoIncrement;not null"`
+		ProgressID uint   `gorm:"not null;index"`
+		UserID     uint   `gorm:"not null"`
+		Note       string `gorm:"type:varchar(500);not null"`
+		// Internal notes are only visible to the school admin.
+		Internal  bool `gorm:"not null"`
+		CreatedAt time.Time
+	}
+	ReqUpdateProgress struct {
+		ProgressStatus  string   `json:"progress_status" validate:"required"`
+		Reason          string   `json:"reason"`
+		RejectionReason string   `json:"rejection_reason"`
+		Note            string   `json:"note" validate:"max=500"`
+		RevisionFields  []string `json:"revision_fields"`
+	}
+	ReqBulkProgress struct {
+		ProgressIds     []int  `json:"progress_ids" validate:"required,min=1,max=100"`
+		Action          string `json:"action" validate:"required,oneof=approve reject send_test_link finish"`
+		Reason          string `json:"reason"`
+		RejectionReason string `json:"rejection_reason"`
+		Note            string `json:"note" validate:"max=500"`
+	}
+	ReqAddNote struct {
+		Note     string `json:"note" validate:"required,max=500"`
+		Internal bool   `json:"internal"`
+	}
+	ResAdmissionNote struct {
+		Id        int       `json:"id"`
+		Note      string    `json:"note"`
+		Internal  bool      `json:"internal"`
+		AuthorID  int       `json:"author_id"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	ProgressEvent struct {
+		ID         uint   `gorm:"primaryKey;autoIncrement;not null"`
+		ProgressID uint   `gorm:"not null;index"`
+		FromStatus string `gorm:"type:varchar(50)"`
+		ToStatus   string `gorm:"type:varchar(50);not null"`
+		// ActorID is 0 when the move was made by the system.
+		ActorID   uint
+		Reason    string `gorm:"type:varchar(255)"`
+		CreatedAt time.Time
+	}
+	ResProgressEvent struct {
+		FromStatus string    `json:"from_status"`
+		ToStatus   string    `json:"to_status"`
+		ActorID    int       `json:"actor_id"`
+		Reason     string    `json:"reason"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+	ResBulkProgress struct {
+		ProgressID     int    `json:"progress_id"`
+		Success        bool   `json:"success"`
+		ProgressStatus string `json:"progress_status,omitempty"`
+		Message        string `json:"message,omitempty"`
+	}
+	// Applicant is an active participant of a school as used by the zonasi ranking.
+	Applicant struct {
+		ProgressID   uint
+		SubmissionID uint
+		UserID       uint
+		StudentName  string
+		Track        string
+		Status       string
+		Latitude     *float64
+		Longitude    *float64
+	}
+	ResZonasiRank struct {
+		Rank         int      `json:"rank"`
+		ProgressId   int      `json:"progress_id"`
+		SubmissionId int      `json:"submission_id"`
+		UserId       int      `json:"user_id"`
+		StudentName  string   `json:"student_name"`
+		Track        string   `json:"track"`
+		Status       string   `json:"progress_status"`
+		Distance     *float64 `json:"distance_km"`
 	}
 	ResAllProgress struct {
 		SchoolName  string `json:"school_name"`
@@ -136,8 +522,12 @@ type (
 		ProgressId  int    `json:"progress_id"`
 	}
 	ResDetailProgress struct {
-		Id     int    `json:"progress_id"`
-		Status string `json:"progress_status"`
+		Id              int                `json:"progress_id"`
+		Status          string             `json:"progress_status"`
+		Pipeline        []string           `json:"pipeline"`
+		RejectionReason string             `json:"rejection_reason,omitempty"`
+		RevisionFields  []string           `json:"revision_fields,omitempty"`
+		Notes           []ResAdmissionNote `json:"notes"`
 	}
 	ResAllProgressSubmission struct {
 		UserId         int    `json:"user_id"`
@@ -167,12 +557,16 @@ type (
 		Adress   ReqAdressSubmission `json:"address"`
 	}
 	ResDetailSubmission struct {
-		StudentData      StudentData `json:"student_data"`
-		ParentData       ParentData  `json:"parent_data"`
-		ParentSignature  string      `json:"parent_signature"`
-		StudentSignature string      `json:"student_signature"`
-		DatePlace        string      `json:"date_place"`
-		SchoolName       string      `json:"school_name"`
+		StudentData      StudentData         `json:"student_data"`
+		ParentData       ParentData          `json:"parent_data"`
+		ParentSignature  string              `json:"parent_signature"`
+		StudentSignature string              `json:"student_signature"`
+		DatePlace        string              `json:"date_place"`
+		SchoolName       string              `json:"school_name"`
+		ProgressStatus   string              `json:"progress_status"`
+		RejectionReason  string              `json:"rejection_reason,omitempty"`
+		Notes            []ResAdmissionNote  `json:"notes"`
+		Snapshot         *SubmissionSnapshot `json:"snapshot,omitempty"`
 	}
 	Achievement struct {
 		gorm.Model
@@ -322,6 +716,8 @@ type (
 		QuizLinkPreview  string        `json:"quizLinkPreview,omitempty"`
 		WaLink           string        `json:"wa_link,omitempty"`
 		Phone            string        `json:"phone"`
+		Latitude         *float64      `json:"latitude,omitempty"`
+		Longitude        *float64      `json:"longitude,omitempty"`
 		Achievements     []ResAddItems `json:"achievements"`
 		Extracurriculars []ResAddItems `json:"extracurriculars"`
 		ResPayment       ResPayment    `json:"payments"`
@@ -375,12 +771,14 @@ type (
 		QuizLinkPreview string `json:"quizLinkPreview"`
 	}
 	Location struct {
-		Province string `json:"province"`
-		City     string `json:"city"`
-		District string `json:"district"`
-		Village  string `json:"village"`
-		Detail   string `json:"detail"`
-		ZipCode  string `json:"zipcode"`
+		Province  string   `json:"province"`
+		City      string   `json:"city"`
+		District  string   `json:"district"`
+		Village   string   `json:"village"`
+		Detail    string   `json:"detail"`
+		ZipCode   string   `json:"zipcode"`
+		Latitude  *float64 `json:"latitude,omitempty"`
+		Longitude *float64 `json:"longitude,omitempty"`
 	}
 	ResUpdateSchool struct {
 		Id            int      `json:"id"`
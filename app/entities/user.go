@@ -1,37 +1,209 @@
 package entities
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type (
 	User struct {
-		gorm.Model       `json:"-"`
-		Username         string `gorm:"type:varchar(30);not null" json:"username,omitempty"`
-		FirstName        string `gorm:"type:varchar(30);not null" json:"fname,omitempty"`
-		SureName         string `gorm:"type:varchar(30);not null" json:"sname,omitempty"`
-		Email            string `gorm:"type:varchar(255);not null" json:"email,omitempty"`
-		Password         string `gorm:"type:varchar(80);not null" json:"password,omitempty"`
-		Address          string `gorm:"type:varchar(255);not null" json:"address,omitempty"`
-		Image            string `gorm:"type:varchar(255);not null;default:default.jpg" json:"image,omitempty"`
-		Role             string `gorm:"not null" json:"-"`
-		IsVerified       bool   `gorm:"not null" json:"-"`
-		VerificationCode string `gorm:"not null" json:"-"`
-		School           School
-		Progresses       []Progress
-		Submission       []Submission
-		Reviews          []Reviews
-		Carts            []Carts
+		gorm.Model     `json:"-"`
+		Username       string `gorm:"type:varchar(30);not null" json:"username,omitempty"`
+		FirstName      string `gorm:"type:varchar(30);not null" json:"fname,omitempty"`
+		SureName       string `gorm:"type:varchar(30);not null" json:"sname,omitempty"`
+		Email          string `gorm:"type:varchar(255);not null" json:"email,omitempty"`
+		Password       string `gorm:"type:varchar(80);not null" json:"password,omitempty"`
+		Address        string `gorm:"type:varchar(255);not null" json:"address,omitempty"`
+		Image          string `gorm:"type:varchar(255);not null;default:default.jpg" json:"image,omitempty"`
+		Role           string `gorm:"not null" json:"-"`
+		IsVerified     bool   `gorm:"not null" json:"-"`
+		TOTPSecret     string `gorm:"type:varchar(64)" json:"-"`
+		TOTPEnabled    bool   `gorm:"not null;default:false" json:"-"`
+		TOTPStep       int64  `gorm:"not null;default:0" json:"-"`
+		School         School
+		Progresses     []Progress
+		Submission     []Submission
+		Reviews        []Reviews
+		Carts          []Carts
+		StudentProfile *StudentProfile `json:"-"`
+	}
+	// StudentProfile keeps the data a student fills in once to prefill every submission,
+	// the addresses are stored like the submission ones and the documents are stored file names.
+	StudentProfile struct {
+		ID               uint   `gorm:"primaryKey;autoIncrement;not null"`
+		UserID           uint   `gorm:"not null;uniqueIndex"`
+		StudentName      string `gorm:"type:varchar(255);not null"`
+		PlaceDate        string `gorm:"type:varchar(255);not null"`
+		Gender           string `gorm:"type:varchar(255);not null"`
+		Religion         string `gorm:"type:varchar(255);not null"`
+		GraduationFrom   string `gorm:"type:varchar(255);not null"`
+		NISN             string `gorm:"type:varchar(255);not null"`
+		StudentAddress   string `gorm:"type:varchar(255);not null"`
+		ParentName       string `gorm:"type:varchar(255);not null"`
+		ParentJob        string `gorm:"type:varchar(255);not null"`
+		ParentReligion   string `gorm:"type:varchar(255);not null"`
+		ParentGender     string `gorm:"type:varchar(255);not null"`
+		ParentAddress    string `gorm:"type:varchar(255);not null"`
+		ParentPhone      string `gorm:"type:varchar(255);not null"`
+		StudentPhoto     string `gorm:"type:varchar(255)"`
+		StudentSignature string `gorm:"type:varchar(255)"`
+		ParentSignature  string `gorm:"type:varchar(255)"`
+		ReportAverage    *float64
+		Achievements     int
+		UpdatedAt        time.Time
+	}
+	// ParentLink links a parent account to a student account, it is pending until the student accepts it.
+	ParentLink struct {
+		ID         uint   `gorm:"primaryKey;autoIncrement;not null"`
+		ParentID   uint   `gorm:"not null;uniqueIndex:idx_parent_student"`
+		StudentID  uint   `gorm:"not null;uniqueIndex:idx_parent_student;index"`
+		Status     string `gorm:"type:varchar(10);not null"`
+		CreatedAt  time.Time
+		AcceptedAt *time.Time
+		Parent     User `gorm:"foreignKey:ParentID"`
+		Student    User `gorm:"foreignKey:StudentID"`
+	}
+	// RecoveryCode lets a user pass the second step of the login without the authenticator app,
+	// only the hash of the code is kept and it can be used once.
+	RecoveryCode struct {
+		ID       uint   `gorm:"primaryKey;autoIncrement;not null"`
+		UserID   uint   `gorm:"not null;index"`
+		CodeHash string `gorm:"type:varchar(64);not null"`
+		UsedAt   *time.Time
+	}
+	// UserIdentity links a user to an account at an OpenID Connect provider, the subject is the id
+	// the provider gives the user and it is what the login looks up, the email is only kept for reference.
+	UserIdentity struct {
+		ID        uint   `gorm:"primaryKey;autoIncrement;not null"`
+		UserID    uint   `gorm:"not null;index"`
+		Provider  string `gorm:"type:varchar(255);not null;uniqueIndex:idx_provider_subject"`
+		Subject   string `gorm:"type:varchar(255);not null;uniqueIndex:idx_provider_subject"`
+		Email     string `gorm:"type:varchar(255);not null"`
+		CreatedAt time.Time
+	}
+	// ErasureRequest is queued when a user deletes the account, the eraser anonymizes the personal data
+	// and deletes the stored files later on. The transactions are kept for the accounting.
+	ErasureRequest struct {
+		ID          uint   `gorm:"primaryKey;autoIncrement;not null"`
+		UserID      uint   `gorm:"not null;uniqueIndex"`
+		Status      string `gorm:"type:varchar(10);not null;index"`
+		Attempts    int    `gorm:"not null;default:0"`
+		LastError   string `gorm:"type:varchar(255)"`
+		RequestedAt time.Time
+		ErasedAt    *time.Time
+	}
+	// UserData is everything kept about a user, it is what the export holds and what the erasure clears.
+	UserData struct {
+		User           User
+		StudentProfile *StudentProfile
+		ParentLinks    []ParentLink
+		Identities     []UserIdentity
+		Submissions    []Submission
+		Progresses     []Progress
+		Appeals        []Appeal
+		Letters        []AcceptanceLetter
+		Transactions   []Transaction
+		Reviews        []Reviews
+	}
+	ReqSocialLogin struct {
+		State string `json:"state" validate:"required"`
+		Code  string `json:"code" validate:"required"`
+	}
+	ReqTwoFactorCode struct {
+		Code string `json:"code" validate:"required"`
+	}
+	ReqLoginTwoFactor struct {
+		Challenge string `json:"challenge" validate:"required"`
+		Code      string `json:"code" validate:"required"`
+	}
+	ResTwoFactorSetup struct {
+		Secret string `json:"secret"`
+		URI    string `json:"uri"`
+	}
+	ResRecoveryCodes struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	ReqInviteChild struct {
+		Student string `json:"student" validate:"required"`
+	}
+	ReqRespondParent struct {
+		Accept *bool `json:"accept" validate:"required"`
+	}
+	ResParentLink struct {
+		ID         int        `json:"id"`
+		UserID     int        `json:"user_id"`
+		Username   string     `json:"username"`
+		Name       string     `json:"name"`
+		Status     string     `json:"status"`
+		CreatedAt  time.Time  `json:"created_at"`
+		AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	}
+	ReqStudentProfile struct {
+		StudentName      string   `form:"student_name" validate:"required"`
+		PlaceDate        string   `form:"place_date" validate:"required"`
+		Gender           string   `form:"gender" validate:"required"`
+		Religion         string   `form:"religion" validate:"required"`
+		GraduationFrom   string   `form:"graduation_from" validate:"required"`
+		NISN             string   `form:"nisn" validate:"required"`
+		StudentProvince  string   `form:"student_province" validate:"required"`
+		StudentDistrict  string   `form:"student_district" validate:"required"`
+		StudentVillage   string   `form:"student_village" validate:"required"`
+		StudentZipCode   string   `form:"student_zip_code" validate:"required"`
+		StudentCity      string   `form:"student_city" validate:"required"`
+		StudentDetail    string   `form:"student_detail" validate:"required"`
+		ParentProvince   string   `form:"parent_province" validate:"required"`
+		ParentDistrict   string   `form:"parent_district" validate:"required"`
+		ParentVillage    string   `form:"parent_village" validate:"required"`
+		ParentZipCode    string   `form:"parent_zip_code" validate:"required"`
+		ParentCity       string   `form:"parent_city" validate:"required"`
+		ParentDetail     string   `form:"parent_detail" validate:"required"`
+		ParentName       string   `form:"parent_name" validate:"required"`
+		ParentGender     string   `form:"parent_gender" validate:"required"`
+		ParentJob        string   `form:"parent_job" validate:"required"`
+		ParentReligion   string   `form:"parent_religion" validate:"required"`
+		ParentPhone      string   `form:"parent_phone" validate:"required"`
+		ReportAverage    *float64 `form:"report_average" validate:"omitempty,min=0,max=100"`
+		Achievements     int      `form:"achievements" validate:"min=0,max=50"`
+		StudentPhoto     string
+		StudentSignature string
+		ParentSignature  string
+	}
+	ResStudentProfile struct {
+		StudentData      StudentData `json:"student_data"`
+		ParentData       ParentData  `json:"parent_data"`
+		StudentSignature string      `json:"student_signature"`
+		ParentSignature  string      `json:"parent_signature"`
+		ReportAverage    *float64    `json:"report_average,omitempty"`
+		Achievements     int         `json:"achievements"`
+		UpdatedAt        time.Time   `json:"updated_at"`
 	}
 
+	// ForgotPass and EmailVerification are the tokens mailed to the user, only their hash is kept
+	// and a token stops working once used, expired or replaced by a newer one.
 	ForgotPass struct {
-		Token     string
-		Email     string
-		DeletedAt gorm.DeletedAt `gorm:"index"`
+		ID        uint      `gorm:"primaryKey;autoIncrement;not null"`
+		Email     string    `gorm:"type:varchar(255);not null;index"`
+		TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+		ExpiresAt time.Time `gorm:"not null"`
+		UsedAt    *time.Time
+		CreatedAt time.Time
+	}
+	EmailVerification struct {
+		ID        uint      `gorm:"primaryKey;autoIncrement;not null"`
+		Email     string    `gorm:"type:varchar(255);not null;index"`
+		TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+		ExpiresAt time.Time `gorm:"not null"`
+		UsedAt    *time.Time
+		CreatedAt time.Time
 	}
+	// LoginReq carries the captcha once the login of the username or of the ip failed a few times.
 	LoginReq struct {
-		Username string `json:"username" validate:"required"`
-		Password string `json:"password" validate:"required"`
+		Username  string `json:"username" validate:"required"`
+		Password  string `json:"password" validate:"required"`
+		CaptchaID string `json:"captcha_id"`
+		Captcha   string `json:"captcha"`
+		IP        string `json:"-"`
 	}
 	RegisterReq struct {
 		Email     string `json:"email" validate:"required"`
@@ -1,12 +1,18 @@
 package features
 
 import (
+	"github.com/education-hub/BE/app/admission"
+	"github.com/education-hub/BE/app/audit"
+	"github.com/education-hub/BE/app/authz"
 	schoolrepo "github.com/education-hub/BE/app/features/school/repository"
 	schoolserv "github.com/education-hub/BE/app/features/school/service"
 	trxrepo "github.com/education-hub/BE/app/features/transaction/repository"
 	trxserv "github.com/education-hub/BE/app/features/transaction/service"
 	userrepo "github.com/education-hub/BE/app/features/user/repository"
 	userserv "github.com/education-hub/BE/app/features/user/service"
+	"github.com/education-hub/BE/app/oidc"
+	"github.com/education-hub/BE/app/session"
+	"github.com/education-hub/BE/app/throttle"
 	"go.uber.org/dig"
 )
 
@@ -20,13 +26,64 @@ func RegisterRepo(C *dig.Container) error {
 	if err := C.Provide(trxrepo.NewTransactionRepo); err != nil {
 		return err
 	}
+	if err := C.Provide(func(repo schoolrepo.SchoolRepo) admission.Store { return repo }); err != nil {
+		return err
+	}
+	if err := C.Provide(func(repo userrepo.UserRepo) admission.Users { return repo }); err != nil {
+		return err
+	}
+	if err := C.Provide(func(repo schoolrepo.SchoolRepo) authz.Store { return repo }); err != nil {
+		return err
+	}
+	if err := C.Provide(func(repo userrepo.UserRepo) session.Users { return repo }); err != nil {
+		return err
+	}
+	if err := C.Provide(session.NewRedisStore); err != nil {
+		return err
+	}
+	if err := C.Provide(throttle.NewRedisStore); err != nil {
+		return err
+	}
+	if err := C.Provide(oidc.NewRedisStore); err != nil {
+		return err
+	}
+	if err := C.Provide(audit.NewGormStore); err != nil {
+		return err
+	}
 	return nil
 }
 
 func RegisterService(C *dig.Container) error {
+	if err := C.Provide(audit.NewLog); err != nil {
+		return err
+	}
+	if err := C.Provide(admission.NewWorkflow); err != nil {
+		return err
+	}
+	if err := C.Provide(admission.NewSweeper); err != nil {
+		return err
+	}
+	if err := C.Provide(authz.NewAuthorizer); err != nil {
+		return err
+	}
+	if err := C.Provide(session.NewManager); err != nil {
+		return err
+	}
+	if err := C.Provide(throttle.NewGuard); err != nil {
+		return err
+	}
+	if err := C.Provide(oidc.NewProvider); err != nil {
+		return err
+	}
+	if err := C.Provide(oidc.NewFlow); err != nil {
+		return err
+	}
 	if err := C.Provide(userserv.NewUserService); err != nil {
 		return err
 	}
+	if err := C.Provide(userserv.NewEraser); err != nil {
+		return err
+	}
 	if err := C.Provide(schoolserv.NewSchoolService); err != nil {
 		return err
 	}
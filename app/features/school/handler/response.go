@@ -34,5 +34,8 @@ func CreateErrorResponse(err error, c echo.Context) error {
 	if err, ok := err.(errorr.BadRequest); ok {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, err.Error(), nil))
 	}
+	if err, ok := err.(errorr.Forbidden); ok {
+		return c.JSON(http.StatusForbidden, CreateWebResponse(http.StatusForbidden, err.Error(), nil))
+	}
 	return c.JSON(http.StatusInternalServerError, CreateWebResponse(http.StatusInternalServerError, err.Error(), nil))
 }
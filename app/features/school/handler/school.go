@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/education-hub/BE/app/authz"
 	entity "github.com/education-hub/BE/app/entities"
 	"github.com/education-hub/BE/app/features/school/service"
 	"github.com/education-hub/BE/config/dependency"
@@ -110,7 +111,7 @@ func (u *School) Update(c echo.Context) error {
 		req.Image = imagefile.Filename
 		image = fimage
 	}
-	res, err := u.Service.Update(c.Request().Context(), req, image, pdf)
+	res, err := u.Service.Update(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req, image, pdf)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -204,7 +205,7 @@ func (u *School) AddAchievement(c echo.Context) error {
 	if filehead.Size > 2*1024*1024 {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "File is too large. Maximum size is 2MB.", nil))
 	}
-	id, err := u.Service.AddAchievement(c.Request().Context(), req, image)
+	id, err := u.Service.AddAchievement(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req, image)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -229,7 +230,7 @@ func (u *School) UpdateAchievement(c echo.Context) error {
 		req.Image = filehead.Filename
 		image = multipart
 	}
-	schoolid, err := u.Service.UpdateAchievement(c.Request().Context(), req, image)
+	schoolid, err := u.Service.UpdateAchievement(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req, image)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -245,7 +246,7 @@ func (u *School) DeleteAchievement(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Achievement Id", nil))
 	}
-	if err := u.Service.DeleteAchievement(c.Request().Context(), newid); err != nil {
+	if err := u.Service.DeleteAchievement(c.Request().Context(), newid, helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
 	}
@@ -270,7 +271,7 @@ func (u *School) AddExtracurricular(c echo.Context) error {
 	if filehead.Size > 2*1024*1024 {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "File is too large. Maximum size is 2MB.", nil))
 	}
-	id, err := u.Service.AddExtracurricular(c.Request().Context(), req, image)
+	id, err := u.Service.AddExtracurricular(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req, image)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -295,7 +296,7 @@ func (u *School) UpdateExtracurricular(c echo.Context) error {
 		req.Image = filehead.Filename
 		image = multipart
 	}
-	schoolid, err := u.Service.UpdateExtracurricular(c.Request().Context(), req, image)
+	schoolid, err := u.Service.UpdateExtracurricular(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req, image)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -311,7 +312,7 @@ func (u *School) DeleteExtracurricular(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Extracurricular Id", nil))
 	}
-	if err := u.Service.DeleteExtracurricular(c.Request().Context(), newid); err != nil {
+	if err := u.Service.DeleteExtracurricular(c.Request().Context(), newid, helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
 	}
@@ -325,7 +326,7 @@ func (u *School) AddFaq(c echo.Context) error {
 		c.Set("err", err.Error())
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
 	}
-	id, err := u.Service.AddFaq(c.Request().Context(), req)
+	id, err := u.Service.AddFaq(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -340,7 +341,7 @@ func (u *School) UpdateFaq(c echo.Context) error {
 		u.Dep.Log.Errorf("[ERROR] WHEN BINDING REQUPDATEFaq, ERROR: %v", err)
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
 	}
-	schoolid, err := u.Service.UpdateFaq(c.Request().Context(), req)
+	schoolid, err := u.Service.UpdateFaq(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -356,7 +357,7 @@ func (u *School) DeleteFaq(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Faq Id", nil))
 	}
-	if err := u.Service.DeleteFaq(c.Request().Context(), newid); err != nil {
+	if err := u.Service.DeleteFaq(c.Request().Context(), newid, helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
 	}
@@ -374,12 +375,12 @@ func (u *School) GenerateUrl(c echo.Context) error {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
 	}
-	data, err := u.Service.GetByUid(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	schid, err := u.Service.Authorize(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), authz.ManageContent)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
 	}
-	if data.Id != req.SchoolId {
+	if schid != req.SchoolId {
 		c.Set("err", "School ID mismatch")
 		return CreateErrorResponse(errorr.NewBad("School ID mismatch"), c)
 	}
@@ -412,7 +413,7 @@ func (u *School) CreateGmeet(c echo.Context) error {
 	start_date := strings.Replace(data[1], ":00+07", "", 1)
 	end_date := strings.Replace(data[2], ":00+07", "", 1)
 	gmeetlink := u.Dep.Calendar.NewService(auth).Create(start_date, end_date, schooldata.Name)
-	_, err3 := u.Service.Update(c.Request().Context(), entity.ReqUpdateSchool{Id: schooldata.Id, Gmeet: gmeetlink, GmeetDate: strings.Replace(start_date, "+07:00", "", 1)}, nil, nil)
+	err3 := u.Service.SetGmeet(c.Request().Context(), schooldata.Id, gmeetlink, strings.Replace(start_date, "+07:00", "", 1))
 	if err3 != nil {
 		u.Dep.Log.Errorf("[ERROR]WHEN UPDATING SCHOOL DATA, err : %v", err3)
 	}
@@ -439,7 +440,7 @@ func (u *School) AddPayment(c echo.Context) error {
 	if filehead.Size > 2*1024*1024 {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "File is too large. Maximum size is 2MB.", nil))
 	}
-	id, err := u.Service.AddPayment(c.Request().Context(), req, image)
+	id, err := u.Service.AddPayment(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req, image)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -464,7 +465,7 @@ func (u *School) UpdatePayment(c echo.Context) error {
 		req.Image = filehead.Filename
 		image = multipart
 	}
-	schoolid, err := u.Service.UpdatePayment(c.Request().Context(), req, image)
+	schoolid, err := u.Service.UpdatePayment(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req, image)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -480,7 +481,7 @@ func (u *School) DeletePayment(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Payment Id", nil))
 	}
-	if err := u.Service.DeletePayment(c.Request().Context(), newid); err != nil {
+	if err := u.Service.DeletePayment(c.Request().Context(), newid, helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
 	}
@@ -543,6 +544,61 @@ func (u *School) CreateSubbmision(c echo.Context) error {
 	return c.JSON(http.StatusCreated, CreateWebResponse(http.StatusCreated, "StatusCreated", map[string]any{"id": res}))
 }
 
+func (u *School) CreateSubmissionFromProfile(c echo.Context) error {
+	req := entity.ReqProfileSubmission{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING CreateSubmissionFromProfile, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.CreateSubmissionFromProfile(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusCreated, CreateWebResponse(http.StatusCreated, "StatusCreated", map[string]any{"id": res}))
+}
+
+func (u *School) ReviseSubmission(c echo.Context) error {
+	subid := c.Param("id")
+	if subid == "" {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Submission Id is missing", nil))
+	}
+	newsubid, err := strconv.Atoi(subid)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Submission Id", nil))
+	}
+	req := entity.ReqReviseSubmission{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING ReviseSubmission, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	files := map[string]multipart.File{}
+	filenames := map[string]*string{"student_photo": &req.StudentPhoto, "student_signature": &req.StudentSignature, "parent_signature": &req.ParentSignature}
+	for field, filename := range filenames {
+		head, err := c.FormFile(field)
+		if err != nil {
+			continue
+		}
+		if head.Size > 2*1024*1024 {
+			return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "File is too large. Maximum size is 2MB.", nil))
+		}
+		file, err := head.Open()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Cannot laod image", nil))
+		}
+		*filename = head.Filename
+		files[field] = file
+	}
+	res, err := u.Service.ReviseSubmission(c.Request().Context(), newsubid, helper.GetUid(c.Get("user").(*jwt.Token)), req, files)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"progress_id": res}))
+}
+
 func (u *School) UpdateProgressByid(c echo.Context) error {
 	progid := c.Param("id")
 	if progid == "" {
@@ -552,9 +608,7 @@ func (u *School) UpdateProgressByid(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Progress Id", nil))
 	}
-	req := struct {
-		ProgressStatus string `json:"progress_status" validate:"required"`
-	}{}
+	req := entity.ReqUpdateProgress{}
 	if err := c.Bind(&req); err != nil {
 		c.Set("err", err.Error())
 		u.Dep.Log.Errorf("[ERROR] WHEN BINDING UpdateProgress Req, ERROR: %v", err)
@@ -563,7 +617,7 @@ func (u *School) UpdateProgressByid(c echo.Context) error {
 	if err := c.Validate(req); err != nil {
 		return CreateErrorResponse(err, c)
 	}
-	res, err := u.Service.UpdateProgressByid(c.Request().Context(), newprogid, req.ProgressStatus)
+	res, err := u.Service.UpdateProgressByid(c.Request().Context(), newprogid, helper.GetUid(c.Get("user").(*jwt.Token)), req)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -572,9 +626,27 @@ func (u *School) UpdateProgressByid(c echo.Context) error {
 
 }
 
+func (u *School) BulkUpdateProgress(c echo.Context) error {
+	req := entity.ReqBulkProgress{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING BulkUpdateProgress Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	if err := c.Validate(req); err != nil {
+		return CreateErrorResponse(err, c)
+	}
+	res, err := u.Service.BulkUpdateProgress(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Sucess Operation", res))
+}
+
 func (u *School) GetAllProgressByUid(c echo.Context) error {
 
-	res, err := u.Service.GetAllProgressByUid(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	res, err := u.Service.GetAllProgressByUid(c.Request().Context(), helper.GetStudentUid(c))
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -591,7 +663,52 @@ func (u *School) GetProgressById(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Progress Id", nil))
 	}
-	res, err := u.Service.GetProgressById(c.Request().Context(), newprogid)
+	token := c.Get("user").(*jwt.Token)
+	res, err := u.Service.GetProgressById(c.Request().Context(), newprogid, helper.GetUid(token), helper.GetRole(token))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+func (u *School) AddNote(c echo.Context) error {
+	progid := c.Param("id")
+	if progid == "" {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Progress Id is missing", nil))
+	}
+	newprogid, err := strconv.Atoi(progid)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Progress Id", nil))
+	}
+	req := entity.ReqAddNote{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING AddNote Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.AddNote(c.Request().Context(), newprogid, helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusCreated, CreateWebResponse(http.StatusCreated, "Success Operation", map[string]any{"id": res}))
+}
+
+func (u *School) GetProgressTimeline(c echo.Context) error {
+	progid := c.Param("id")
+	if progid == "" {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Progress Id is missing", nil))
+	}
+	newprogid, err := strconv.Atoi(progid)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Progress Id", nil))
+	}
+	token := c.Get("user").(*jwt.Token)
+	uid, role := helper.GetUid(token), helper.GetRole(token)
+	if _, ok := c.Get("child").(int); ok {
+		uid, role = helper.GetStudentUid(c), "student"
+	}
+	res, err := u.Service.GetProgressTimeline(c.Request().Context(), newprogid, uid, role)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -599,7 +716,7 @@ func (u *School) GetProgressById(c echo.Context) error {
 	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
 }
 func (u *School) GetAllAdmission(c echo.Context) error {
-	res, err := u.Service.GetAllProgressAndSubmissionByuid(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	res, err := u.Service.GetAllProgressAndSubmission(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -616,7 +733,7 @@ func (u *School) GetSubmissionByid(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Progress Id", nil))
 	}
-	res, err := u.Service.GetSubmissionByid(c.Request().Context(), newsubid)
+	res, err := u.Service.GetSubmissionByid(c.Request().Context(), newsubid, helper.GetUid(c.Get("user").(*jwt.Token)))
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -624,6 +741,21 @@ func (u *School) GetSubmissionByid(c echo.Context) error {
 	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
 }
 
+func (u *School) GetSubmissionPdf(c echo.Context) error {
+	subid, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Submission Id", nil))
+	}
+	token := c.Get("user").(*jwt.Token)
+	res, err := u.Service.GetSubmissionPdf(c.Request().Context(), subid, helper.GetUid(token), helper.GetRole(token))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=submission-%d.pdf", subid))
+	return c.Blob(http.StatusOK, "application/pdf", res)
+}
+
 func (u *School) AddReview(c echo.Context) error {
 	req := entity.Reviews{}
 	if err := c.Bind(&req); err != nil {
@@ -647,7 +779,7 @@ func (u *School) CreateQuiz(c echo.Context) error {
 		u.Dep.Log.Errorf("[ERROR] WHEN BINDING CreateQuiz, ERROR: %v", err)
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
 	}
-	err := u.Service.CreateQuiz(c.Request().Context(), req)
+	err := u.Service.CreateQuiz(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -694,9 +826,10 @@ func (u *School) GetBase64File(c echo.Context) error {
 	if filename == "" {
 		return CreateErrorResponse(errorr.NewBad("Filename is missing"), c)
 	}
-	base32, err := u.Dep.Gcp.GetFile(filename)
+	base32, err := u.Service.GetFile(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), filename)
 	if err != nil {
-		return CreateErrorResponse(errorr.NewBad("Data Not Found"), c)
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
 	}
 	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"file": base32}))
 }
@@ -725,8 +858,447 @@ func (u *School) DeleteProgressByid(c echo.Context) error {
 		c.Set("err", err.Error())
 		return CreateErrorResponse(errorr.NewBad("Invalid Progress Id"), c)
 	}
-	if err := u.Service.DeleteProgressByid(c.Request().Context(), newid); err != nil {
+	if err := u.Service.DeleteProgressByid(c.Request().Context(), newid, helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
 
+func (u *School) GetPipeline(c echo.Context) error {
+	res, err := u.Service.GetPipeline(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) UpdatePipeline(c echo.Context) error {
+	req := entity.ReqUpdatePipeline{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING UpdatePipeline Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.UpdatePipeline(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) GetQuotas(c echo.Context) error {
+	res, err := u.Service.GetQuotas(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) UpdateQuotas(c echo.Context) error {
+	req := entity.ReqUpdateQuota{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING UpdateQuotas Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.UpdateQuotas(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) GetWaitlist(c echo.Context) error {
+	res, err := u.Service.GetWaitlist(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) GetPeriods(c echo.Context) error {
+	res, err := u.Service.GetPeriods(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) CreatePeriod(c echo.Context) error {
+	req := entity.ReqAdmissionPeriod{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING CreatePeriod Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.CreatePeriod(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusCreated, CreateWebResponse(http.StatusCreated, "Success Operation", map[string]any{"id": res}))
+}
+
+func (u *School) UpdatePeriod(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Period Id", nil))
+	}
+	req := entity.ReqAdmissionPeriod{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING UpdatePeriod Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	if err := u.Service.UpdatePeriod(c.Request().Context(), id, helper.GetUid(c.Get("user").(*jwt.Token)), req); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
+
+func (u *School) DeletePeriod(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Period Id", nil))
+	}
+	if err := u.Service.DeletePeriod(c.Request().Context(), id, helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
 	}
 	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
 }
+
+func (u *School) GetZonasiRanking(c echo.Context) error {
+	res, err := u.Service.GetZonasiRanking(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), c.QueryParam("track"))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) GetSelectionCriteria(c echo.Context) error {
+	res, err := u.Service.GetSelectionCriteria(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) UpdateSelectionCriteria(c echo.Context) error {
+	req := entity.ReqUpdateSelection{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING UpdateSelectionCriteria Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.UpdateSelectionCriteria(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) GetSelection(c echo.Context) error {
+	res, err := u.Service.GetSelection(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) ExportSelection(c echo.Context) error {
+	res, err := u.Service.ExportSelection(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=selection.csv")
+	return c.Blob(http.StatusOK, "text/csv", res)
+}
+
+func (u *School) AdvanceSelection(c echo.Context) error {
+	req := entity.ReqAdvanceSelection{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING AdvanceSelection Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.AdvanceSelection(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) CreateAppeal(c echo.Context) error {
+	req := entity.ReqCreateAppeal{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING CreateAppeal Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	files := []multipart.File{}
+	defer func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}()
+	if form, err := c.MultipartForm(); err == nil {
+		if len(form.File["attachments"]) > 3 {
+			return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Maximum 3 attachments", nil))
+		}
+		for _, head := range form.File["attachments"] {
+			if head.Size > 2*1024*1024 {
+				return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "File is too large. Maximum size is 2MB.", nil))
+			}
+			file, err := head.Open()
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Cannot laod file", nil))
+			}
+			req.Attachments = append(req.Attachments, head.Filename)
+			files = append(files, file)
+		}
+	}
+	res, err := u.Service.CreateAppeal(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req, files)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusCreated, CreateWebResponse(http.StatusCreated, "Success Operation", map[string]any{"appeal_id": res}))
+}
+
+func (u *School) GetAppealsByUid(c echo.Context) error {
+	res, err := u.Service.GetAppealsByUid(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) GetAppeals(c echo.Context) error {
+	res, err := u.Service.GetAppeals(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), c.QueryParam("status"))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) DecideAppeal(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Appeal Id", nil))
+	}
+	req := entity.ReqDecideAppeal{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING DecideAppeal Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.DecideAppeal(c.Request().Context(), id, helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) RespondOffer(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Progress Id", nil))
+	}
+	req := entity.ReqRespondOffer{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING RespondOffer Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.RespondOffer(c.Request().Context(), id, helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"progress_id": res}))
+}
+
+func (u *School) Withdraw(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Progress Id", nil))
+	}
+	req := entity.ReqWithdraw{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING Withdraw Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.Withdraw(c.Request().Context(), id, helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"progress_id": res}))
+}
+
+func (u *School) GetLetterTemplate(c echo.Context) error {
+	res, err := u.Service.GetLetterTemplate(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) UpdateLetterTemplate(c echo.Context) error {
+	req := entity.ReqLetterTemplate{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING UpdateLetterTemplate Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.UpdateLetterTemplate(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) GetAcceptanceLetter(c echo.Context) error {
+	progid, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Progress Id", nil))
+	}
+	res, err := u.Service.GetAcceptanceLetter(c.Request().Context(), progid, helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=acceptance-letter-%d.pdf", progid))
+	return c.Blob(http.StatusOK, "application/pdf", res)
+}
+
+func (u *School) VerifyLetter(c echo.Context) error {
+	res, err := u.Service.VerifyLetter(c.Request().Context(), c.Param("reference"))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) GetAuditLog(c echo.Context) error {
+	req := entity.ReqAuditQuery{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING AUDIT QUERY, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid query param", nil))
+	}
+	token := c.Get("user").(*jwt.Token)
+	res, err := u.Service.GetAuditLog(c.Request().Context(), helper.GetUid(token), helper.GetRole(token), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) GetMembers(c echo.Context) error {
+	res, err := u.Service.GetMembers(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) UpdateMember(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Member Id", nil))
+	}
+	req := entity.ReqUpdateMember{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING UpdateMember Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.UpdateMember(c.Request().Context(), id, helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) DeleteMember(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Member Id", nil))
+	}
+	if err := u.Service.DeleteMember(c.Request().Context(), id, helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
+
+func (u *School) InviteMember(c echo.Context) error {
+	req := entity.ReqInviteMember{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING InviteMember Req, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Or Missing Request Body", nil))
+	}
+	res, err := u.Service.InviteMember(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusCreated, CreateWebResponse(http.StatusCreated, "Status Created", res))
+}
+
+func (u *School) GetInvitations(c echo.Context) error {
+	res, err := u.Service.GetInvitations(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *School) DeleteInvitation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Invitation Id", nil))
+	}
+	if err := u.Service.DeleteInvitation(c.Request().Context(), id, helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
+
+func (u *School) AcceptInvitation(c echo.Context) error {
+	res, err := u.Service.AcceptInvitation(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), c.Param("token"))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
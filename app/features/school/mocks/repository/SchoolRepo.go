@@ -7,6 +7,8 @@ import (
 	gorm "gorm.io/gorm"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // SchoolRepo is an autogenerated mock type for the SchoolRepo type
@@ -14,19 +16,35 @@ type SchoolRepo struct {
 	mock.Mock
 }
 
+// AcceptInvitation provides a mock function with given fields: db, invitation, member
+func (_m *SchoolRepo) AcceptInvitation(db *gorm.DB, invitation entities.SchoolInvitation, member entities.SchoolMember) error {
+	ret := _m.Called(db, invitation, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.SchoolInvitation, entities.SchoolMember) error); ok {
+		r0 = rf(db, invitation, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddAchievement provides a mock function with given fields: db, achv
-func (_m *SchoolRepo) AddAchievement(db *gorm.DB, achv entities.Achievement) (int, error) {
+func (_m *SchoolRepo) AddAchievement(db *gorm.DB, achv entities.Achievement) (*entities.Achievement, error) {
 	ret := _m.Called(db, achv)
 
-	var r0 int
+	var r0 *entities.Achievement
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Achievement) (int, error)); ok {
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Achievement) (*entities.Achievement, error)); ok {
 		return rf(db, achv)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Achievement) int); ok {
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Achievement) *entities.Achievement); ok {
 		r0 = rf(db, achv)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Achievement)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.Achievement) error); ok {
@@ -39,18 +57,20 @@ func (_m *SchoolRepo) AddAchievement(db *gorm.DB, achv entities.Achievement) (in
 }
 
 // AddExtracurricular provides a mock function with given fields: db, achv
-func (_m *SchoolRepo) AddExtracurricular(db *gorm.DB, achv entities.Extracurricular) (int, error) {
+func (_m *SchoolRepo) AddExtracurricular(db *gorm.DB, achv entities.Extracurricular) (*entities.Extracurricular, error) {
 	ret := _m.Called(db, achv)
 
-	var r0 int
+	var r0 *entities.Extracurricular
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Extracurricular) (int, error)); ok {
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Extracurricular) (*entities.Extracurricular, error)); ok {
 		return rf(db, achv)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Extracurricular) int); ok {
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Extracurricular) *entities.Extracurricular); ok {
 		r0 = rf(db, achv)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Extracurricular)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.Extracurricular) error); ok {
@@ -63,18 +83,20 @@ func (_m *SchoolRepo) AddExtracurricular(db *gorm.DB, achv entities.Extracurricu
 }
 
 // AddFaq provides a mock function with given fields: db, faq
-func (_m *SchoolRepo) AddFaq(db *gorm.DB, faq entities.Faq) (int, error) {
+func (_m *SchoolRepo) AddFaq(db *gorm.DB, faq entities.Faq) (*entities.Faq, error) {
 	ret := _m.Called(db, faq)
 
-	var r0 int
+	var r0 *entities.Faq
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Faq) (int, error)); ok {
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Faq) (*entities.Faq, error)); ok {
 		return rf(db, faq)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Faq) int); ok {
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Faq) *entities.Faq); ok {
 		r0 = rf(db, faq)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Faq)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.Faq) error); ok {
@@ -87,18 +109,20 @@ func (_m *SchoolRepo) AddFaq(db *gorm.DB, faq entities.Faq) (int, error) {
 }
 
 // AddPayment provides a mock function with given fields: db, paym
-func (_m *SchoolRepo) AddPayment(db *gorm.DB, paym entities.Payment) (int, error) {
+func (_m *SchoolRepo) AddPayment(db *gorm.DB, paym entities.Payment) (*entities.Payment, error) {
 	ret := _m.Called(db, paym)
 
-	var r0 int
+	var r0 *entities.Payment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Payment) (int, error)); ok {
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Payment) (*entities.Payment, error)); ok {
 		return rf(db, paym)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Payment) int); ok {
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Payment) *entities.Payment); ok {
 		r0 = rf(db, paym)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Payment)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.Payment) error); ok {
@@ -134,6 +158,54 @@ func (_m *SchoolRepo) AddReview(db *gorm.DB, data entities.Reviews) (int, error)
 	return r0, r1
 }
 
+// CountOwners provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) CountOwners(db *gorm.DB, schid int) (int, error) {
+	ret := _m.Called(db, schid)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (int, error)); ok {
+		return rf(db, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) int); ok {
+		r0 = rf(db, schid)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountSeats provides a mock function with given fields: db, schid, track
+func (_m *SchoolRepo) CountSeats(db *gorm.DB, schid int, track string) (int, error) {
+	ret := _m.Called(db, schid, track)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, string) (int, error)); ok {
+		return rf(db, schid, track)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, string) int); ok {
+		r0 = rf(db, schid, track)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, string) error); ok {
+		r1 = rf(db, schid, track)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: db, school
 func (_m *SchoolRepo) Create(db *gorm.DB, school entities.School) (int, error) {
 	ret := _m.Called(db, school)
@@ -158,23 +230,1207 @@ func (_m *SchoolRepo) Create(db *gorm.DB, school entities.School) (int, error) {
 	return r0, r1
 }
 
-// CreateSubmission provides a mock function with given fields: db, subm
-func (_m *SchoolRepo) CreateSubmission(db *gorm.DB, subm entities.Submission) (int, error) {
-	ret := _m.Called(db, subm)
+// CreateAppeal provides a mock function with given fields: db, appeal
+func (_m *SchoolRepo) CreateAppeal(db *gorm.DB, appeal entities.Appeal) (*entities.Appeal, error) {
+	ret := _m.Called(db, appeal)
+
+	var r0 *entities.Appeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Appeal) (*entities.Appeal, error)); ok {
+		return rf(db, appeal)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Appeal) *entities.Appeal); ok {
+		r0 = rf(db, appeal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Appeal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.Appeal) error); ok {
+		r1 = rf(db, appeal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCart provides a mock function with given fields: db, cart
+func (_m *SchoolRepo) CreateCart(db *gorm.DB, cart entities.Carts) error {
+	ret := _m.Called(db, cart)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Carts) error); ok {
+		r0 = rf(db, cart)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateInvitation provides a mock function with given fields: db, invitation
+func (_m *SchoolRepo) CreateInvitation(db *gorm.DB, invitation entities.SchoolInvitation) (*entities.SchoolInvitation, error) {
+	ret := _m.Called(db, invitation)
+
+	var r0 *entities.SchoolInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.SchoolInvitation) (*entities.SchoolInvitation, error)); ok {
+		return rf(db, invitation)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.SchoolInvitation) *entities.SchoolInvitation); ok {
+		r0 = rf(db, invitation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SchoolInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.SchoolInvitation) error); ok {
+		r1 = rf(db, invitation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLetter provides a mock function with given fields: db, letter
+func (_m *SchoolRepo) CreateLetter(db *gorm.DB, letter entities.AcceptanceLetter) (*entities.AcceptanceLetter, error) {
+	ret := _m.Called(db, letter)
+
+	var r0 *entities.AcceptanceLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.AcceptanceLetter) (*entities.AcceptanceLetter, error)); ok {
+		return rf(db, letter)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.AcceptanceLetter) *entities.AcceptanceLetter); ok {
+		r0 = rf(db, letter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.AcceptanceLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.AcceptanceLetter) error); ok {
+		r1 = rf(db, letter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateNote provides a mock function with given fields: db, note
+func (_m *SchoolRepo) CreateNote(db *gorm.DB, note entities.AdmissionNote) error {
+	ret := _m.Called(db, note)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.AdmissionNote) error); ok {
+		r0 = rf(db, note)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePeriod provides a mock function with given fields: db, period
+func (_m *SchoolRepo) CreatePeriod(db *gorm.DB, period entities.AdmissionPeriod) (int, error) {
+	ret := _m.Called(db, period)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.AdmissionPeriod) (int, error)); ok {
+		return rf(db, period)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.AdmissionPeriod) int); ok {
+		r0 = rf(db, period)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.AdmissionPeriod) error); ok {
+		r1 = rf(db, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProgressEvent provides a mock function with given fields: db, event
+func (_m *SchoolRepo) CreateProgressEvent(db *gorm.DB, event entities.ProgressEvent) error {
+	ret := _m.Called(db, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.ProgressEvent) error); ok {
+		r0 = rf(db, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSubmission provides a mock function with given fields: db, subm
+func (_m *SchoolRepo) CreateSubmission(db *gorm.DB, subm entities.Submission) (int, error) {
+	ret := _m.Called(db, subm)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Submission) (int, error)); ok {
+		return rf(db, subm)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Submission) int); ok {
+		r0 = rf(db, subm)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.Submission) error); ok {
+		r1 = rf(db, subm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecideAppeal provides a mock function with given fields: db, appeal
+func (_m *SchoolRepo) DecideAppeal(db *gorm.DB, appeal entities.Appeal) error {
+	ret := _m.Called(db, appeal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Appeal) error); ok {
+		r0 = rf(db, appeal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: db, id
+func (_m *SchoolRepo) Delete(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAchievement provides a mock function with given fields: db, id
+func (_m *SchoolRepo) DeleteAchievement(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCartBySchool provides a mock function with given fields: db, uid, schid
+func (_m *SchoolRepo) DeleteCartBySchool(db *gorm.DB, uid int, schid int) error {
+	ret := _m.Called(db, uid, schid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) error); ok {
+		r0 = rf(db, uid, schid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCartByUid provides a mock function with given fields: db, uid
+func (_m *SchoolRepo) DeleteCartByUid(db *gorm.DB, uid int) error {
+	ret := _m.Called(db, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExtracurricular provides a mock function with given fields: db, id
+func (_m *SchoolRepo) DeleteExtracurricular(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFaq provides a mock function with given fields: db, id
+func (_m *SchoolRepo) DeleteFaq(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInvitation provides a mock function with given fields: db, id
+func (_m *SchoolRepo) DeleteInvitation(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMember provides a mock function with given fields: db, id
+func (_m *SchoolRepo) DeleteMember(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePayment provides a mock function with given fields: db, id
+func (_m *SchoolRepo) DeletePayment(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePeriod provides a mock function with given fields: db, id
+func (_m *SchoolRepo) DeletePeriod(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteProgressByid provides a mock function with given fields: db, id
+func (_m *SchoolRepo) DeleteProgressByid(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByNPSN provides a mock function with given fields: db, npsn
+func (_m *SchoolRepo) FindByNPSN(db *gorm.DB, npsn string) error {
+	ret := _m.Called(db, npsn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) error); ok {
+		r0 = rf(db, npsn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveProgressByUid provides a mock function with given fields: db, uid, schid
+func (_m *SchoolRepo) GetActiveProgressByUid(db *gorm.DB, uid int, schid int) (*entities.Progress, error) {
+	ret := _m.Called(db, uid, schid)
+
+	var r0 *entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) (*entities.Progress, error)); ok {
+		return rf(db, uid, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) *entities.Progress); ok {
+		r0 = rf(db, uid, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, int) error); ok {
+		r1 = rf(db, uid, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveStatusBySchool provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetActiveStatusBySchool(db *gorm.DB, schid int) ([]string, error) {
+	ret := _m.Called(db, schid)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]string, error)); ok {
+		return rf(db, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []string); ok {
+		r0 = rf(db, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: db, limit, offset, search
+func (_m *SchoolRepo) GetAll(db *gorm.DB, limit int, offset int, search string) ([]entities.School, int, error) {
+	ret := _m.Called(db, limit, offset, search)
+
+	var r0 []entities.School
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int, string) ([]entities.School, int, error)); ok {
+		return rf(db, limit, offset, search)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int, string) []entities.School); ok {
+		r0 = rf(db, limit, offset, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.School)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, int, string) int); ok {
+		r1 = rf(db, limit, offset, search)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(*gorm.DB, int, int, string) error); ok {
+		r2 = rf(db, limit, offset, search)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAllProgressAndSubmission provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetAllProgressAndSubmission(db *gorm.DB, schid int) (*entities.School, error) {
+	ret := _m.Called(db, schid)
+
+	var r0 *entities.School
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.School, error)); ok {
+		return rf(db, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.School); ok {
+		r0 = rf(db, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.School)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllProgressByuid provides a mock function with given fields: db, uid
+func (_m *SchoolRepo) GetAllProgressByuid(db *gorm.DB, uid int) ([]entities.Progress, error) {
+	ret := _m.Called(db, uid)
+
+	var r0 []entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.Progress, error)); ok {
+		return rf(db, uid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.Progress); ok {
+		r0 = rf(db, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAppealById provides a mock function with given fields: db, id
+func (_m *SchoolRepo) GetAppealById(db *gorm.DB, id int) (*entities.Appeal, error) {
+	ret := _m.Called(db, id)
+
+	var r0 *entities.Appeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.Appeal, error)); ok {
+		return rf(db, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.Appeal); ok {
+		r0 = rf(db, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Appeal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAppealsBySchool provides a mock function with given fields: db, schid, status
+func (_m *SchoolRepo) GetAppealsBySchool(db *gorm.DB, schid int, status string) ([]entities.Appeal, error) {
+	ret := _m.Called(db, schid, status)
+
+	var r0 []entities.Appeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, string) ([]entities.Appeal, error)); ok {
+		return rf(db, schid, status)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, string) []entities.Appeal); ok {
+		r0 = rf(db, schid, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Appeal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, string) error); ok {
+		r1 = rf(db, schid, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAppealsByUid provides a mock function with given fields: db, uid
+func (_m *SchoolRepo) GetAppealsByUid(db *gorm.DB, uid int) ([]entities.Appeal, error) {
+	ret := _m.Called(db, uid)
+
+	var r0 []entities.Appeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.Appeal, error)); ok {
+		return rf(db, uid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.Appeal); ok {
+		r0 = rf(db, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Appeal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetApplicants provides a mock function with given fields: db, schid, track
+func (_m *SchoolRepo) GetApplicants(db *gorm.DB, schid int, track string) ([]entities.Applicant, error) {
+	ret := _m.Called(db, schid, track)
+
+	var r0 []entities.Applicant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, string) ([]entities.Applicant, error)); ok {
+		return rf(db, schid, track)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, string) []entities.Applicant); ok {
+		r0 = rf(db, schid, track)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Applicant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, string) error); ok {
+		r1 = rf(db, schid, track)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetById provides a mock function with given fields: db, id
+func (_m *SchoolRepo) GetById(db *gorm.DB, id int) (*entities.School, error) {
+	ret := _m.Called(db, id)
+
+	var r0 *entities.School
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.School, error)); ok {
+		return rf(db, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.School); ok {
+		r0 = rf(db, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.School)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFileOwner provides a mock function with given fields: db, fname
+func (_m *SchoolRepo) GetFileOwner(db *gorm.DB, fname string) (int, bool, error) {
+	ret := _m.Called(db, fname)
+
+	var r0 int
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) (int, bool, error)); ok {
+		return rf(db, fname)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) int); ok {
+		r0 = rf(db, fname)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, string) bool); ok {
+		r1 = rf(db, fname)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(*gorm.DB, string) error); ok {
+		r2 = rf(db, fname)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetInvitationById provides a mock function with given fields: db, id
+func (_m *SchoolRepo) GetInvitationById(db *gorm.DB, id int) (*entities.SchoolInvitation, error) {
+	ret := _m.Called(db, id)
+
+	var r0 *entities.SchoolInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.SchoolInvitation, error)); ok {
+		return rf(db, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.SchoolInvitation); ok {
+		r0 = rf(db, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SchoolInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitationByToken provides a mock function with given fields: db, tokenhash
+func (_m *SchoolRepo) GetInvitationByToken(db *gorm.DB, tokenhash string) (*entities.SchoolInvitation, error) {
+	ret := _m.Called(db, tokenhash)
+
+	var r0 *entities.SchoolInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) (*entities.SchoolInvitation, error)); ok {
+		return rf(db, tokenhash)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) *entities.SchoolInvitation); ok {
+		r0 = rf(db, tokenhash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SchoolInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, string) error); ok {
+		r1 = rf(db, tokenhash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitations provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetInvitations(db *gorm.DB, schid int) ([]entities.SchoolInvitation, error) {
+	ret := _m.Called(db, schid)
+
+	var r0 []entities.SchoolInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.SchoolInvitation, error)); ok {
+		return rf(db, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.SchoolInvitation); ok {
+		r0 = rf(db, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.SchoolInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetItemById provides a mock function with given fields: db, model, id
+func (_m *SchoolRepo) GetItemById(db *gorm.DB, model interface{}, id int) error {
+	ret := _m.Called(db, model, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, interface{}, int) error); ok {
+		r0 = rf(db, model, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLastProgress provides a mock function with given fields: db, uid, schid
+func (_m *SchoolRepo) GetLastProgress(db *gorm.DB, uid int, schid int) (*entities.Progress, error) {
+	ret := _m.Called(db, uid, schid)
+
+	var r0 *entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) (*entities.Progress, error)); ok {
+		return rf(db, uid, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) *entities.Progress); ok {
+		r0 = rf(db, uid, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, int) error); ok {
+		r1 = rf(db, uid, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastSubmission provides a mock function with given fields: db, uid, schid
+func (_m *SchoolRepo) GetLastSubmission(db *gorm.DB, uid int, schid int) (*entities.Submission, error) {
+	ret := _m.Called(db, uid, schid)
+
+	var r0 *entities.Submission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) (*entities.Submission, error)); ok {
+		return rf(db, uid, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) *entities.Submission); ok {
+		r0 = rf(db, uid, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Submission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, int) error); ok {
+		r1 = rf(db, uid, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLetterByProgress provides a mock function with given fields: db, progid
+func (_m *SchoolRepo) GetLetterByProgress(db *gorm.DB, progid int) (*entities.AcceptanceLetter, error) {
+	ret := _m.Called(db, progid)
+
+	var r0 *entities.AcceptanceLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.AcceptanceLetter, error)); ok {
+		return rf(db, progid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.AcceptanceLetter); ok {
+		r0 = rf(db, progid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.AcceptanceLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, progid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLetterByReference provides a mock function with given fields: db, reference
+func (_m *SchoolRepo) GetLetterByReference(db *gorm.DB, reference string) (*entities.AcceptanceLetter, error) {
+	ret := _m.Called(db, reference)
+
+	var r0 *entities.AcceptanceLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) (*entities.AcceptanceLetter, error)); ok {
+		return rf(db, reference)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) *entities.AcceptanceLetter); ok {
+		r0 = rf(db, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.AcceptanceLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, string) error); ok {
+		r1 = rf(db, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLetterTemplate provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetLetterTemplate(db *gorm.DB, schid int) (*entities.LetterTemplate, error) {
+	ret := _m.Called(db, schid)
+
+	var r0 *entities.LetterTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.LetterTemplate, error)); ok {
+		return rf(db, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.LetterTemplate); ok {
+		r0 = rf(db, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.LetterTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMember provides a mock function with given fields: db, uid
+func (_m *SchoolRepo) GetMember(db *gorm.DB, uid int) (*entities.SchoolMember, error) {
+	ret := _m.Called(db, uid)
+
+	var r0 *entities.SchoolMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.SchoolMember, error)); ok {
+		return rf(db, uid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.SchoolMember); ok {
+		r0 = rf(db, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SchoolMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMemberById provides a mock function with given fields: db, id
+func (_m *SchoolRepo) GetMemberById(db *gorm.DB, id int) (*entities.SchoolMember, error) {
+	ret := _m.Called(db, id)
+
+	var r0 *entities.SchoolMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.SchoolMember, error)); ok {
+		return rf(db, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.SchoolMember); ok {
+		r0 = rf(db, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SchoolMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembers provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetMembers(db *gorm.DB, schid int) ([]entities.SchoolMember, error) {
+	ret := _m.Called(db, schid)
+
+	var r0 []entities.SchoolMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.SchoolMember, error)); ok {
+		return rf(db, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.SchoolMember); ok {
+		r0 = rf(db, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.SchoolMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotes provides a mock function with given fields: db, progid, internal
+func (_m *SchoolRepo) GetNotes(db *gorm.DB, progid int, internal bool) ([]entities.AdmissionNote, error) {
+	ret := _m.Called(db, progid, internal)
+
+	var r0 []entities.AdmissionNote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, bool) ([]entities.AdmissionNote, error)); ok {
+		return rf(db, progid, internal)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, bool) []entities.AdmissionNote); ok {
+		r0 = rf(db, progid, internal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.AdmissionNote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, bool) error); ok {
+		r1 = rf(db, progid, internal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenPeriod provides a mock function with given fields: db, schid, now
+func (_m *SchoolRepo) GetOpenPeriod(db *gorm.DB, schid int, now time.Time) (*entities.AdmissionPeriod, error) {
+	ret := _m.Called(db, schid, now)
+
+	var r0 *entities.AdmissionPeriod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, time.Time) (*entities.AdmissionPeriod, error)); ok {
+		return rf(db, schid, now)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, time.Time) *entities.AdmissionPeriod); ok {
+		r0 = rf(db, schid, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.AdmissionPeriod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, time.Time) error); ok {
+		r1 = rf(db, schid, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOtherActiveProgressByUid provides a mock function with given fields: db, uid, schid
+func (_m *SchoolRepo) GetOtherActiveProgressByUid(db *gorm.DB, uid int, schid int) ([]entities.Progress, error) {
+	ret := _m.Called(db, uid, schid)
+
+	var r0 []entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) ([]entities.Progress, error)); ok {
+		return rf(db, uid, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) []entities.Progress); ok {
+		r0 = rf(db, uid, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, int) error); ok {
+		r1 = rf(db, uid, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverdueProgress provides a mock function with given fields: db, now
+func (_m *SchoolRepo) GetOverdueProgress(db *gorm.DB, now time.Time) ([]entities.Progress, error) {
+	ret := _m.Called(db, now)
+
+	var r0 []entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, time.Time) ([]entities.Progress, error)); ok {
+		return rf(db, now)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, time.Time) []entities.Progress); ok {
+		r0 = rf(db, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, time.Time) error); ok {
+		r1 = rf(db, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentById provides a mock function with given fields: db, id
+func (_m *SchoolRepo) GetPaymentById(db *gorm.DB, id int) (*entities.Payment, error) {
+	ret := _m.Called(db, id)
+
+	var r0 *entities.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.Payment, error)); ok {
+		return rf(db, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.Payment); ok {
+		r0 = rf(db, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPeriodById provides a mock function with given fields: db, id
+func (_m *SchoolRepo) GetPeriodById(db *gorm.DB, id int) (*entities.AdmissionPeriod, error) {
+	ret := _m.Called(db, id)
+
+	var r0 *entities.AdmissionPeriod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.AdmissionPeriod, error)); ok {
+		return rf(db, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.AdmissionPeriod); ok {
+		r0 = rf(db, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.AdmissionPeriod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPeriods provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetPeriods(db *gorm.DB, schid int) ([]entities.AdmissionPeriod, error) {
+	ret := _m.Called(db, schid)
+
+	var r0 []entities.AdmissionPeriod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.AdmissionPeriod, error)); ok {
+		return rf(db, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.AdmissionPeriod); ok {
+		r0 = rf(db, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.AdmissionPeriod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipeline provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetPipeline(db *gorm.DB, schid int) ([]entities.PipelineStep, error) {
+	ret := _m.Called(db, schid)
+
+	var r0 []entities.PipelineStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.PipelineStep, error)); ok {
+		return rf(db, schid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.PipelineStep); ok {
+		r0 = rf(db, schid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.PipelineStep)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProgressByid provides a mock function with given fields: db, id
+func (_m *SchoolRepo) GetProgressByid(db *gorm.DB, id int) (*entities.Progress, error) {
+	ret := _m.Called(db, id)
+
+	var r0 *entities.Progress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.Progress, error)); ok {
+		return rf(db, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.Progress); ok {
+		r0 = rf(db, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Progress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProgressEvents provides a mock function with given fields: db, progid
+func (_m *SchoolRepo) GetProgressEvents(db *gorm.DB, progid int) ([]entities.ProgressEvent, error) {
+	ret := _m.Called(db, progid)
 
-	var r0 int
+	var r0 []entities.ProgressEvent
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Submission) (int, error)); ok {
-		return rf(db, subm)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.ProgressEvent, error)); ok {
+		return rf(db, progid)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Submission) int); ok {
-		r0 = rf(db, subm)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.ProgressEvent); ok {
+		r0 = rf(db, progid)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ProgressEvent)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.Submission) error); ok {
-		r1 = rf(db, subm)
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, progid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -182,156 +1438,101 @@ func (_m *SchoolRepo) CreateSubmission(db *gorm.DB, subm entities.Submission) (i
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: db, id, uid
-func (_m *SchoolRepo) Delete(db *gorm.DB, id int, uid int) error {
-	ret := _m.Called(db, id, uid)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) error); ok {
-		r0 = rf(db, id, uid)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DeleteAchievement provides a mock function with given fields: db, id
-func (_m *SchoolRepo) DeleteAchievement(db *gorm.DB, id int) error {
-	ret := _m.Called(db, id)
+// GetQuotas provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetQuotas(db *gorm.DB, schid int) ([]entities.Quota, error) {
+	ret := _m.Called(db, schid)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
-		r0 = rf(db, id)
-	} else {
-		r0 = ret.Error(0)
+	var r0 []entities.Quota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.Quota, error)); ok {
+		return rf(db, schid)
 	}
-
-	return r0
-}
-
-// DeleteExtracurricular provides a mock function with given fields: db, id
-func (_m *SchoolRepo) DeleteExtracurricular(db *gorm.DB, id int) error {
-	ret := _m.Called(db, id)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
-		r0 = rf(db, id)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.Quota); ok {
+		r0 = rf(db, schid)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Quota)
+		}
 	}
 
-	return r0
-}
-
-// DeleteFaq provides a mock function with given fields: db, id
-func (_m *SchoolRepo) DeleteFaq(db *gorm.DB, id int) error {
-	ret := _m.Called(db, id)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
-		r0 = rf(db, id)
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// DeletePayment provides a mock function with given fields: db, id
-func (_m *SchoolRepo) DeletePayment(db *gorm.DB, id int) error {
-	ret := _m.Called(db, id)
+// GetSchoolIdOf provides a mock function with given fields: db, model, id
+func (_m *SchoolRepo) GetSchoolIdOf(db *gorm.DB, model interface{}, id int) (int, error) {
+	ret := _m.Called(db, model, id)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
-		r0 = rf(db, id)
-	} else {
-		r0 = ret.Error(0)
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, interface{}, int) (int, error)); ok {
+		return rf(db, model, id)
 	}
-
-	return r0
-}
-
-// DeleteProgressByid provides a mock function with given fields: db, id
-func (_m *SchoolRepo) DeleteProgressByid(db *gorm.DB, id int) error {
-	ret := _m.Called(db, id)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
-		r0 = rf(db, id)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, interface{}, int) int); ok {
+		r0 = rf(db, model, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
-}
-
-// FindByNPSN provides a mock function with given fields: db, npsn
-func (_m *SchoolRepo) FindByNPSN(db *gorm.DB, npsn string) error {
-	ret := _m.Called(db, npsn)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, string) error); ok {
-		r0 = rf(db, npsn)
+	if rf, ok := ret.Get(1).(func(*gorm.DB, interface{}, int) error); ok {
+		r1 = rf(db, model, id)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// GetAll provides a mock function with given fields: db, limit, offset, search
-func (_m *SchoolRepo) GetAll(db *gorm.DB, limit int, offset int, search string) ([]entities.School, int, error) {
-	ret := _m.Called(db, limit, offset, search)
+// GetSelectionCriteria provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetSelectionCriteria(db *gorm.DB, schid int) ([]entities.SelectionCriterion, error) {
+	ret := _m.Called(db, schid)
 
-	var r0 []entities.School
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int, string) ([]entities.School, int, error)); ok {
-		return rf(db, limit, offset, search)
+	var r0 []entities.SelectionCriterion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.SelectionCriterion, error)); ok {
+		return rf(db, schid)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int, string) []entities.School); ok {
-		r0 = rf(db, limit, offset, search)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.SelectionCriterion); ok {
+		r0 = rf(db, schid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entities.School)
+			r0 = ret.Get(0).([]entities.SelectionCriterion)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gorm.DB, int, int, string) int); ok {
-		r1 = rf(db, limit, offset, search)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-
-	if rf, ok := ret.Get(2).(func(*gorm.DB, int, int, string) error); ok {
-		r2 = rf(db, limit, offset, search)
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, schid)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetAllProgressAndSubmissionByuid provides a mock function with given fields: db, uid
-func (_m *SchoolRepo) GetAllProgressAndSubmissionByuid(db *gorm.DB, uid int) (*entities.School, error) {
-	ret := _m.Called(db, uid)
+// GetSubmissionByid provides a mock function with given fields: db, id
+func (_m *SchoolRepo) GetSubmissionByid(db *gorm.DB, id int) (*entities.Submission, error) {
+	ret := _m.Called(db, id)
 
-	var r0 *entities.School
+	var r0 *entities.Submission
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.School, error)); ok {
-		return rf(db, uid)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.Submission, error)); ok {
+		return rf(db, id)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.School); ok {
-		r0 = rf(db, uid)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.Submission); ok {
+		r0 = rf(db, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.School)
+			r0 = ret.Get(0).(*entities.Submission)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
-		r1 = rf(db, uid)
+		r1 = rf(db, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -339,17 +1540,17 @@ func (_m *SchoolRepo) GetAllProgressAndSubmissionByuid(db *gorm.DB, uid int) (*e
 	return r0, r1
 }
 
-// GetAllProgressByuid provides a mock function with given fields: db, uid
-func (_m *SchoolRepo) GetAllProgressByuid(db *gorm.DB, uid int) ([]entities.Progress, error) {
-	ret := _m.Called(db, uid)
+// GetWaitlist provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) GetWaitlist(db *gorm.DB, schid int) ([]entities.Progress, error) {
+	ret := _m.Called(db, schid)
 
 	var r0 []entities.Progress
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.Progress, error)); ok {
-		return rf(db, uid)
+		return rf(db, schid)
 	}
 	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.Progress); ok {
-		r0 = rf(db, uid)
+		r0 = rf(db, schid)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entities.Progress)
@@ -357,7 +1558,7 @@ func (_m *SchoolRepo) GetAllProgressByuid(db *gorm.DB, uid int) ([]entities.Prog
 	}
 
 	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
-		r1 = rf(db, uid)
+		r1 = rf(db, schid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -365,25 +1566,23 @@ func (_m *SchoolRepo) GetAllProgressByuid(db *gorm.DB, uid int) ([]entities.Prog
 	return r0, r1
 }
 
-// GetById provides a mock function with given fields: db, id
-func (_m *SchoolRepo) GetById(db *gorm.DB, id int) (*entities.School, error) {
-	ret := _m.Called(db, id)
+// HasAppeal provides a mock function with given fields: db, progid
+func (_m *SchoolRepo) HasAppeal(db *gorm.DB, progid int) (bool, error) {
+	ret := _m.Called(db, progid)
 
-	var r0 *entities.School
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.School, error)); ok {
-		return rf(db, id)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (bool, error)); ok {
+		return rf(db, progid)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.School); ok {
-		r0 = rf(db, id)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) bool); ok {
+		r0 = rf(db, progid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.School)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
-		r1 = rf(db, id)
+		r1 = rf(db, progid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -391,25 +1590,23 @@ func (_m *SchoolRepo) GetById(db *gorm.DB, id int) (*entities.School, error) {
 	return r0, r1
 }
 
-// GetByUid provides a mock function with given fields: db, uid
-func (_m *SchoolRepo) GetByUid(db *gorm.DB, uid int) (*entities.School, error) {
-	ret := _m.Called(db, uid)
+// IsPeriodOverlapping provides a mock function with given fields: db, period
+func (_m *SchoolRepo) IsPeriodOverlapping(db *gorm.DB, period entities.AdmissionPeriod) (bool, error) {
+	ret := _m.Called(db, period)
 
-	var r0 *entities.School
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.School, error)); ok {
-		return rf(db, uid)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.AdmissionPeriod) (bool, error)); ok {
+		return rf(db, period)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.School); ok {
-		r0 = rf(db, uid)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.AdmissionPeriod) bool); ok {
+		r0 = rf(db, period)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.School)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
-		r1 = rf(db, uid)
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.AdmissionPeriod) error); ok {
+		r1 = rf(db, period)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -417,8 +1614,8 @@ func (_m *SchoolRepo) GetByUid(db *gorm.DB, uid int) (*entities.School, error) {
 	return r0, r1
 }
 
-// GetProgressByid provides a mock function with given fields: db, id
-func (_m *SchoolRepo) GetProgressByid(db *gorm.DB, id int) (*entities.Progress, error) {
+// LockProgress provides a mock function with given fields: db, id
+func (_m *SchoolRepo) LockProgress(db *gorm.DB, id int) (*entities.Progress, error) {
 	ret := _m.Called(db, id)
 
 	var r0 *entities.Progress
@@ -443,25 +1640,25 @@ func (_m *SchoolRepo) GetProgressByid(db *gorm.DB, id int) (*entities.Progress,
 	return r0, r1
 }
 
-// GetSubmissionByid provides a mock function with given fields: db, id
-func (_m *SchoolRepo) GetSubmissionByid(db *gorm.DB, id int) (*entities.Submission, error) {
-	ret := _m.Called(db, id)
+// LockQuotas provides a mock function with given fields: db, schid
+func (_m *SchoolRepo) LockQuotas(db *gorm.DB, schid int) ([]entities.Quota, error) {
+	ret := _m.Called(db, schid)
 
-	var r0 *entities.Submission
+	var r0 []entities.Quota
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.Submission, error)); ok {
-		return rf(db, id)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.Quota, error)); ok {
+		return rf(db, schid)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.Submission); ok {
-		r0 = rf(db, id)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.Quota); ok {
+		r0 = rf(db, schid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.Submission)
+			r0 = ret.Get(0).([]entities.Quota)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
-		r1 = rf(db, id)
+		r1 = rf(db, schid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -573,6 +1770,48 @@ func (_m *SchoolRepo) UpdateFaq(db *gorm.DB, extrac entities.Faq) (*entities.Faq
 	return r0, r1
 }
 
+// UpdateLetterTemplate provides a mock function with given fields: db, tmpl
+func (_m *SchoolRepo) UpdateLetterTemplate(db *gorm.DB, tmpl entities.LetterTemplate) error {
+	ret := _m.Called(db, tmpl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.LetterTemplate) error); ok {
+		r0 = rf(db, tmpl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLocation provides a mock function with given fields: db, schid, lat, lng
+func (_m *SchoolRepo) UpdateLocation(db *gorm.DB, schid int, lat float64, lng float64) error {
+	ret := _m.Called(db, schid, lat, lng)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, float64, float64) error); ok {
+		r0 = rf(db, schid, lat, lng)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMember provides a mock function with given fields: db, member
+func (_m *SchoolRepo) UpdateMember(db *gorm.DB, member entities.SchoolMember) error {
+	ret := _m.Called(db, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.SchoolMember) error); ok {
+		r0 = rf(db, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePayment provides a mock function with given fields: db, paym
 func (_m *SchoolRepo) UpdatePayment(db *gorm.DB, paym entities.Payment) (*entities.Payment, error) {
 	ret := _m.Called(db, paym)
@@ -599,25 +1838,53 @@ func (_m *SchoolRepo) UpdatePayment(db *gorm.DB, paym entities.Payment) (*entiti
 	return r0, r1
 }
 
-// UpdateProgress provides a mock function with given fields: db, id, status
-func (_m *SchoolRepo) UpdateProgress(db *gorm.DB, id int, status string) (*entities.Progress, error) {
-	ret := _m.Called(db, id, status)
+// UpdatePeriod provides a mock function with given fields: db, period
+func (_m *SchoolRepo) UpdatePeriod(db *gorm.DB, period entities.AdmissionPeriod) error {
+	ret := _m.Called(db, period)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.AdmissionPeriod) error); ok {
+		r0 = rf(db, period)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePipeline provides a mock function with given fields: db, schid, steps
+func (_m *SchoolRepo) UpdatePipeline(db *gorm.DB, schid int, steps []entities.PipelineStep) error {
+	ret := _m.Called(db, schid, steps)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, []entities.PipelineStep) error); ok {
+		r0 = rf(db, schid, steps)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProgress provides a mock function with given fields: db, data
+func (_m *SchoolRepo) UpdateProgress(db *gorm.DB, data entities.Progress) (*entities.Progress, error) {
+	ret := _m.Called(db, data)
 
 	var r0 *entities.Progress
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int, string) (*entities.Progress, error)); ok {
-		return rf(db, id, status)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Progress) (*entities.Progress, error)); ok {
+		return rf(db, data)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int, string) *entities.Progress); ok {
-		r0 = rf(db, id, status)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Progress) *entities.Progress); ok {
+		r0 = rf(db, data)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Progress)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gorm.DB, int, string) error); ok {
-		r1 = rf(db, id, status)
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.Progress) error); ok {
+		r1 = rf(db, data)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -625,28 +1892,46 @@ func (_m *SchoolRepo) UpdateProgress(db *gorm.DB, id int, status string) (*entit
 	return r0, r1
 }
 
-// UpdateProgressByUid provides a mock function with given fields: db, uid, schid, status
-func (_m *SchoolRepo) UpdateProgressByUid(db *gorm.DB, uid int, schid int, status string) (int, error) {
-	ret := _m.Called(db, uid, schid, status)
+// UpdateQuotas provides a mock function with given fields: db, schid, quotas
+func (_m *SchoolRepo) UpdateQuotas(db *gorm.DB, schid int, quotas []entities.Quota) error {
+	ret := _m.Called(db, schid, quotas)
 
-	var r0 int
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int, string) (int, error)); ok {
-		return rf(db, uid, schid, status)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, []entities.Quota) error); ok {
+		r0 = rf(db, schid, quotas)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int, string) int); ok {
-		r0 = rf(db, uid, schid, status)
+
+	return r0
+}
+
+// UpdateSelectionCriteria provides a mock function with given fields: db, schid, criteria
+func (_m *SchoolRepo) UpdateSelectionCriteria(db *gorm.DB, schid int, criteria []entities.SelectionCriterion) error {
+	ret := _m.Called(db, schid, criteria)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, []entities.SelectionCriterion) error); ok {
+		r0 = rf(db, schid, criteria)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(*gorm.DB, int, int, string) error); ok {
-		r1 = rf(db, uid, schid, status)
+	return r0
+}
+
+// UpdateSubmission provides a mock function with given fields: db, id, data
+func (_m *SchoolRepo) UpdateSubmission(db *gorm.DB, id int, data map[string]interface{}) error {
+	ret := _m.Called(db, id, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, map[string]interface{}) error); ok {
+		r0 = rf(db, id, data)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 type mockConstructorTestingTNewSchoolRepo interface {
@@ -5,7 +5,10 @@ package mocks
 import (
 	context "context"
 
+	authz "github.com/education-hub/BE/app/authz"
+
 	entities "github.com/education-hub/BE/app/entities"
+
 	mock "github.com/stretchr/testify/mock"
 
 	multipart "mime/multipart"
@@ -18,23 +21,49 @@ type SchoolService struct {
 	mock.Mock
 }
 
-// AddAchievement provides a mock function with given fields: ctx, req, image
-func (_m *SchoolService) AddAchievement(ctx context.Context, req entities.ReqAddAchievemnt, image multipart.File) (int, error) {
-	ret := _m.Called(ctx, req, image)
+// AcceptInvitation provides a mock function with given fields: ctx, uid, token
+func (_m *SchoolService) AcceptInvitation(ctx context.Context, uid int, token string) (*entities.ResMember, error) {
+	ret := _m.Called(ctx, uid, token)
+
+	var r0 *entities.ResMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*entities.ResMember, error)); ok {
+		return rf(ctx, uid, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *entities.ResMember); ok {
+		r0 = rf(ctx, uid, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, uid, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddAchievement provides a mock function with given fields: ctx, uid, req, image
+func (_m *SchoolService) AddAchievement(ctx context.Context, uid int, req entities.ReqAddAchievemnt, image multipart.File) (int, error) {
+	ret := _m.Called(ctx, uid, req, image)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqAddAchievemnt, multipart.File) (int, error)); ok {
-		return rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAddAchievemnt, multipart.File) (int, error)); ok {
+		return rf(ctx, uid, req, image)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqAddAchievemnt, multipart.File) int); ok {
-		r0 = rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAddAchievemnt, multipart.File) int); ok {
+		r0 = rf(ctx, uid, req, image)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entities.ReqAddAchievemnt, multipart.File) error); ok {
-		r1 = rf(ctx, req, image)
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqAddAchievemnt, multipart.File) error); ok {
+		r1 = rf(ctx, uid, req, image)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,23 +71,23 @@ func (_m *SchoolService) AddAchievement(ctx context.Context, req entities.ReqAdd
 	return r0, r1
 }
 
-// AddExtracurricular provides a mock function with given fields: ctx, req, image
-func (_m *SchoolService) AddExtracurricular(ctx context.Context, req entities.ReqAddExtracurricular, image multipart.File) (int, error) {
-	ret := _m.Called(ctx, req, image)
+// AddExtracurricular provides a mock function with given fields: ctx, uid, req, image
+func (_m *SchoolService) AddExtracurricular(ctx context.Context, uid int, req entities.ReqAddExtracurricular, image multipart.File) (int, error) {
+	ret := _m.Called(ctx, uid, req, image)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqAddExtracurricular, multipart.File) (int, error)); ok {
-		return rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAddExtracurricular, multipart.File) (int, error)); ok {
+		return rf(ctx, uid, req, image)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqAddExtracurricular, multipart.File) int); ok {
-		r0 = rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAddExtracurricular, multipart.File) int); ok {
+		r0 = rf(ctx, uid, req, image)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entities.ReqAddExtracurricular, multipart.File) error); ok {
-		r1 = rf(ctx, req, image)
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqAddExtracurricular, multipart.File) error); ok {
+		r1 = rf(ctx, uid, req, image)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -66,23 +95,47 @@ func (_m *SchoolService) AddExtracurricular(ctx context.Context, req entities.Re
 	return r0, r1
 }
 
-// AddFaq provides a mock function with given fields: ctx, req
-func (_m *SchoolService) AddFaq(ctx context.Context, req entities.ReqAddFaq) (int, error) {
-	ret := _m.Called(ctx, req)
+// AddFaq provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) AddFaq(ctx context.Context, uid int, req entities.ReqAddFaq) (int, error) {
+	ret := _m.Called(ctx, uid, req)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqAddFaq) (int, error)); ok {
-		return rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAddFaq) (int, error)); ok {
+		return rf(ctx, uid, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqAddFaq) int); ok {
-		r0 = rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAddFaq) int); ok {
+		r0 = rf(ctx, uid, req)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entities.ReqAddFaq) error); ok {
-		r1 = rf(ctx, req)
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqAddFaq) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddNote provides a mock function with given fields: ctx, id, uid, req
+func (_m *SchoolService) AddNote(ctx context.Context, id int, uid int, req entities.ReqAddNote) (int, error) {
+	ret := _m.Called(ctx, id, uid, req)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqAddNote) (int, error)); ok {
+		return rf(ctx, id, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqAddNote) int); ok {
+		r0 = rf(ctx, id, uid, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, entities.ReqAddNote) error); ok {
+		r1 = rf(ctx, id, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -90,23 +143,23 @@ func (_m *SchoolService) AddFaq(ctx context.Context, req entities.ReqAddFaq) (in
 	return r0, r1
 }
 
-// AddPayment provides a mock function with given fields: ctx, req, image
-func (_m *SchoolService) AddPayment(ctx context.Context, req entities.ReqAddPayment, image multipart.File) (int, error) {
-	ret := _m.Called(ctx, req, image)
+// AddPayment provides a mock function with given fields: ctx, uid, req, image
+func (_m *SchoolService) AddPayment(ctx context.Context, uid int, req entities.ReqAddPayment, image multipart.File) (int, error) {
+	ret := _m.Called(ctx, uid, req, image)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqAddPayment, multipart.File) (int, error)); ok {
-		return rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAddPayment, multipart.File) (int, error)); ok {
+		return rf(ctx, uid, req, image)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqAddPayment, multipart.File) int); ok {
-		r0 = rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAddPayment, multipart.File) int); ok {
+		r0 = rf(ctx, uid, req, image)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entities.ReqAddPayment, multipart.File) error); ok {
-		r1 = rf(ctx, req, image)
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqAddPayment, multipart.File) error); ok {
+		r1 = rf(ctx, uid, req, image)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -138,6 +191,82 @@ func (_m *SchoolService) AddReview(ctx context.Context, req entities.Reviews) (i
 	return r0, r1
 }
 
+// AdvanceSelection provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) AdvanceSelection(ctx context.Context, uid int, req entities.ReqAdvanceSelection) ([]entities.ResBulkProgress, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 []entities.ResBulkProgress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAdvanceSelection) ([]entities.ResBulkProgress, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAdvanceSelection) []entities.ResBulkProgress); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResBulkProgress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqAdvanceSelection) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authorize provides a mock function with given fields: ctx, uid, action
+func (_m *SchoolService) Authorize(ctx context.Context, uid int, action authz.Action) (int, error) {
+	ret := _m.Called(ctx, uid, action)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, authz.Action) (int, error)); ok {
+		return rf(ctx, uid, action)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, authz.Action) int); ok {
+		r0 = rf(ctx, uid, action)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, authz.Action) error); ok {
+		r1 = rf(ctx, uid, action)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkUpdateProgress provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) BulkUpdateProgress(ctx context.Context, uid int, req entities.ReqBulkProgress) ([]entities.ResBulkProgress, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 []entities.ResBulkProgress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqBulkProgress) ([]entities.ResBulkProgress, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqBulkProgress) []entities.ResBulkProgress); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResBulkProgress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqBulkProgress) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, req, image, pdf
 func (_m *SchoolService) Create(ctx context.Context, req entities.ReqCreateSchool, image multipart.File, pdf multipart.File) (int, error) {
 	ret := _m.Called(ctx, req, image, pdf)
@@ -162,13 +291,61 @@ func (_m *SchoolService) Create(ctx context.Context, req entities.ReqCreateSchoo
 	return r0, r1
 }
 
-// CreateQuiz provides a mock function with given fields: ctx, req
-func (_m *SchoolService) CreateQuiz(ctx context.Context, req []entities.ReqAddQuiz) error {
-	ret := _m.Called(ctx, req)
+// CreateAppeal provides a mock function with given fields: ctx, uid, req, files
+func (_m *SchoolService) CreateAppeal(ctx context.Context, uid int, req entities.ReqCreateAppeal, files []multipart.File) (int, error) {
+	ret := _m.Called(ctx, uid, req, files)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqCreateAppeal, []multipart.File) (int, error)); ok {
+		return rf(ctx, uid, req, files)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqCreateAppeal, []multipart.File) int); ok {
+		r0 = rf(ctx, uid, req, files)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqCreateAppeal, []multipart.File) error); ok {
+		r1 = rf(ctx, uid, req, files)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePeriod provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) CreatePeriod(ctx context.Context, uid int, req entities.ReqAdmissionPeriod) (int, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAdmissionPeriod) (int, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqAdmissionPeriod) int); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqAdmissionPeriod) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateQuiz provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) CreateQuiz(ctx context.Context, uid int, req []entities.ReqAddQuiz) error {
+	ret := _m.Called(ctx, uid, req)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []entities.ReqAddQuiz) error); ok {
-		r0 = rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, int, []entities.ReqAddQuiz) error); ok {
+		r0 = rf(ctx, uid, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -200,6 +377,56 @@ func (_m *SchoolService) CreateSubmission(ctx context.Context, req entities.ReqC
 	return r0, r1
 }
 
+// CreateSubmissionFromProfile provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) CreateSubmissionFromProfile(ctx context.Context, uid int, req entities.ReqProfileSubmission) (int, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqProfileSubmission) (int, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqProfileSubmission) int); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqProfileSubmission) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecideAppeal provides a mock function with given fields: ctx, id, uid, req
+func (_m *SchoolService) DecideAppeal(ctx context.Context, id int, uid int, req entities.ReqDecideAppeal) (*entities.ResAppeal, error) {
+	ret := _m.Called(ctx, id, uid, req)
+
+	var r0 *entities.ResAppeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqDecideAppeal) (*entities.ResAppeal, error)); ok {
+		return rf(ctx, id, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqDecideAppeal) *entities.ResAppeal); ok {
+		r0 = rf(ctx, id, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResAppeal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, entities.ReqDecideAppeal) error); ok {
+		r1 = rf(ctx, id, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, id, uid
 func (_m *SchoolService) Delete(ctx context.Context, id int, uid int) error {
 	ret := _m.Called(ctx, id, uid)
@@ -214,13 +441,13 @@ func (_m *SchoolService) Delete(ctx context.Context, id int, uid int) error {
 	return r0
 }
 
-// DeleteAchievement provides a mock function with given fields: ctx, id
-func (_m *SchoolService) DeleteAchievement(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// DeleteAchievement provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) DeleteAchievement(ctx context.Context, id int, uid int) error {
+	ret := _m.Called(ctx, id, uid)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -228,13 +455,13 @@ func (_m *SchoolService) DeleteAchievement(ctx context.Context, id int) error {
 	return r0
 }
 
-// DeleteExtracurricular provides a mock function with given fields: ctx, id
-func (_m *SchoolService) DeleteExtracurricular(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// DeleteExtracurricular provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) DeleteExtracurricular(ctx context.Context, id int, uid int) error {
+	ret := _m.Called(ctx, id, uid)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -242,13 +469,13 @@ func (_m *SchoolService) DeleteExtracurricular(ctx context.Context, id int) erro
 	return r0
 }
 
-// DeleteFaq provides a mock function with given fields: ctx, id
-func (_m *SchoolService) DeleteFaq(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// DeleteFaq provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) DeleteFaq(ctx context.Context, id int, uid int) error {
+	ret := _m.Called(ctx, id, uid)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -256,13 +483,13 @@ func (_m *SchoolService) DeleteFaq(ctx context.Context, id int) error {
 	return r0
 }
 
-// DeletePayment provides a mock function with given fields: ctx, id
-func (_m *SchoolService) DeletePayment(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// DeleteInvitation provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) DeleteInvitation(ctx context.Context, id int, uid int) error {
+	ret := _m.Called(ctx, id, uid)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -270,13 +497,55 @@ func (_m *SchoolService) DeletePayment(ctx context.Context, id int) error {
 	return r0
 }
 
-// DeleteProgressByid provides a mock function with given fields: ctx, id
-func (_m *SchoolService) DeleteProgressByid(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// DeleteMember provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) DeleteMember(ctx context.Context, id int, uid int) error {
+	ret := _m.Called(ctx, id, uid)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePayment provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) DeletePayment(ctx context.Context, id int, uid int) error {
+	ret := _m.Called(ctx, id, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePeriod provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) DeletePeriod(ctx context.Context, id int, uid int) error {
+	ret := _m.Called(ctx, id, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteProgressByid provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) DeleteProgressByid(ctx context.Context, id int, uid int) error {
+	ret := _m.Called(ctx, id, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -284,6 +553,58 @@ func (_m *SchoolService) DeleteProgressByid(ctx context.Context, id int) error {
 	return r0
 }
 
+// ExportSelection provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) ExportSelection(ctx context.Context, uid int) ([]byte, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]byte, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []byte); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAcceptanceLetter provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) GetAcceptanceLetter(ctx context.Context, id int, uid int) ([]byte, error) {
+	ret := _m.Called(ctx, id, uid)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]byte, error)); ok {
+		return rf(ctx, id, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []byte); ok {
+		r0 = rf(ctx, id, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields: ctx, page, limit, search
 func (_m *SchoolService) GetAll(ctx context.Context, page int, limit int, search string) (*entities.Response, error) {
 	ret := _m.Called(ctx, page, limit, search)
@@ -310,8 +631,8 @@ func (_m *SchoolService) GetAll(ctx context.Context, page int, limit int, search
 	return r0, r1
 }
 
-// GetAllProgressAndSubmissionByuid provides a mock function with given fields: ctx, uid
-func (_m *SchoolService) GetAllProgressAndSubmissionByuid(ctx context.Context, uid int) ([]entities.ResAllProgressSubmission, error) {
+// GetAllProgressAndSubmission provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetAllProgressAndSubmission(ctx context.Context, uid int) ([]entities.ResAllProgressSubmission, error) {
 	ret := _m.Called(ctx, uid)
 
 	var r0 []entities.ResAllProgressSubmission
@@ -362,25 +683,25 @@ func (_m *SchoolService) GetAllProgressByUid(ctx context.Context, uid int) ([]en
 	return r0, r1
 }
 
-// GetByUid provides a mock function with given fields: ctx, uid
-func (_m *SchoolService) GetByUid(ctx context.Context, uid int) (*entities.ResDetailSchool, error) {
-	ret := _m.Called(ctx, uid)
+// GetAppeals provides a mock function with given fields: ctx, uid, status
+func (_m *SchoolService) GetAppeals(ctx context.Context, uid int, status string) ([]entities.ResAppeal, error) {
+	ret := _m.Called(ctx, uid, status)
 
-	var r0 *entities.ResDetailSchool
+	var r0 []entities.ResAppeal
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*entities.ResDetailSchool, error)); ok {
-		return rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]entities.ResAppeal, error)); ok {
+		return rf(ctx, uid, status)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *entities.ResDetailSchool); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []entities.ResAppeal); ok {
+		r0 = rf(ctx, uid, status)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.ResDetailSchool)
+			r0 = ret.Get(0).([]entities.ResAppeal)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, uid)
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, uid, status)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -388,8 +709,86 @@ func (_m *SchoolService) GetByUid(ctx context.Context, uid int) (*entities.ResDe
 	return r0, r1
 }
 
-// GetByid provides a mock function with given fields: ctx, id
-func (_m *SchoolService) GetByid(ctx context.Context, id int) (*entities.ResDetailSchool, error) {
+// GetAppealsByUid provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetAppealsByUid(ctx context.Context, uid int) ([]entities.ResAppeal, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []entities.ResAppeal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResAppeal, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResAppeal); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResAppeal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuditLog provides a mock function with given fields: ctx, uid, role, req
+func (_m *SchoolService) GetAuditLog(ctx context.Context, uid int, role string, req entities.ReqAuditQuery) (*entities.Response, error) {
+	ret := _m.Called(ctx, uid, role, req)
+
+	var r0 *entities.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, entities.ReqAuditQuery) (*entities.Response, error)); ok {
+		return rf(ctx, uid, role, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, entities.ReqAuditQuery) *entities.Response); ok {
+		r0 = rf(ctx, uid, role, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, entities.ReqAuditQuery) error); ok {
+		r1 = rf(ctx, uid, role, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUid provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetByUid(ctx context.Context, uid int) (*entities.ResDetailSchool, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *entities.ResDetailSchool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*entities.ResDetailSchool, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entities.ResDetailSchool); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResDetailSchool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByid provides a mock function with given fields: ctx, id
+func (_m *SchoolService) GetByid(ctx context.Context, id int) (*entities.ResDetailSchool, error) {
 	ret := _m.Called(ctx, id)
 
 	var r0 *entities.ResDetailSchool
@@ -414,25 +813,231 @@ func (_m *SchoolService) GetByid(ctx context.Context, id int) (*entities.ResDeta
 	return r0, r1
 }
 
-// GetProgressById provides a mock function with given fields: ctx, id
-func (_m *SchoolService) GetProgressById(ctx context.Context, id int) (*entities.ResDetailProgress, error) {
-	ret := _m.Called(ctx, id)
+// GetFile provides a mock function with given fields: ctx, uid, fname
+func (_m *SchoolService) GetFile(ctx context.Context, uid int, fname string) (string, error) {
+	ret := _m.Called(ctx, uid, fname)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (string, error)); ok {
+		return rf(ctx, uid, fname)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) string); ok {
+		r0 = rf(ctx, uid, fname)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, uid, fname)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitations provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetInvitations(ctx context.Context, uid int) ([]entities.ResInvitation, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []entities.ResInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResInvitation, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResInvitation); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLetterTemplate provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetLetterTemplate(ctx context.Context, uid int) (*entities.ResLetterTemplate, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *entities.ResLetterTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*entities.ResLetterTemplate, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entities.ResLetterTemplate); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResLetterTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembers provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetMembers(ctx context.Context, uid int) ([]entities.ResMember, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []entities.ResMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResMember, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResMember); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPeriods provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetPeriods(ctx context.Context, uid int) ([]entities.ResAdmissionPeriod, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []entities.ResAdmissionPeriod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResAdmissionPeriod, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResAdmissionPeriod); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResAdmissionPeriod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipeline provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetPipeline(ctx context.Context, uid int) (*entities.ResPipeline, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *entities.ResPipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*entities.ResPipeline, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entities.ResPipeline); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResPipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProgressById provides a mock function with given fields: ctx, id, uid, role
+func (_m *SchoolService) GetProgressById(ctx context.Context, id int, uid int, role string) (*entities.ResDetailProgress, error) {
+	ret := _m.Called(ctx, id, uid, role)
 
 	var r0 *entities.ResDetailProgress
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*entities.ResDetailProgress, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) (*entities.ResDetailProgress, error)); ok {
+		return rf(ctx, id, uid, role)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *entities.ResDetailProgress); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) *entities.ResDetailProgress); ok {
+		r0 = rf(ctx, id, uid, role)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.ResDetailProgress)
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string) error); ok {
+		r1 = rf(ctx, id, uid, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProgressTimeline provides a mock function with given fields: ctx, id, uid, role
+func (_m *SchoolService) GetProgressTimeline(ctx context.Context, id int, uid int, role string) ([]entities.ResProgressEvent, error) {
+	ret := _m.Called(ctx, id, uid, role)
+
+	var r0 []entities.ResProgressEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) ([]entities.ResProgressEvent, error)); ok {
+		return rf(ctx, id, uid, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) []entities.ResProgressEvent); ok {
+		r0 = rf(ctx, id, uid, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResProgressEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string) error); ok {
+		r1 = rf(ctx, id, uid, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuotas provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetQuotas(ctx context.Context, uid int) ([]entities.ResQuota, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []entities.ResQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResQuota, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResQuota); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResQuota)
+		}
+	}
+
 	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, id)
+		r1 = rf(ctx, uid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -440,25 +1045,103 @@ func (_m *SchoolService) GetProgressById(ctx context.Context, id int) (*entities
 	return r0, r1
 }
 
-// GetSubmissionByid provides a mock function with given fields: ctx, id
-func (_m *SchoolService) GetSubmissionByid(ctx context.Context, id int) (*entities.ResDetailSubmission, error) {
-	ret := _m.Called(ctx, id)
+// GetSelection provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetSelection(ctx context.Context, uid int) ([]entities.ResSelectionRank, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []entities.ResSelectionRank
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResSelectionRank, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResSelectionRank); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResSelectionRank)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSelectionCriteria provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetSelectionCriteria(ctx context.Context, uid int) ([]entities.ResSelectionCriterion, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []entities.ResSelectionCriterion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResSelectionCriterion, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResSelectionCriterion); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResSelectionCriterion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubmissionByid provides a mock function with given fields: ctx, id, uid
+func (_m *SchoolService) GetSubmissionByid(ctx context.Context, id int, uid int) (*entities.ResDetailSubmission, error) {
+	ret := _m.Called(ctx, id, uid)
 
 	var r0 *entities.ResDetailSubmission
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*entities.ResDetailSubmission, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*entities.ResDetailSubmission, error)); ok {
+		return rf(ctx, id, uid)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *entities.ResDetailSubmission); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *entities.ResDetailSubmission); ok {
+		r0 = rf(ctx, id, uid)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.ResDetailSubmission)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubmissionPdf provides a mock function with given fields: ctx, id, uid, role
+func (_m *SchoolService) GetSubmissionPdf(ctx context.Context, id int, uid int, role string) ([]byte, error) {
+	ret := _m.Called(ctx, id, uid, role)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) ([]byte, error)); ok {
+		return rf(ctx, id, uid, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) []byte); ok {
+		r0 = rf(ctx, id, uid, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string) error); ok {
+		r1 = rf(ctx, id, uid, role)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -492,6 +1175,132 @@ func (_m *SchoolService) GetTestResult(ctx context.Context, uid int) ([]pkg.Test
 	return r0, r1
 }
 
+// GetWaitlist provides a mock function with given fields: ctx, uid
+func (_m *SchoolService) GetWaitlist(ctx context.Context, uid int) ([]entities.ResWaitlist, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []entities.ResWaitlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResWaitlist, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResWaitlist); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResWaitlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetZonasiRanking provides a mock function with given fields: ctx, uid, track
+func (_m *SchoolService) GetZonasiRanking(ctx context.Context, uid int, track string) ([]entities.ResZonasiRank, error) {
+	ret := _m.Called(ctx, uid, track)
+
+	var r0 []entities.ResZonasiRank
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]entities.ResZonasiRank, error)); ok {
+		return rf(ctx, uid, track)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []entities.ResZonasiRank); ok {
+		r0 = rf(ctx, uid, track)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResZonasiRank)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, uid, track)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteMember provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) InviteMember(ctx context.Context, uid int, req entities.ReqInviteMember) (*entities.ResInvitation, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 *entities.ResInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqInviteMember) (*entities.ResInvitation, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqInviteMember) *entities.ResInvitation); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqInviteMember) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RespondOffer provides a mock function with given fields: ctx, id, uid, req
+func (_m *SchoolService) RespondOffer(ctx context.Context, id int, uid int, req entities.ReqRespondOffer) (int, error) {
+	ret := _m.Called(ctx, id, uid, req)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqRespondOffer) (int, error)); ok {
+		return rf(ctx, id, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqRespondOffer) int); ok {
+		r0 = rf(ctx, id, uid, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, entities.ReqRespondOffer) error); ok {
+		r1 = rf(ctx, id, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviseSubmission provides a mock function with given fields: ctx, id, uid, req, files
+func (_m *SchoolService) ReviseSubmission(ctx context.Context, id int, uid int, req entities.ReqReviseSubmission, files map[string]multipart.File) (int, error) {
+	ret := _m.Called(ctx, id, uid, req, files)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqReviseSubmission, map[string]multipart.File) (int, error)); ok {
+		return rf(ctx, id, uid, req, files)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqReviseSubmission, map[string]multipart.File) int); ok {
+		r0 = rf(ctx, id, uid, req, files)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, entities.ReqReviseSubmission, map[string]multipart.File) error); ok {
+		r1 = rf(ctx, id, uid, req, files)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Search provides a mock function with given fields: searchval
 func (_m *SchoolService) Search(searchval string) interface{} {
 	ret := _m.Called(searchval)
@@ -508,25 +1317,39 @@ func (_m *SchoolService) Search(searchval string) interface{} {
 	return r0
 }
 
-// Update provides a mock function with given fields: ctx, req, image, pdf
-func (_m *SchoolService) Update(ctx context.Context, req entities.ReqUpdateSchool, image multipart.File, pdf multipart.File) (*entities.ResUpdateSchool, error) {
-	ret := _m.Called(ctx, req, image, pdf)
+// SetGmeet provides a mock function with given fields: ctx, id, link, date
+func (_m *SchoolService) SetGmeet(ctx context.Context, id int, link string, date string) error {
+	ret := _m.Called(ctx, id, link, date)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) error); ok {
+		r0 = rf(ctx, id, link, date)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, uid, req, image, pdf
+func (_m *SchoolService) Update(ctx context.Context, uid int, req entities.ReqUpdateSchool, image multipart.File, pdf multipart.File) (*entities.ResUpdateSchool, error) {
+	ret := _m.Called(ctx, uid, req, image, pdf)
 
 	var r0 *entities.ResUpdateSchool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdateSchool, multipart.File, multipart.File) (*entities.ResUpdateSchool, error)); ok {
-		return rf(ctx, req, image, pdf)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateSchool, multipart.File, multipart.File) (*entities.ResUpdateSchool, error)); ok {
+		return rf(ctx, uid, req, image, pdf)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdateSchool, multipart.File, multipart.File) *entities.ResUpdateSchool); ok {
-		r0 = rf(ctx, req, image, pdf)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateSchool, multipart.File, multipart.File) *entities.ResUpdateSchool); ok {
+		r0 = rf(ctx, uid, req, image, pdf)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.ResUpdateSchool)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entities.ReqUpdateSchool, multipart.File, multipart.File) error); ok {
-		r1 = rf(ctx, req, image, pdf)
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqUpdateSchool, multipart.File, multipart.File) error); ok {
+		r1 = rf(ctx, uid, req, image, pdf)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -534,23 +1357,23 @@ func (_m *SchoolService) Update(ctx context.Context, req entities.ReqUpdateSchoo
 	return r0, r1
 }
 
-// UpdateAchievement provides a mock function with given fields: ctx, req, image
-func (_m *SchoolService) UpdateAchievement(ctx context.Context, req entities.ReqUpdateAchievemnt, image multipart.File) (int, error) {
-	ret := _m.Called(ctx, req, image)
+// UpdateAchievement provides a mock function with given fields: ctx, uid, req, image
+func (_m *SchoolService) UpdateAchievement(ctx context.Context, uid int, req entities.ReqUpdateAchievemnt, image multipart.File) (int, error) {
+	ret := _m.Called(ctx, uid, req, image)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdateAchievemnt, multipart.File) (int, error)); ok {
-		return rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateAchievemnt, multipart.File) (int, error)); ok {
+		return rf(ctx, uid, req, image)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdateAchievemnt, multipart.File) int); ok {
-		r0 = rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateAchievemnt, multipart.File) int); ok {
+		r0 = rf(ctx, uid, req, image)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entities.ReqUpdateAchievemnt, multipart.File) error); ok {
-		r1 = rf(ctx, req, image)
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqUpdateAchievemnt, multipart.File) error); ok {
+		r1 = rf(ctx, uid, req, image)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -558,23 +1381,23 @@ func (_m *SchoolService) UpdateAchievement(ctx context.Context, req entities.Req
 	return r0, r1
 }
 
-// UpdateExtracurricular provides a mock function with given fields: ctx, req, image
-func (_m *SchoolService) UpdateExtracurricular(ctx context.Context, req entities.ReqUpdateExtracurricular, image multipart.File) (int, error) {
-	ret := _m.Called(ctx, req, image)
+// UpdateExtracurricular provides a mock function with given fields: ctx, uid, req, image
+func (_m *SchoolService) UpdateExtracurricular(ctx context.Context, uid int, req entities.ReqUpdateExtracurricular, image multipart.File) (int, error) {
+	ret := _m.Called(ctx, uid, req, image)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdateExtracurricular, multipart.File) (int, error)); ok {
-		return rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateExtracurricular, multipart.File) (int, error)); ok {
+		return rf(ctx, uid, req, image)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdateExtracurricular, multipart.File) int); ok {
-		r0 = rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateExtracurricular, multipart.File) int); ok {
+		r0 = rf(ctx, uid, req, image)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entities.ReqUpdateExtracurricular, multipart.File) error); ok {
-		r1 = rf(ctx, req, image)
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqUpdateExtracurricular, multipart.File) error); ok {
+		r1 = rf(ctx, uid, req, image)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -582,23 +1405,75 @@ func (_m *SchoolService) UpdateExtracurricular(ctx context.Context, req entities
 	return r0, r1
 }
 
-// UpdateFaq provides a mock function with given fields: ctx, req
-func (_m *SchoolService) UpdateFaq(ctx context.Context, req entities.ReqUpdateFaq) (int, error) {
-	ret := _m.Called(ctx, req)
+// UpdateFaq provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) UpdateFaq(ctx context.Context, uid int, req entities.ReqUpdateFaq) (int, error) {
+	ret := _m.Called(ctx, uid, req)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdateFaq) (int, error)); ok {
-		return rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateFaq) (int, error)); ok {
+		return rf(ctx, uid, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdateFaq) int); ok {
-		r0 = rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateFaq) int); ok {
+		r0 = rf(ctx, uid, req)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entities.ReqUpdateFaq) error); ok {
-		r1 = rf(ctx, req)
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqUpdateFaq) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLetterTemplate provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) UpdateLetterTemplate(ctx context.Context, uid int, req entities.ReqLetterTemplate) (*entities.ResLetterTemplate, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 *entities.ResLetterTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqLetterTemplate) (*entities.ResLetterTemplate, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqLetterTemplate) *entities.ResLetterTemplate); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResLetterTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqLetterTemplate) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateMember provides a mock function with given fields: ctx, id, uid, req
+func (_m *SchoolService) UpdateMember(ctx context.Context, id int, uid int, req entities.ReqUpdateMember) (*entities.ResMember, error) {
+	ret := _m.Called(ctx, id, uid, req)
+
+	var r0 *entities.ResMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqUpdateMember) (*entities.ResMember, error)); ok {
+		return rf(ctx, id, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqUpdateMember) *entities.ResMember); ok {
+		r0 = rf(ctx, id, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, entities.ReqUpdateMember) error); ok {
+		r1 = rf(ctx, id, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -606,23 +1481,63 @@ func (_m *SchoolService) UpdateFaq(ctx context.Context, req entities.ReqUpdateFa
 	return r0, r1
 }
 
-// UpdatePayment provides a mock function with given fields: ctx, req, image
-func (_m *SchoolService) UpdatePayment(ctx context.Context, req entities.ReqUpdatePayment, image multipart.File) (int, error) {
-	ret := _m.Called(ctx, req, image)
+// UpdatePayment provides a mock function with given fields: ctx, uid, req, image
+func (_m *SchoolService) UpdatePayment(ctx context.Context, uid int, req entities.ReqUpdatePayment, image multipart.File) (int, error) {
+	ret := _m.Called(ctx, uid, req, image)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdatePayment, multipart.File) (int, error)); ok {
-		return rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdatePayment, multipart.File) (int, error)); ok {
+		return rf(ctx, uid, req, image)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entities.ReqUpdatePayment, multipart.File) int); ok {
-		r0 = rf(ctx, req, image)
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdatePayment, multipart.File) int); ok {
+		r0 = rf(ctx, uid, req, image)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entities.ReqUpdatePayment, multipart.File) error); ok {
-		r1 = rf(ctx, req, image)
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqUpdatePayment, multipart.File) error); ok {
+		r1 = rf(ctx, uid, req, image)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePeriod provides a mock function with given fields: ctx, id, uid, req
+func (_m *SchoolService) UpdatePeriod(ctx context.Context, id int, uid int, req entities.ReqAdmissionPeriod) error {
+	ret := _m.Called(ctx, id, uid, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqAdmissionPeriod) error); ok {
+		r0 = rf(ctx, id, uid, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePipeline provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) UpdatePipeline(ctx context.Context, uid int, req entities.ReqUpdatePipeline) (*entities.ResPipeline, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 *entities.ResPipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdatePipeline) (*entities.ResPipeline, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdatePipeline) *entities.ResPipeline); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResPipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqUpdatePipeline) error); ok {
+		r1 = rf(ctx, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -630,23 +1545,125 @@ func (_m *SchoolService) UpdatePayment(ctx context.Context, req entities.ReqUpda
 	return r0, r1
 }
 
-// UpdateProgressByid provides a mock function with given fields: ctx, id, status
-func (_m *SchoolService) UpdateProgressByid(ctx context.Context, id int, status string) (int, error) {
-	ret := _m.Called(ctx, id, status)
+// UpdateProgressByid provides a mock function with given fields: ctx, id, uid, req
+func (_m *SchoolService) UpdateProgressByid(ctx context.Context, id int, uid int, req entities.ReqUpdateProgress) (int, error) {
+	ret := _m.Called(ctx, id, uid, req)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, string) (int, error)); ok {
-		return rf(ctx, id, status)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqUpdateProgress) (int, error)); ok {
+		return rf(ctx, id, uid, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, string) int); ok {
-		r0 = rf(ctx, id, status)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqUpdateProgress) int); ok {
+		r0 = rf(ctx, id, uid, req)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
-		r1 = rf(ctx, id, status)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, entities.ReqUpdateProgress) error); ok {
+		r1 = rf(ctx, id, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateQuotas provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) UpdateQuotas(ctx context.Context, uid int, req entities.ReqUpdateQuota) ([]entities.ResQuota, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 []entities.ResQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateQuota) ([]entities.ResQuota, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateQuota) []entities.ResQuota); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResQuota)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqUpdateQuota) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateSelectionCriteria provides a mock function with given fields: ctx, uid, req
+func (_m *SchoolService) UpdateSelectionCriteria(ctx context.Context, uid int, req entities.ReqUpdateSelection) ([]entities.ResSelectionCriterion, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 []entities.ResSelectionCriterion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateSelection) ([]entities.ResSelectionCriterion, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqUpdateSelection) []entities.ResSelectionCriterion); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResSelectionCriterion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqUpdateSelection) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyLetter provides a mock function with given fields: ctx, reference
+func (_m *SchoolService) VerifyLetter(ctx context.Context, reference string) (*entities.ResLetterVerification, error) {
+	ret := _m.Called(ctx, reference)
+
+	var r0 *entities.ResLetterVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.ResLetterVerification, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entities.ResLetterVerification); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResLetterVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Withdraw provides a mock function with given fields: ctx, id, uid, req
+func (_m *SchoolService) Withdraw(ctx context.Context, id int, uid int, req entities.ReqWithdraw) (int, error) {
+	ret := _m.Called(ctx, id, uid, req)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqWithdraw) (int, error)); ok {
+		return rf(ctx, id, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqWithdraw) int); ok {
+		r0 = rf(ctx, id, uid, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, entities.ReqWithdraw) error); ok {
+		r1 = rf(ctx, id, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,12 +1,17 @@
 package repository
 
 import (
+	"encoding/json"
 	"reflect"
+	"strings"
+	"time"
 
+	"github.com/education-hub/BE/app/admission"
 	entity "github.com/education-hub/BE/app/entities"
 	"github.com/education-hub/BE/errorr"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
@@ -17,31 +22,89 @@ type (
 		Create(db *gorm.DB, school entity.School) (int, error)
 		FindByNPSN(db *gorm.DB, npsn string) error
 		Update(db *gorm.DB, school entity.School) (*entity.School, error)
-		Delete(db *gorm.DB, id int, uid int) error
-		AddAchievement(db *gorm.DB, achv entity.Achievement) (int, error)
+		Delete(db *gorm.DB, id int) error
+		AddAchievement(db *gorm.DB, achv entity.Achievement) (*entity.Achievement, error)
 		DeleteAchievement(db *gorm.DB, id int) error
 		UpdateAchievement(db *gorm.DB, achv entity.Achievement) (*entity.Achievement, error)
-		AddExtracurricular(db *gorm.DB, achv entity.Extracurricular) (int, error)
+		AddExtracurricular(db *gorm.DB, achv entity.Extracurricular) (*entity.Extracurricular, error)
 		DeleteExtracurricular(db *gorm.DB, id int) error
 		UpdateExtracurricular(db *gorm.DB, achv entity.Extracurricular) (*entity.Extracurricular, error)
-		GetByUid(db *gorm.DB, uid int) (*entity.School, error)
+		GetMember(db *gorm.DB, uid int) (*entity.SchoolMember, error)
 		GetById(db *gorm.DB, id int) (*entity.School, error)
-		AddFaq(db *gorm.DB, faq entity.Faq) (int, error)
+		AddFaq(db *gorm.DB, faq entity.Faq) (*entity.Faq, error)
 		DeleteFaq(db *gorm.DB, id int) error
 		UpdateFaq(db *gorm.DB, extrac entity.Faq) (*entity.Faq, error)
-		AddPayment(db *gorm.DB, paym entity.Payment) (int, error)
+		AddPayment(db *gorm.DB, paym entity.Payment) (*entity.Payment, error)
 		DeletePayment(db *gorm.DB, id int) error
+		GetPaymentById(db *gorm.DB, id int) (*entity.Payment, error)
 		GetAll(db *gorm.DB, limit, offset int, search string) ([]entity.School, int, error)
 		UpdatePayment(db *gorm.DB, paym entity.Payment) (*entity.Payment, error)
 		CreateSubmission(db *gorm.DB, subm entity.Submission) (int, error)
-		UpdateProgress(db *gorm.DB, id int, status string) (*entity.Progress, error)
+		UpdateProgress(db *gorm.DB, data entity.Progress) (*entity.Progress, error)
+		UpdateSubmission(db *gorm.DB, id int, data map[string]any) error
 		GetAllProgressByuid(db *gorm.DB, uid int) ([]entity.Progress, error)
 		GetProgressByid(db *gorm.DB, id int) (*entity.Progress, error)
-		GetAllProgressAndSubmissionByuid(db *gorm.DB, uid int) (*entity.School, error)
+		LockProgress(db *gorm.DB, id int) (*entity.Progress, error)
+		GetAllProgressAndSubmission(db *gorm.DB, schid int) (*entity.School, error)
 		GetSubmissionByid(db *gorm.DB, id int) (*entity.Submission, error)
 		DeleteProgressByid(db *gorm.DB, id int) error
 		AddReview(db *gorm.DB, data entity.Reviews) (int, error)
-		UpdateProgressByUid(db *gorm.DB, uid int, schid int, status string) (int, error)
+		GetActiveProgressByUid(db *gorm.DB, uid int, schid int) (*entity.Progress, error)
+		GetOtherActiveProgressByUid(db *gorm.DB, uid int, schid int) ([]entity.Progress, error)
+		CreateCart(db *gorm.DB, cart entity.Carts) error
+		DeleteCartByUid(db *gorm.DB, uid int) error
+		GetPipeline(db *gorm.DB, schid int) ([]entity.PipelineStep, error)
+		UpdatePipeline(db *gorm.DB, schid int, steps []entity.PipelineStep) error
+		GetActiveStatusBySchool(db *gorm.DB, schid int) ([]string, error)
+		CreateProgressEvent(db *gorm.DB, event entity.ProgressEvent) error
+		GetProgressEvents(db *gorm.DB, progid int) ([]entity.ProgressEvent, error)
+		CreateNote(db *gorm.DB, note entity.AdmissionNote) error
+		GetNotes(db *gorm.DB, progid int, internal bool) ([]entity.AdmissionNote, error)
+		GetLastProgress(db *gorm.DB, uid int, schid int) (*entity.Progress, error)
+		GetQuotas(db *gorm.DB, schid int) ([]entity.Quota, error)
+		LockQuotas(db *gorm.DB, schid int) ([]entity.Quota, error)
+		UpdateQuotas(db *gorm.DB, schid int, quotas []entity.Quota) error
+		CountSeats(db *gorm.DB, schid int, track string) (int, error)
+		GetWaitlist(db *gorm.DB, schid int) ([]entity.Progress, error)
+		DeleteCartBySchool(db *gorm.DB, uid int, schid int) error
+		GetOpenPeriod(db *gorm.DB, schid int, now time.Time) (*entity.AdmissionPeriod, error)
+		GetPeriods(db *gorm.DB, schid int) ([]entity.AdmissionPeriod, error)
+		GetPeriodById(db *gorm.DB, id int) (*entity.AdmissionPeriod, error)
+		IsPeriodOverlapping(db *gorm.DB, period entity.AdmissionPeriod) (bool, error)
+		CreatePeriod(db *gorm.DB, period entity.AdmissionPeriod) (int, error)
+		UpdatePeriod(db *gorm.DB, period entity.AdmissionPeriod) error
+		DeletePeriod(db *gorm.DB, id int) error
+		GetOverdueProgress(db *gorm.DB, now time.Time) ([]entity.Progress, error)
+		UpdateLocation(db *gorm.DB, schid int, lat, lng float64) error
+		GetApplicants(db *gorm.DB, schid int, track string) ([]entity.Applicant, error)
+		GetSelectionCriteria(db *gorm.DB, schid int) ([]entity.SelectionCriterion, error)
+		UpdateSelectionCriteria(db *gorm.DB, schid int, criteria []entity.SelectionCriterion) error
+		HasAppeal(db *gorm.DB, progid int) (bool, error)
+		CreateAppeal(db *gorm.DB, appeal entity.Appeal) (*entity.Appeal, error)
+		GetAppealById(db *gorm.DB, id int) (*entity.Appeal, error)
+		DecideAppeal(db *gorm.DB, appeal entity.Appeal) error
+		GetAppealsBySchool(db *gorm.DB, schid int, status string) ([]entity.Appeal, error)
+		GetAppealsByUid(db *gorm.DB, uid int) ([]entity.Appeal, error)
+		GetLastSubmission(db *gorm.DB, uid int, schid int) (*entity.Submission, error)
+		GetLetterTemplate(db *gorm.DB, schid int) (*entity.LetterTemplate, error)
+		UpdateLetterTemplate(db *gorm.DB, tmpl entity.LetterTemplate) error
+		GetLetterByProgress(db *gorm.DB, progid int) (*entity.AcceptanceLetter, error)
+		GetLetterByReference(db *gorm.DB, reference string) (*entity.AcceptanceLetter, error)
+		CreateLetter(db *gorm.DB, letter entity.AcceptanceLetter) (*entity.AcceptanceLetter, error)
+		GetSchoolIdOf(db *gorm.DB, model any, id int) (int, error)
+		GetFileOwner(db *gorm.DB, fname string) (int, bool, error)
+		GetItemById(db *gorm.DB, model any, id int) error
+		GetMembers(db *gorm.DB, schid int) ([]entity.SchoolMember, error)
+		GetMemberById(db *gorm.DB, id int) (*entity.SchoolMember, error)
+		UpdateMember(db *gorm.DB, member entity.SchoolMember) error
+		DeleteMember(db *gorm.DB, id int) error
+		CountOwners(db *gorm.DB, schid int) (int, error)
+		CreateInvitation(db *gorm.DB, invitation entity.SchoolInvitation) (*entity.SchoolInvitation, error)
+		GetInvitations(db *gorm.DB, schid int) ([]entity.SchoolInvitation, error)
+		GetInvitationById(db *gorm.DB, id int) (*entity.SchoolInvitation, error)
+		GetInvitationByToken(db *gorm.DB, tokenhash string) (*entity.SchoolInvitation, error)
+		AcceptInvitation(db *gorm.DB, invitation entity.SchoolInvitation, member entity.SchoolMember) error
+		DeleteInvitation(db *gorm.DB, id int) error
 	}
 )
 
@@ -49,31 +112,42 @@ func NewSchoolRepo(log *logrus.Logger) SchoolRepo {
 	return &school{log}
 }
 
+// Create registers the school with the admin who registers it as its owner.
 func (u *school) Create(db *gorm.DB, school entity.School) (int, error) {
-	if err := db.Where("user_id=?", school.UserID).First(&entity.School{}).Error; err == nil {
+	if err := db.Where("user_id=?", school.UserID).First(&entity.SchoolMember{}).Error; err == nil {
 		return 0, errorr.NewBad("You have already registered your school")
 	}
-	if err := db.Create(&school).Error; err != nil {
+	err := db.Transaction(func(db *gorm.DB) error {
+		if err := db.Create(&school).Error; err != nil {
+			return err
+		}
+		return db.Create(&entity.SchoolMember{SchoolID: school.ID, UserID: school.UserID, Role: "owner"}).Error
+	})
+	if err != nil {
 		u.log.Errorf("[ERROR]WHEN CREATE USER,Error: %v ", err)
 		return 0, errorr.NewInternal("Internal Server Error")
 	}
 	return int(school.ID), nil
 }
-func (u *school) GetByUid(db *gorm.DB, uid int) (*entity.School, error) {
-	res := entity.School{}
-	if err := db.Preload("Achievements", func(db *gorm.DB) *gorm.DB {
-		return db.Select("id,school_id,description,image,title")
-	}).Preload("Extracurriculars", func(db *gorm.DB) *gorm.DB {
-		return db.Select("id,school_id,description,image,title")
-	}).Preload("Faqs", func(db *gorm.DB) *gorm.DB {
-		return db.Select("id,school_id,question,answer")
-	}).Preload("Reviews", func(db *gorm.DB) *gorm.DB {
-		return db.Preload("User").Select("user_id,school_id,review")
-	}).Preload("Payments").Where("user_id=?", uid).Find(&res).Error; err != nil {
-		u.log.Errorf("[ERROR]WHEN GETTING The School Data BY UID, Err: %v", err)
+
+// GetMember returns the membership of a staff account along with its school.
+func (u *school) GetMember(db *gorm.DB, uid int) (*entity.SchoolMember, error) {
+	res := entity.SchoolMember{}
+	if err := db.Preload("School", func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Achievements", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id,school_id,description,image,title")
+		}).Preload("Extracurriculars", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id,school_id,description,image,title")
+		}).Preload("Faqs", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id,school_id,question,answer")
+		}).Preload("Reviews", func(db *gorm.DB) *gorm.DB {
+			return db.Preload("User").Select("user_id,school_id,review")
+		}).Preload("Payments")
+	}).Where("user_id=?", uid).Find(&res).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN GETTING The School Member BY UID, Err: %v", err)
 		return nil, errorr.NewInternal("Internal Server Error")
 	}
-	if res.Name == "" {
+	if res.ID == 0 || res.School.Name == "" {
 		return nil, errorr.NewBad("Data Not Found")
 	}
 	return &res, nil
@@ -95,9 +169,9 @@ func (u *school) GetAll(db *gorm.DB, limit, offset int, search string) ([]entity
 	return res, int(total), nil
 }
 
-func (u *school) Delete(db *gorm.DB, id int, uid int) error {
+func (u *school) Delete(db *gorm.DB, id int) error {
 
-	if err := db.Where("id=? AND user_id=?", id, uid).First(&entity.School{}).Error; err != nil {
+	if err := db.Where("id=?", id).First(&entity.School{}).Error; err != nil {
 		if err != gorm.ErrRecordNotFound {
 			u.log.Errorf("[ERROR]WHEN GETTING The Achievement Data, Err: %v", err)
 			return errorr.NewInternal("Internal Server Error")
@@ -106,7 +180,7 @@ func (u *school) Delete(db *gorm.DB, id int, uid int) error {
 		return errorr.NewBad("Id Not Found")
 	}
 	progress := entity.Progress{}
-	if err := db.Where("school_id=? AND status != 'Failed Test Result' AND status != 'Failed File Approved' AND status != 'Finish'", id).Find(&progress).Error; err != nil {
+	if err := db.Where("school_id=? AND status NOT IN ?", id, admission.Closed).Find(&progress).Error; err != nil {
 		if err != gorm.ErrRecordNotFound {
 			u.log.Errorf("[ERROR]WHEN GETTING The Achievement Data, Err: %v", err)
 			return errorr.NewInternal("Internal Server Error")
@@ -116,7 +190,17 @@ func (u *school) Delete(db *gorm.DB, id int, uid int) error {
 	if progress.ID != 0 {
 		return errorr.NewBad("There are still participants enrolling in your school")
 	}
-	if err := db.Where("id=?", id).Delete(&entity.School{}).Error; err != nil {
+	err := db.Transaction(func(db *gorm.DB) error {
+		if err := db.Where("id=?", id).Delete(&entity.School{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("school_id=?", id).Delete(&entity.SchoolInvitation{}).Error; err != nil {
+			return err
+		}
+		// the staff is free to register or join another school
+		return db.Where("school_id=?", id).Delete(&entity.SchoolMember{}).Error
+	})
+	if err != nil {
 		u.log.Errorf("[ERROR]WHEN DELETING Achievement, Err: %v", err)
 		return errorr.NewInternal("Internal Server Error")
 	}
@@ -178,12 +262,12 @@ func (s *school) Update(db *gorm.DB, school entity.School) (*entity.School, erro
 	return &newdata, nil
 }
 
-func (u *school) AddAchievement(db *gorm.DB, achv entity.Achievement) (int, error) {
+func (u *school) AddAchievement(db *gorm.DB, achv entity.Achievement) (*entity.Achievement, error) {
 	if err := db.Save(&achv).Error; err != nil {
 		u.log.Errorf("[ERROR]WHEN ADDING ACHIEVEMENT, Err: %v", err)
-		return 0, errorr.NewInternal("internal Server Error")
+		return nil, errorr.NewInternal("internal Server Error")
 	}
-	return int(achv.SchoolID), nil
+	return &achv, nil
 }
 
 func (u *school) DeleteAchievement(db *gorm.DB, id int) error {
@@ -225,12 +309,12 @@ func (s *school) UpdateAchievement(db *gorm.DB, achv entity.Achievement) (*entit
 	return &newdata, nil
 }
 
-func (u *school) AddExtracurricular(db *gorm.DB, extrac entity.Extracurricular) (int, error) {
+func (u *school) AddExtracurricular(db *gorm.DB, extrac entity.Extracurricular) (*entity.Extracurricular, error) {
 	if err := db.Save(&extrac).Error; err != nil {
 		u.log.Errorf("[ERROR]WHEN ADDING Extracurricular, Err: %v", err)
-		return 0, errorr.NewInternal("internal Server Error")
+		return nil, errorr.NewInternal("internal Server Error")
 	}
-	return int(extrac.SchoolID), nil
+	return &extrac, nil
 }
 
 func (u *school) DeleteExtracurricular(db *gorm.DB, id int) error {
@@ -272,12 +356,12 @@ func (s *school) UpdateExtracurricular(db *gorm.DB, extrac entity.Extracurricula
 	return &newdata, nil
 }
 
-func (u *school) AddFaq(db *gorm.DB, faq entity.Faq) (int, error) {
+func (u *school) AddFaq(db *gorm.DB, faq entity.Faq) (*entity.Faq, error) {
 	if err := db.Save(&faq).Error; err != nil {
 		u.log.Errorf("[ERROR]WHEN ADDING Faq, Err: %v", err)
-		return 0, errorr.NewInternal("internal Server Error")
+		return nil, errorr.NewInternal("internal Server Error")
 	}
-	return int(faq.SchoolID), nil
+	return &faq, nil
 }
 
 func (u *school) DeleteFaq(db *gorm.DB, id int) error {
@@ -318,12 +402,24 @@ func (s *school) UpdateFaq(db *gorm.DB, extrac entity.Faq) (*entity.Faq, error)
 	return &newdata, nil
 }
 
-func (u *school) AddPayment(db *gorm.DB, paym entity.Payment) (int, error) {
+func (u *school) AddPayment(db *gorm.DB, paym entity.Payment) (*entity.Payment, error) {
 	if err := db.Save(&paym).Error; err != nil {
 		u.log.Errorf("[ERROR]WHEN ADDING Payment, Err: %v", err)
-		return 0, errorr.NewInternal("internal Server Error")
+		return nil, errorr.NewInternal("internal Server Error")
+	}
+	return &paym, nil
+}
+
+func (u *school) GetPaymentById(db *gorm.DB, id int) (*entity.Payment, error) {
+	res := entity.Payment{}
+	if err := db.First(&res, id).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			u.log.Errorf("[ERROR]WHEN GETTING The Payment Data, Err: %v", err)
+			return nil, errorr.NewInternal("Internal Server Error")
+		}
+		return nil, errorr.NewBad("Id Not Found")
 	}
-	return int(paym.SchoolID), nil
+	return &res, nil
 }
 
 func (u *school) DeletePayment(db *gorm.DB, id int) error {
@@ -373,14 +469,14 @@ func (s *school) UpdatePayment(db *gorm.DB, paym entity.Payment) (*entity.Paymen
 }
 
 func (s *school) CreateSubmission(db *gorm.DB, subm entity.Submission) (int, error) {
-	progress := entity.Progress{UserID: subm.UserID, SchoolID: subm.SchoolID, Status: "Check File Registration"}
+	progress := entity.Progress{UserID: subm.UserID, SchoolID: subm.SchoolID, Status: string(admission.Initial), Track: subm.Track, PeriodID: subm.PeriodID}
 	err := db.Transaction(func(db *gorm.DB) error {
 		existdata1 := entity.Progress{}
-		if err := db.Where("user_id=? AND status ='Finish'", subm.UserID).First(&existdata1).Error; err == nil {
+		if err := db.Where("user_id=? AND status = ?", subm.UserID, admission.Finish).First(&existdata1).Error; err == nil {
 			return errorr.NewBad("You are already registered as a student")
 		}
 		existdata2 := entity.Submission{}
-		if err := db.Joins("JOIN progresses p  on p.user_id= submissions.user_id AND submissions.school_id = p.school_id").Where("submissions.user_id=? AND submissions.school_id=? AND p.status != 'Failed File Approved' AND p.status != 'Failed Test Result' ", subm.UserID, subm.SchoolID).Find(&existdata2).Error; err != nil {
+		if err := db.Joins("JOIN progresses p  on p.user_id= submissions.user_id AND submissions.school_id = p.school_id").Where("submissions.user_id=? AND submissions.school_id=? AND p.status NOT IN ?", subm.UserID, subm.SchoolID, admission.Released).Find(&existdata2).Error; err != nil {
 			return errorr.NewInternal("Internal Server Error")
 		}
 		if existdata2.StudentName != "" {
@@ -393,6 +489,10 @@ func (s *school) CreateSubmission(db *gorm.DB, subm entity.Submission) (int, err
 		if err := db.Create(&progress).Error; err != nil {
 			return errorr.NewInternal("Internal server error")
 		}
+		if err := db.Create(&entity.ProgressEvent{ProgressID: progress.ID, ToStatus: progress.Status, ActorID: subm.UserID}).Error; err != nil {
+			s.log.Errorf("[ERROR]WHEN CREATING PROGRESS EVENT, Err: %v", err)
+			return errorr.NewInternal("Internal server error")
+		}
 		return nil
 	})
 	if err != nil {
@@ -401,80 +501,281 @@ func (s *school) CreateSubmission(db *gorm.DB, subm entity.Submission) (int, err
 	return int(progress.ID), nil
 }
 
-func (s *school) UpdateProgress(db *gorm.DB, id int, status string) (*entity.Progress, error) {
+func (s *school) UpdateProgress(db *gorm.DB, data entity.Progress) (*entity.Progress, error) {
 	prog := entity.Progress{}
-	if err := db.Where("status != 'Finish' AND status != 'Failed Test Result' AND status != 'Failed File Approved'").First(&prog, id).Error; err != nil {
+	if err := db.First(&prog, data.ID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errorr.NewBad("Data not found")
 		}
 		s.log.Errorf("[ERORR]WHEN GETTING Progress DATA, Err: %v", err)
 		return nil, errorr.NewInternal("Internal Server Error")
 	}
-	if status == "Finish" {
-		err := db.Transaction(func(db *gorm.DB) error {
-			if prog.Status != "Already Paid Her-Registration" {
-				return errorr.NewBad("Participant has not paid her registration fee")
-			}
-			db.Model(&entity.Progress{}).Where("user_id = ? AND school_id != ?", prog.UserID, prog.SchoolID).Update("status", "Failed Test Result")
-			db.Where("user_id=?", prog.UserID).Delete(&entity.Carts{})
-			return nil
-		})
-		if err != nil {
-			return nil, err
-		}
-	} else if status == "Send Detail Costs Registration" {
-		if prog.Status != "File Approved" {
-			return nil, errorr.NewBad("Participant registration form has not been approved")
-		}
-		db.Create(&entity.Carts{UserID: prog.UserID, SchoolID: prog.SchoolID, Type: "registration"})
-	} else if status == "File Approved" || status == "Failed File Approved" {
-		if prog.Status != "Check File Registration" {
-			return nil, errorr.NewBad("participant has not submitted the registration form")
-		}
-	} else if status == "Send Detail Costs Her-Registration" {
-		err := db.Transaction(func(db *gorm.DB) error {
-			if prog.Status != "Test Result" {
-				return errorr.NewBad("participant has not taken the test or did not pass the test")
-			}
-			db.Create(&entity.Carts{UserID: prog.UserID, SchoolID: prog.SchoolID, Type: "herregistration"})
-			return nil
-		})
-		if err != nil {
-			return nil, err
-		}
-	} else if status == "Send Test Link" {
-		if prog.Status != "Done Payment" {
-			return nil, errorr.NewBad("participant has not taken the test or did not pass the test")
-		}
-	}
-	prog.Status = status
+	prog.Status = data.Status
+	prog.RejectionReason = data.RejectionReason
+	prog.RevisionFields = data.RevisionFields
+	prog.WaitlistedAt = data.WaitlistedAt
 	if err := db.Save(&prog).Error; err != nil {
-		s.log.Errorf("[ERROR]WHEN UPDATING Submission, Err : %v", err)
+		s.log.Errorf("[ERROR]WHEN UPDATING PROGRESS, Err : %v", err)
 		return nil, errorr.NewInternal("Internal Server Erorr")
 	}
 	return &prog, nil
 }
-func (s *school) UpdateProgressByUid(db *gorm.DB, uid int, schid int, status string) (int, error) {
+func (s *school) UpdateSubmission(db *gorm.DB, id int, data map[string]any) error {
+	if err := db.Model(&entity.Submission{}).Where("id=?", id).Updates(data).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN UPDATING SUBMISSION, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+func (s *school) GetActiveProgressByUid(db *gorm.DB, uid int, schid int) (*entity.Progress, error) {
 	progress := entity.Progress{}
-	if err := db.Where("status != 'Finish' AND status != 'Failed Test Result' AND status != 'Failed File Approved' AND user_id=? AND school_id=?", uid, schid).First(&progress).Error; err != nil {
+	if err := db.Where("status NOT IN ? AND user_id=? AND school_id=?", admission.Closed, uid, schid).First(&progress).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return 0, errorr.NewBad("Data not found")
+			return nil, errorr.NewBad("Data not found")
 		}
 		s.log.Errorf("[ERORR]WHEN GETTING Progress DATA, Err: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &progress, nil
+}
+func (s *school) GetOtherActiveProgressByUid(db *gorm.DB, uid int, schid int) ([]entity.Progress, error) {
+	res := []entity.Progress{}
+	if err := db.Where("user_id = ? AND school_id != ? AND status NOT IN ?", uid, schid, admission.Closed).Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING OTHER PROGRESS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) CreateCart(db *gorm.DB, cart entity.Carts) error {
+	if err := db.Create(&cart).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN CREATING CART, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+func (s *school) DeleteCartByUid(db *gorm.DB, uid int) error {
+	if err := db.Where("user_id=?", uid).Delete(&entity.Carts{}).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN DELETING CART, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+func (s *school) GetPipeline(db *gorm.DB, schid int) ([]entity.PipelineStep, error) {
+	res := []entity.PipelineStep{}
+	if err := db.Where("school_id=?", schid).Order("position").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING PIPELINE DATA, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) UpdatePipeline(db *gorm.DB, schid int, steps []entity.PipelineStep) error {
+	return db.Transaction(func(db *gorm.DB) error {
+		if err := db.Where("school_id=?", schid).Delete(&entity.PipelineStep{}).Error; err != nil {
+			s.log.Errorf("[ERROR]WHEN DELETING PIPELINE, Err : %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		if len(steps) == 0 {
+			return nil
+		}
+		if err := db.Create(&steps).Error; err != nil {
+			s.log.Errorf("[ERROR]WHEN CREATING PIPELINE, Err : %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		return nil
+	})
+}
+func (s *school) GetActiveStatusBySchool(db *gorm.DB, schid int) ([]string, error) {
+	res := []string{}
+	if err := db.Model(&entity.Progress{}).Distinct("status").Where("school_id=? AND status NOT IN ?", schid, admission.Closed).Pluck("status", &res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING PROGRESS STATUS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) CreateProgressEvent(db *gorm.DB, event entity.ProgressEvent) error {
+	if err := db.Create(&event).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN CREATING PROGRESS EVENT, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+func (s *school) GetProgressEvents(db *gorm.DB, progid int) ([]entity.ProgressEvent, error) {
+	res := []entity.ProgressEvent{}
+	if err := db.Where("progress_id=?", progid).Order("created_at, id").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING PROGRESS EVENTS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) CreateNote(db *gorm.DB, note entity.AdmissionNote) error {
+	if err := db.Create(&note).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN CREATING ADMISSION NOTE, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+func (s *school) GetNotes(db *gorm.DB, progid int, internal bool) ([]entity.AdmissionNote, error) {
+	res := []entity.AdmissionNote{}
+	query := db.Where("progress_id=?", progid)
+	if !internal {
+		query = query.Where("internal = ?", false)
+	}
+	if err := query.Order("created_at, id").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING ADMISSION NOTES, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) GetLastProgress(db *gorm.DB, uid int, schid int) (*entity.Progress, error) {
+	res := entity.Progress{}
+	if err := db.Where("user_id=? AND school_id=?", uid, schid).Order("id desc").First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING Student Progress Data, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+func (s *school) GetQuotas(db *gorm.DB, schid int) ([]entity.Quota, error) {
+	res := []entity.Quota{}
+	if err := db.Where("school_id=?", schid).Order("track").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING QUOTA DATA, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+
+// LockQuotas reads the quotas of a school and locks them until the transaction ends,
+// so two participants can not take the last seat at the same time.
+func (s *school) LockQuotas(db *gorm.DB, schid int) ([]entity.Quota, error) {
+	return s.GetQuotas(db.Clauses(clause.Locking{Strength: "UPDATE"}), schid)
+}
+func (s *school) UpdateQuotas(db *gorm.DB, schid int, quotas []entity.Quota) error {
+	return db.Transaction(func(db *gorm.DB) error {
+		if err := db.Where("school_id=?", schid).Delete(&entity.Quota{}).Error; err != nil {
+			s.log.Errorf("[ERROR]WHEN DELETING QUOTA, Err : %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		if len(quotas) == 0 {
+			return nil
+		}
+		if err := db.Create(&quotas).Error; err != nil {
+			s.log.Errorf("[ERROR]WHEN CREATING QUOTA, Err : %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		return nil
+	})
+}
+
+// CountSeats counts the seats taken at a school, an empty track counts every track.
+func (s *school) CountSeats(db *gorm.DB, schid int, track string) (int, error) {
+	var res int64
+	query := db.Model(&entity.Progress{}).Where("school_id=? AND status IN ?", schid, admission.Seated)
+	if track != "" {
+		query = query.Where("track=?", track)
+	}
+	if err := query.Count(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN COUNTING SEATS, Err : %v", err)
 		return 0, errorr.NewInternal("Internal Server Error")
 	}
-	progress.Status = status
-	if err := db.Save(&progress).Error; err != nil {
-		s.log.Errorf("[ERROR]WHEN UPDATING PROGRESS, Err : %v", err)
-		return 0, errorr.NewInternal("Internal Server Erorr")
+	return int(res), nil
+}
+func (s *school) GetWaitlist(db *gorm.DB, schid int) ([]entity.Progress, error) {
+	res := []entity.Progress{}
+	if err := db.Where("school_id=? AND status=?", schid, admission.Waitlisted).Order("waitlisted_at, id").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING WAITLIST, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
 	}
-	return int(progress.ID), nil
+	return res, nil
+}
+func (s *school) DeleteCartBySchool(db *gorm.DB, uid int, schid int) error {
+	if err := db.Where("user_id=? AND school_id=?", uid, schid).Delete(&entity.Carts{}).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN DELETING CART, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+func (s *school) GetOpenPeriod(db *gorm.DB, schid int, now time.Time) (*entity.AdmissionPeriod, error) {
+	res := entity.AdmissionPeriod{}
+	if err := db.Where("school_id=? AND open_at <= ? AND close_at > ?", schid, now, now).First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Admission is not open at this school")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING ADMISSION PERIOD, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+func (s *school) GetPeriods(db *gorm.DB, schid int) ([]entity.AdmissionPeriod, error) {
+	res := []entity.AdmissionPeriod{}
+	if err := db.Where("school_id=?", schid).Order("open_at").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING ADMISSION PERIODS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) GetPeriodById(db *gorm.DB, id int) (*entity.AdmissionPeriod, error) {
+	res := entity.AdmissionPeriod{}
+	if err := db.First(&res, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING ADMISSION PERIOD, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+
+// IsPeriodOverlapping reports whether another period of the school is open at the same time.
+func (s *school) IsPeriodOverlapping(db *gorm.DB, period entity.AdmissionPeriod) (bool, error) {
+	var res int64
+	if err := db.Model(&entity.AdmissionPeriod{}).Where("school_id=? AND id != ? AND open_at < ? AND close_at > ?", period.SchoolID, period.ID, period.CloseAt, period.OpenAt).Count(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN CHECKING ADMISSION PERIOD, Err : %v", err)
+		return false, errorr.NewInternal("Internal Server Error")
+	}
+	return res > 0, nil
+}
+func (s *school) CreatePeriod(db *gorm.DB, period entity.AdmissionPeriod) (int, error) {
+	if err := db.Create(&period).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN CREATING ADMISSION PERIOD, Err : %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	return int(period.ID), nil
+}
+func (s *school) UpdatePeriod(db *gorm.DB, period entity.AdmissionPeriod) error {
+	if err := db.Save(&period).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN UPDATING ADMISSION PERIOD, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+func (s *school) DeletePeriod(db *gorm.DB, id int) error {
+	if err := db.Where("period_id=?", id).First(&entity.Progress{}).Error; err == nil {
+		return errorr.NewBad("Admission period already has participants")
+	}
+	if err := db.Delete(&entity.AdmissionPeriod{}, id).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN DELETING ADMISSION PERIOD, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// GetOverdueProgress returns the progresses that stayed in a waiting status longer than the
+// step deadline of their admission period.
+func (s *school) GetOverdueProgress(db *gorm.DB, now time.Time) ([]entity.Progress, error) {
+	res := []entity.Progress{}
+	if err := db.Joins("JOIN admission_periods ap ON ap.id = progresses.period_id").Where("ap.step_deadline > 0 AND progresses.status IN ? AND progresses.updated_at < DATE_SUB(?, INTERVAL ap.step_deadline DAY)", admission.Waiting, now).Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING OVERDUE PROGRESS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
 }
 func (s *school) GetAllProgressByuid(db *gorm.DB, uid int) ([]entity.Progress, error) {
 	res := []entity.Progress{}
 	if err := db.Preload("School", func(db *gorm.DB) *gorm.DB {
 		return db.Select("id,image,name,web")
-	}).Where("user_id=? AND status != 'Failed Test Result' AND status != 'Failed File Approved' AND status !='Finish'", uid).Find(&res).Error; err != nil {
+	}).Where("user_id=? AND status NOT IN ?", uid, admission.Closed).Find(&res).Error; err != nil {
 		s.log.Errorf("[ERROR]WHEN GETTING Student Progress Data, Err : %v", err)
 		return nil, errorr.NewInternal("Internal Server Error")
 	}
@@ -496,15 +797,21 @@ func (s *school) GetProgressByid(db *gorm.DB, id int) (*entity.Progress, error)
 	return &res, nil
 }
 
-func (s *school) GetAllProgressAndSubmissionByuid(db *gorm.DB, uid int) (*entity.School, error) {
+// LockProgress reads the progress and locks it until the transaction ends, so two changes of
+// the same progress are applied one after the other.
+func (s *school) LockProgress(db *gorm.DB, id int) (*entity.Progress, error) {
+	return s.GetProgressByid(db.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (s *school) GetAllProgressAndSubmission(db *gorm.DB, schid int) (*entity.School, error) {
 	res := entity.School{}
 	if err := db.Preload("Progresses", func(db *gorm.DB) *gorm.DB {
 		return db.Preload("User", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id,first_name,sure_name,image")
+			return db.Select("id,first_name,sure_name,image,email")
 		}).Select("school_id,id,user_id,status")
 	}).Preload("Submissions", func(db *gorm.DB) *gorm.DB {
-		return db.Select("id,school_id")
-	}).Joins("join progresses p on p.school_id=schools.id").Where("schools.user_id=? AND p.deleted_at IS NULL", uid).Find(&res).Error; err != nil {
+		return db.Select("id,school_id,user_id,student_name,student_latitude,student_longitude,report_average,achievements")
+	}).Joins("join progresses p on p.school_id=schools.id").Where("schools.id=? AND p.deleted_at IS NULL", schid).Find(&res).Error; err != nil {
 		s.log.Errorf("[ERROR]WHEN GETTING PRORGRESS AND SUBMISSION DATA, Err: %v", err)
 		return nil, errorr.NewInternal("Internal Server Erorr")
 	}
@@ -539,3 +846,380 @@ func (s *school) AddReview(db *gorm.DB, data entity.Reviews) (int, error) {
 	}
 	return int(data.SchoolID), nil
 }
+func (s *school) UpdateLocation(db *gorm.DB, schid int, lat, lng float64) error {
+	if err := db.Model(&entity.School{}).Where("id=?", schid).Updates(map[string]any{"latitude": lat, "longitude": lng}).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN UPDATING SCHOOL LOCATION, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// GetApplicants lists the progresses still running at a school together with the latest submission of each participant.
+func (s *school) GetApplicants(db *gorm.DB, schid int, track string) ([]entity.Applicant, error) {
+	res := []entity.Applicant{}
+	query := db.Table("progresses p").
+		Select("p.id AS progress_id, s.id AS submission_id, p.user_id, s.student_name, p.track, p.status, s.student_latitude AS latitude, s.student_longitude AS longitude").
+		Joins("JOIN submissions s ON s.id = (SELECT MAX(id) FROM submissions WHERE user_id = p.user_id AND school_id = p.school_id AND deleted_at IS NULL)").
+		Where("p.school_id=? AND p.status NOT IN ? AND p.deleted_at IS NULL", schid, admission.Closed)
+	if track != "" {
+		query = query.Where("p.track=?", track)
+	}
+	if err := query.Scan(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING APPLICANTS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) GetSelectionCriteria(db *gorm.DB, schid int) ([]entity.SelectionCriterion, error) {
+	res := []entity.SelectionCriterion{}
+	if err := db.Where("school_id=?", schid).Order("id").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING SELECTION CRITERIA, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) UpdateSelectionCriteria(db *gorm.DB, schid int, criteria []entity.SelectionCriterion) error {
+	return db.Transaction(func(db *gorm.DB) error {
+		if err := db.Where("school_id=?", schid).Delete(&entity.SelectionCriterion{}).Error; err != nil {
+			s.log.Errorf("[ERROR]WHEN DELETING SELECTION CRITERIA, Err : %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		if err := db.Create(&criteria).Error; err != nil {
+			s.log.Errorf("[ERROR]WHEN CREATING SELECTION CRITERIA, Err : %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		return nil
+	})
+}
+func (s *school) HasAppeal(db *gorm.DB, progid int) (bool, error) {
+	var res int64
+	if err := db.Model(&entity.Appeal{}).Where("progress_id=?", progid).Count(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN COUNTING APPEAL, Err : %v", err)
+		return false, errorr.NewInternal("Internal Server Error")
+	}
+	return res > 0, nil
+}
+func (s *school) CreateAppeal(db *gorm.DB, appeal entity.Appeal) (*entity.Appeal, error) {
+	if err := db.Create(&appeal).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN CREATING APPEAL, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &appeal, nil
+}
+func (s *school) GetAppealById(db *gorm.DB, id int) (*entity.Appeal, error) {
+	res := entity.Appeal{}
+	if err := db.First(&res, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING APPEAL, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+
+// DecideAppeal stores the decision only while the appeal is still pending, so two admins can not both decide it.
+func (s *school) DecideAppeal(db *gorm.DB, appeal entity.Appeal) error {
+	query := db.Model(&entity.Appeal{}).Where("id=? AND status=?", appeal.ID, admission.AppealPending).Updates(map[string]any{
+		"status":        appeal.Status,
+		"reopen_status": appeal.ReopenStatus,
+		"response":      appeal.Response,
+		"decided_by":    appeal.DecidedBy,
+		"decided_at":    appeal.DecidedAt,
+	})
+	if query.Error != nil {
+		s.log.Errorf("[ERROR]WHEN DECIDING APPEAL, Err : %v", query.Error)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	if query.RowsAffected == 0 {
+		return errorr.NewBad("Appeal has already been decided")
+	}
+	return nil
+}
+func (s *school) GetAppealsBySchool(db *gorm.DB, schid int, status string) ([]entity.Appeal, error) {
+	res := []entity.Appeal{}
+	query := db.Where("school_id=?", schid)
+	if status != "" {
+		query = query.Where("status=?", status)
+	}
+	if err := query.Order("created_at").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING APPEALS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) GetAppealsByUid(db *gorm.DB, uid int) ([]entity.Appeal, error) {
+	res := []entity.Appeal{}
+	if err := db.Where("user_id=?", uid).Order("created_at DESC").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING APPEALS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) GetLastSubmission(db *gorm.DB, uid int, schid int) (*entity.Submission, error) {
+	res := entity.Submission{}
+	if err := db.Where("user_id=? AND school_id=?", uid, schid).Order("id desc").First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING SUBMISSION DATA, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+func (s *school) GetLetterTemplate(db *gorm.DB, schid int) (*entity.LetterTemplate, error) {
+	res := entity.LetterTemplate{}
+	if err := db.Where("school_id=?", schid).First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING LETTER TEMPLATE, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+func (s *school) UpdateLetterTemplate(db *gorm.DB, tmpl entity.LetterTemplate) error {
+	if err := db.Where("school_id=?", tmpl.SchoolID).Assign(entity.LetterTemplate{
+		Title:       tmpl.Title,
+		Body:        tmpl.Body,
+		SignerName:  tmpl.SignerName,
+		SignerTitle: tmpl.SignerTitle,
+	}).FirstOrCreate(&entity.LetterTemplate{SchoolID: tmpl.SchoolID}).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN UPDATING LETTER TEMPLATE, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+func (s *school) GetLetterByProgress(db *gorm.DB, progid int) (*entity.AcceptanceLetter, error) {
+	res := entity.AcceptanceLetter{}
+	if err := db.Where("progress_id=?", progid).First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING ACCEPTANCE LETTER, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+func (s *school) GetLetterByReference(db *gorm.DB, reference string) (*entity.AcceptanceLetter, error) {
+	res := entity.AcceptanceLetter{}
+	if err := db.Where("reference=?", reference).First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING ACCEPTANCE LETTER, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+func (s *school) CreateLetter(db *gorm.DB, letter entity.AcceptanceLetter) (*entity.AcceptanceLetter, error) {
+	if err := db.Create(&letter).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN CREATING ACCEPTANCE LETTER, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &letter, nil
+}
+
+// GetSchoolIdOf returns the school an item such as an achievement or a payment belongs to.
+func (s *school) GetSchoolIdOf(db *gorm.DB, model any, id int) (int, error) {
+	var res []int
+	if err := db.Model(model).Where("id=?", id).Pluck("school_id", &res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING SCHOOL OF ITEM, Err : %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	if len(res) == 0 {
+		return 0, errorr.NewBad("Data Not Found")
+	}
+	return res[0], nil
+}
+
+// GetFileOwner returns the school a stored file belongs to, document is true for the files of an admission
+// such as a submission, an appeal or a letter and false for the school profile and its content.
+func (s *school) GetFileOwner(db *gorm.DB, fname string) (int, bool, error) {
+	quoted, _ := json.Marshal(fname)
+	// the attachments of an appeal are stored as a json list
+	like := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(string(quoted)) + "%"
+	owners := []struct {
+		model    any
+		column   string
+		query    string
+		document bool
+	}{
+		{&entity.Submission{}, "school_id", "student_photo = @f OR student_signature = @f OR parent_signature = @f", true},
+		{&entity.Appeal{}, "school_id", "attachments LIKE @like", true},
+		{&entity.AcceptanceLetter{}, "school_id", "file = @f", true},
+		{&entity.School{}, "id", "image = @f OR pdf = @f", false},
+		{&entity.Achievement{}, "school_id", "image = @f", false},
+		{&entity.Extracurricular{}, "school_id", "image = @f", false},
+		{&entity.Payment{}, "school_id", "image = @f", false},
+	}
+	for _, val := range owners {
+		var res []int
+		if err := db.Model(val.model).Where(val.query, map[string]any{"f": fname, "like": like}).Limit(1).Pluck(val.column, &res).Error; err != nil {
+			s.log.Errorf("[ERROR]WHEN GETTING OWNER OF FILE, Err : %v", err)
+			return 0, false, errorr.NewInternal("Internal Server Error")
+		}
+		if len(res) != 0 {
+			return res[0], val.document, nil
+		}
+	}
+	return 0, false, errorr.NewBad("Data Not Found")
+}
+
+// GetItemById reads an item such as an achievement or a faq into model.
+func (s *school) GetItemById(db *gorm.DB, model any, id int) error {
+	if err := db.First(model, id).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			s.log.Errorf("[ERROR]WHEN GETTING ITEM, Err : %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		return errorr.NewBad("Id Not Found")
+	}
+	return nil
+}
+func (s *school) GetMembers(db *gorm.DB, schid int) ([]entity.SchoolMember, error) {
+	res := []entity.SchoolMember{}
+	if err := db.Preload("User").Where("school_id=?", schid).Order("id").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING SCHOOL MEMBERS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) GetMemberById(db *gorm.DB, id int) (*entity.SchoolMember, error) {
+	res := entity.SchoolMember{}
+	if err := db.Preload("User").First(&res, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING SCHOOL MEMBER, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+
+// errLastOwner is returned when a change would leave the school without an owner to manage it.
+var errLastOwner = errorr.NewBad("A school needs at least one owner")
+
+// UpdateMember changes the role of a member, an owner is only demoted while another one is left.
+func (s *school) UpdateMember(db *gorm.DB, member entity.SchoolMember) error {
+	err := db.Transaction(func(db *gorm.DB) error {
+		if member.Role != "owner" {
+			if err := keepOwner(db, member); err != nil {
+				return err
+			}
+		}
+		return db.Model(&entity.SchoolMember{}).Where("id=?", member.ID).Update("role", member.Role).Error
+	})
+	if err == errLastOwner {
+		return err
+	}
+	if err != nil {
+		s.log.Errorf("[ERROR]WHEN UPDATING SCHOOL MEMBER, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// DeleteMember removes a member, an owner is only removed while another one is left.
+func (s *school) DeleteMember(db *gorm.DB, id int) error {
+	err := db.Transaction(func(db *gorm.DB) error {
+		member := entity.SchoolMember{}
+		if err := db.First(&member, id).Error; err != nil {
+			return err
+		}
+		if err := keepOwner(db, member); err != nil {
+			return err
+		}
+		return db.Delete(&entity.SchoolMember{}, id).Error
+	})
+	if err == errLastOwner {
+		return err
+	}
+	if err != nil {
+		s.log.Errorf("[ERROR]WHEN DELETING SCHOOL MEMBER, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// keepOwner locks the owners of the school until the transaction ends, so two admins demoting
+// the last two owners at once can't leave the school without one.
+func keepOwner(db *gorm.DB, member entity.SchoolMember) error {
+	owners := []entity.SchoolMember{}
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("school_id=? AND role=?", member.SchoolID, "owner").Find(&owners).Error; err != nil {
+		return err
+	}
+	for _, val := range owners {
+		if val.ID == member.ID && len(owners) <= 1 {
+			return errLastOwner
+		}
+	}
+	return nil
+}
+func (s *school) CountOwners(db *gorm.DB, schid int) (int, error) {
+	var count int64
+	if err := db.Model(&entity.SchoolMember{}).Where("school_id=? AND role=?", schid, "owner").Count(&count).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN COUNTING SCHOOL OWNERS, Err : %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	return int(count), nil
+}
+func (s *school) CreateInvitation(db *gorm.DB, invitation entity.SchoolInvitation) (*entity.SchoolInvitation, error) {
+	if err := db.Create(&invitation).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN CREATING SCHOOL INVITATION, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &invitation, nil
+}
+func (s *school) GetInvitations(db *gorm.DB, schid int) ([]entity.SchoolInvitation, error) {
+	res := []entity.SchoolInvitation{}
+	if err := db.Where("school_id=? AND expires_at > ?", schid, time.Now()).Order("id").Find(&res).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN GETTING SCHOOL INVITATIONS, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+func (s *school) GetInvitationById(db *gorm.DB, id int) (*entity.SchoolInvitation, error) {
+	res := entity.SchoolInvitation{}
+	if err := db.First(&res, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING SCHOOL INVITATION, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+func (s *school) GetInvitationByToken(db *gorm.DB, tokenhash string) (*entity.SchoolInvitation, error) {
+	res := entity.SchoolInvitation{}
+	if err := db.Preload("School").Where("token_hash=?", tokenhash).First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Invitation not found")
+		}
+		s.log.Errorf("[ERROR]WHEN GETTING SCHOOL INVITATION, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+
+// AcceptInvitation adds the member and uses up the invitation together.
+func (s *school) AcceptInvitation(db *gorm.DB, invitation entity.SchoolInvitation, member entity.SchoolMember) error {
+	err := db.Transaction(func(db *gorm.DB) error {
+		if err := db.Create(&member).Error; err != nil {
+			return err
+		}
+		return db.Delete(&entity.SchoolInvitation{}, invitation.ID).Error
+	})
+	if err != nil {
+		s.log.Errorf("[ERROR]WHEN ACCEPTING SCHOOL INVITATION, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+func (s *school) DeleteInvitation(db *gorm.DB, id int) error {
+	if err := db.Delete(&entity.SchoolInvitation{}, id).Error; err != nil {
+		s.log.Errorf("[ERROR]WHEN DELETING SCHOOL INVITATION, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
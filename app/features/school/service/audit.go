@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/education-hub/BE/app/audit"
+	"github.com/education-hub/BE/app/authz"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+)
+
+const (
+	defaultAuditLimit = 20
+	maxAuditLimit     = 100
+)
+
+// GetAuditLog returns the newest audit entries first, the super admin reads every entry and the owner
+// of a school the ones made at the school. From and To are dates, To included.
+func (s *school) GetAuditLog(ctx context.Context, uid int, role string, req entity.ReqAuditQuery) (*entity.Response, error) {
+	filter := audit.Filter{ActorID: req.ActorID, Action: req.Action, ResourceType: req.ResourceType, ResourceID: req.ResourceID}
+	if role != "su" {
+		schooldata, err := s.authz.Can(ctx, uid, authz.ViewAudit, authz.MySchool)
+		if err != nil {
+			return nil, err
+		}
+		filter.School = int(schooldata.ID)
+	}
+	var err error
+	if req.From != "" {
+		if filter.From, err = time.ParseInLocation("2006-01-02", req.From, time.Local); err != nil {
+			s.dep.PromErr["error"] = err.Error()
+			return nil, errorr.NewBad("Invalid Date, Use YYYY-MM-DD")
+		}
+	}
+	if req.To != "" {
+		if filter.To, err = time.ParseInLocation("2006-01-02", req.To, time.Local); err != nil {
+			s.dep.PromErr["error"] = err.Error()
+			return nil, errorr.NewBad("Invalid Date, Use YYYY-MM-DD")
+		}
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > maxAuditLimit {
+		req.Limit = defaultAuditLimit
+	}
+	filter.Limit, filter.Offset = req.Limit, (req.Page-1)*req.Limit
+	entries, total, err := s.auditlog.Query(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Response{
+		Limit:     req.Limit,
+		Page:      req.Page,
+		TotalPage: int(math.Ceil(float64(total) / float64(req.Limit))),
+		TotalData: total,
+		Data:      entries,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+
+	"github.com/education-hub/BE/app/authz"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/pkg"
+)
+
+// GetSubmissionPdf renders the registration form of a submission for the student who sent it or the admin of the school.
+func (s *school) GetSubmissionPdf(ctx context.Context, id int, uid int, role string) ([]byte, error) {
+	subm, err := s.repo.GetSubmissionByid(s.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	switch role {
+	case "student", "parent":
+		if err := authz.Owner(uid, subm.UserID); err != nil {
+			s.dep.PromErr["error"] = "Submission does not belong to the student"
+			return nil, err
+		}
+	case "administrator":
+		if _, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.On(authz.School, int(subm.SchoolID))); err != nil {
+			return nil, err
+		}
+	}
+	res, err := admissionForm(subm, s.formImage(subm.StudentPhoto), s.formImage(subm.ParentSignature), s.formImage(subm.StudentSignature))
+	if err != nil {
+		s.dep.Log.Errorf("[ERROR]WHEN RENDERING SUBMISSION PDF, Err : %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+
+// formImage loads a submission file, a missing or broken one leaves an empty box on the form instead of failing it.
+func (s *school) formImage(name string) image.Image {
+	if name == "" {
+		return nil
+	}
+	file, err := s.dep.Storage.ReadFile(name)
+	if err != nil {
+		s.dep.Log.Errorf("[ERROR]WHEN READING SUBMISSION FILE %s, Err : %v", name, err)
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(file))
+	if err != nil {
+		s.dep.Log.Errorf("[ERROR]WHEN DECODING SUBMISSION FILE %s, Err : %v", name, err)
+		return nil
+	}
+	return img
+}
+
+func admissionForm(subm *entity.Submission, photo, parentsign, studentsign image.Image) ([]byte, error) {
+	studentaddress := entity.ReqAdressSubmission{}
+	parentaddress := entity.ReqAdressSubmission{}
+	json.Unmarshal([]byte(subm.StudentAddress), &studentaddress)
+	json.Unmarshal([]byte(subm.ParentAddress), &parentaddress)
+
+	doc := pkg.NewPDF()
+	doc.Text(50, 40, 14, true, "FORMULIR PENDAFTARAN PESERTA DIDIK BARU")
+	doc.Text(50, 60, 12, true, subm.School.Name)
+	doc.Text(50, 78, 9, false, fmt.Sprintf("No. Pendaftaran: %d", subm.ID))
+	doc.Line(50, 95, pkg.PageWidth-50, 95)
+
+	// 3x4 photo on the right of the student data
+	if err := formBox(doc, photo, 455, 110, 90, 120); err != nil {
+		return nil, err
+	}
+	y := formSection(doc, 110, "DATA CALON SISWA", [][2]string{
+		{"Nama Lengkap", subm.StudentName},
+		{"Tempat, Tanggal Lahir", subm.PlaceDate},
+		{"Jenis Kelamin", subm.Gender},
+		{"Agama", subm.Religion},
+		{"NISN", subm.NISN},
+		{"Asal Sekolah", subm.GraduationFrom},
+		{"Jalur", subm.Track},
+		{"Alamat", pkg.Address(studentaddress.Detail, studentaddress.Village, studentaddress.District, studentaddress.City, studentaddress.Province, studentaddress.ZipCode)},
+	})
+	y = formSection(doc, y+15, "DATA ORANG TUA / WALI", [][2]string{
+		{"Nama Lengkap", subm.ParentName},
+		{"Jenis Kelamin", subm.ParentGender},
+		{"Pekerjaan", subm.ParentJob},
+		{"Agama", subm.ParentReligion},
+		{"Telepon", subm.ParentPhone},
+		{"Alamat", pkg.Address(parentaddress.Detail, parentaddress.Village, parentaddress.District, parentaddress.City, parentaddress.Province, parentaddress.ZipCode)},
+	})
+
+	y += 20
+	doc.Text(370, y, 10, false, fmt.Sprintf("%s, %s", studentaddress.City, subm.Date))
+	y += 20
+	doc.Text(50, y, 10, false, "Orang Tua / Wali")
+	doc.Text(370, y, 10, false, "Calon Siswa")
+	y += 18
+	if err := formBox(doc, parentsign, 50, y, 150, 70); err != nil {
+		return nil, err
+	}
+	if err := formBox(doc, studentsign, 370, y, 150, 70); err != nil {
+		return nil, err
+	}
+	y += 78
+	doc.Text(50, y, 10, true, subm.ParentName)
+	doc.Text(370, y, 10, true, subm.StudentName)
+	return doc.Bytes(), nil
+}
+
+// formSection writes the labelled values under a heading and returns the y below them.
+func formSection(doc *pkg.PDF, y float64, title string, rows [][2]string) float64 {
+	doc.Text(50, y, 11, true, title)
+	y += 20
+	for _, row := range rows {
+		doc.Text(50, y, 10, false, row[0])
+		doc.Text(170, y, 10, false, ":")
+		y = doc.TextBox(180, y, 265, 10, row[1]) + 2
+	}
+	return y
+}
+
+// formBox draws the image inside a frame, the frame stays empty without an image.
+func formBox(doc *pkg.PDF, img image.Image, x, y, w, h float64) error {
+	doc.Rect(x, y, w, h)
+	if img == nil {
+		return nil
+	}
+	return doc.Image(img, x+2, y+2, w-4, h-4)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/education-hub/BE/app/admission"
+	"github.com/education-hub/BE/app/audit"
+	"github.com/education-hub/BE/app/authz"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+)
+
+func (s *school) GetLetterTemplate(ctx context.Context, uid int) (*entity.ResLetterTemplate, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := s.repo.GetLetterTemplate(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if _, missing := err.(errorr.BadRequest); missing {
+		tmpl, err = &admission.DefaultLetter, nil
+	}
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return &entity.ResLetterTemplate{Title: tmpl.Title, Body: tmpl.Body, SignerName: tmpl.SignerName, SignerTitle: tmpl.SignerTitle}, nil
+}
+
+func (s *school) UpdateLetterTemplate(ctx context.Context, uid int, req entity.ReqLetterTemplate) (*entity.ResLetterTemplate, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE UPDATE LETTER TEMPLATE REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	// a school still using the default letter has no template yet, the change creates it
+	before, err := s.repo.GetLetterTemplate(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if _, missing := err.(errorr.BadRequest); missing {
+		err = nil
+	}
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	tmpl := entity.LetterTemplate{SchoolID: schooldata.ID, Title: req.Title, Body: req.Body, SignerName: req.SignerName, SignerTitle: req.SignerTitle}
+	if err := s.repo.UpdateLetterTemplate(s.dep.Db.WithContext(ctx), tmpl); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	audit.Track(ctx, audit.Change{Type: "letter_template", ID: schooldata.ID, Before: before, After: tmpl})
+	return &entity.ResLetterTemplate{Title: tmpl.Title, Body: tmpl.Body, SignerName: tmpl.SignerName, SignerTitle: tmpl.SignerTitle}, nil
+}
+
+// GetAcceptanceLetter returns the letter of a finished progress of the student, it is issued now if it could not be when the progress finished.
+func (s *school) GetAcceptanceLetter(ctx context.Context, id int, uid int) ([]byte, error) {
+	prog, err := s.checkProgressOwner(ctx, id, uid, "student", authz.ViewAdmission)
+	if err != nil {
+		return nil, err
+	}
+	letter, err := s.workflow.IssueLetter(ctx, prog)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res, err := s.dep.Storage.ReadFile(letter.File)
+	if err != nil {
+		s.dep.Log.Errorf("[ERROR]WHEN READING ACCEPTANCE LETTER, Err : %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+
+// VerifyLetter is public, the NISN is masked so a reference does not disclose it whole.
+func (s *school) VerifyLetter(ctx context.Context, reference string) (*entity.ResLetterVerification, error) {
+	letter, err := s.repo.GetLetterByReference(s.dep.Db.WithContext(ctx), strings.ToUpper(strings.TrimSpace(reference)))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	nisn := letter.NISN
+	if len(nisn) > 4 {
+		nisn = strings.Repeat("*", len(nisn)-4) + nisn[len(nisn)-4:]
+	}
+	return &entity.ResLetterVerification{
+		Reference:   letter.Reference,
+		StudentName: letter.StudentName,
+		NISN:        nisn,
+		SchoolName:  letter.SchoolName,
+		Npsn:        letter.Npsn,
+		IssuedAt:    letter.IssuedAt,
+	}, nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/education-hub/BE/app/audit"
+	"github.com/education-hub/BE/app/authz"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/helper"
+)
+
+// invitationDuration is how long an invitation to join a school may be accepted.
+const invitationDuration = 7 * 24 * time.Hour
+
+// Authorize returns the school of the user once its role grants the action.
+func (s *school) Authorize(ctx context.Context, uid int, action authz.Action) (int, error) {
+	schooldata, err := s.authz.Can(ctx, uid, action, authz.MySchool)
+	if err != nil {
+		return 0, err
+	}
+	return int(schooldata.ID), nil
+}
+
+func (s *school) GetMembers(ctx context.Context, uid int) ([]entity.ResMember, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewSchool, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.GetMembers(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res := []entity.ResMember{}
+	for _, val := range members {
+		res = append(res, resMember(val))
+	}
+	return res, nil
+}
+
+func (s *school) UpdateMember(ctx context.Context, id int, uid int, req entity.ReqUpdateMember) (*entity.ResMember, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE UPDATE MEMBER REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	member, err := s.checkMember(ctx, id, uid)
+	if err != nil {
+		return nil, err
+	}
+	before := *member
+	member.Role = req.Role
+	if err := s.repo.UpdateMember(s.dep.Db.WithContext(ctx), *member); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	audit.Track(ctx, audit.Change{Type: "member", ID: id, Before: before, After: *member})
+	res := resMember(*member)
+	return &res, nil
+}
+
+// DeleteMember removes a member from the school, members may always leave on their own.
+func (s *school) DeleteMember(ctx context.Context, id int, uid int) error {
+	member, err := s.repo.GetMemberById(s.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if int(member.UserID) != uid {
+		if member, err = s.checkMember(ctx, id, uid); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.DeleteMember(s.dep.Db.WithContext(ctx), id); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	audit.Track(ctx, audit.Change{Type: "member", ID: id, Before: member})
+	return nil
+}
+
+// checkMember makes sure the member works at the school the user manages.
+func (s *school) checkMember(ctx context.Context, id int, uid int) (*entity.SchoolMember, error) {
+	member, err := s.repo.GetMemberById(s.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageSchool, authz.On(authz.School, int(member.SchoolID))); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// InviteMember emails an invitation to join the school, the token is only known by the invitee.
+func (s *school) InviteMember(ctx context.Context, uid int, req entity.ReqInviteMember) (*entity.ResInvitation, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE INVITE MEMBER REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageSchool, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	token, tokenhash, err := helper.NewToken()
+	if err != nil {
+		s.dep.Log.Errorf("[ERROR]WHEN GENERATING INVITATION TOKEN, Err : %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	invitation, err := s.repo.CreateInvitation(s.dep.Db.WithContext(ctx), entity.SchoolInvitation{
+		SchoolID:  schooldata.ID,
+		Email:     strings.ToLower(strings.TrimSpace(req.Email)),
+		Role:      req.Role,
+		TokenHash: tokenhash,
+		InvitedBy: uint(uid),
+		ExpiresAt: time.Now().Add(invitationDuration),
+	})
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	audit.Track(ctx, audit.Change{Type: "invitation", ID: invitation.ID, After: invitation})
+	encodeddata, _ := json.Marshal(map[string]any{"email": invitation.Email, "school": schooldata.Name, "role": invitation.Role, "token": token, "expire": invitation.ExpiresAt})
+	go func() {
+		if err := s.dep.Nsq.Publish("16", encodeddata); err != nil {
+			s.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
+		}
+	}()
+	return &entity.ResInvitation{ID: int(invitation.ID), Email: invitation.Email, Role: invitation.Role, ExpiresAt: invitation.ExpiresAt}, nil
+}
+
+func (s *school) GetInvitations(ctx context.Context, uid int) ([]entity.ResInvitation, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageSchool, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := s.repo.GetInvitations(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res := []entity.ResInvitation{}
+	for _, val := range invitations {
+		res = append(res, entity.ResInvitation{ID: int(val.ID), Email: val.Email, Role: val.Role, ExpiresAt: val.ExpiresAt})
+	}
+	return res, nil
+}
+
+func (s *school) DeleteInvitation(ctx context.Context, id int, uid int) error {
+	invitation, err := s.repo.GetInvitationById(s.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageSchool, authz.On(authz.School, int(invitation.SchoolID))); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteInvitation(s.dep.Db.WithContext(ctx), id); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	audit.Track(ctx, audit.Change{Type: "invitation", ID: id, Before: invitation})
+	return nil
+}
+
+// AcceptInvitation makes the admin a member of the school, the invitation must have been sent to its email.
+func (s *school) AcceptInvitation(ctx context.Context, uid int, token string) (*entity.ResMember, error) {
+	invitation, err := s.repo.GetInvitationByToken(s.dep.Db.WithContext(ctx), helper.HashToken(token))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if time.Now().After(invitation.ExpiresAt) {
+		s.dep.PromErr["error"] = "Invitation expired"
+		return nil, errorr.NewBad("Invitation has expired")
+	}
+	user, err := s.userrepo.GetById(s.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		s.dep.PromErr["error"] = "Invitation sent to another email"
+		return nil, errorr.NewBad("Invitation not found")
+	}
+	if _, err := s.repo.GetMember(s.dep.Db.WithContext(ctx), uid); err == nil {
+		s.dep.PromErr["error"] = "User is already a member of a school"
+		return nil, errorr.NewBad("You are already a member of a school")
+	}
+	member := entity.SchoolMember{SchoolID: invitation.SchoolID, UserID: user.ID, Role: invitation.Role, CreatedAt: time.Now(), User: *user}
+	if err := s.repo.AcceptInvitation(s.dep.Db.WithContext(ctx), *invitation, member); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res := resMember(member)
+	return &res, nil
+}
+
+func resMember(member entity.SchoolMember) entity.ResMember {
+	return entity.ResMember{
+		ID:        int(member.ID),
+		UserID:    int(member.UserID),
+		Username:  member.User.Username,
+		Name:      member.User.FirstName + " " + member.User.SureName,
+		Email:     member.User.Email,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+}
@@ -1,15 +1,22 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"math"
 	"mime/multipart"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/education-hub/BE/app/admission"
+	"github.com/education-hub/BE/app/audit"
+	"github.com/education-hub/BE/app/authz"
 	entity "github.com/education-hub/BE/app/entities"
 	"github.com/education-hub/BE/app/features/school/repository"
 	user "github.com/education-hub/BE/app/features/user/repository"
@@ -26,42 +33,87 @@ type (
 		validator *validator.Validate
 		dep       dependency.Depend
 		userrepo  user.UserRepo
+		workflow  admission.Workflow
+		authz     authz.Authorizer
+		auditlog  audit.Log
 	}
 	SchoolService interface {
 		Create(ctx context.Context, req entity.ReqCreateSchool, image multipart.File, pdf multipart.File) (int, error)
-		Update(ctx context.Context, req entity.ReqUpdateSchool, image multipart.File, pdf multipart.File) (*entity.ResUpdateSchool, error)
+		Update(ctx context.Context, uid int, req entity.ReqUpdateSchool, image multipart.File, pdf multipart.File) (*entity.ResUpdateSchool, error)
+		SetGmeet(ctx context.Context, id int, link string, date string) error
 		Delete(ctx context.Context, id int, uid int) error
 		Search(searchval string) any
 		GetAll(ctx context.Context, page, limit int, search string) (*entity.Response, error)
-		AddAchievement(ctx context.Context, req entity.ReqAddAchievemnt, image multipart.File) (int, error)
-		DeleteAchievement(ctx context.Context, id int) error
-		UpdateAchievement(ctx context.Context, req entity.ReqUpdateAchievemnt, image multipart.File) (int, error)
+		AddAchievement(ctx context.Context, uid int, req entity.ReqAddAchievemnt, image multipart.File) (int, error)
+		DeleteAchievement(ctx context.Context, id int, uid int) error
+		UpdateAchievement(ctx context.Context, uid int, req entity.ReqUpdateAchievemnt, image multipart.File) (int, error)
 		GetByUid(ctx context.Context, uid int) (*entity.ResDetailSchool, error)
 		GetByid(ctx context.Context, id int) (*entity.ResDetailSchool, error)
-		AddExtracurricular(ctx context.Context, req entity.ReqAddExtracurricular, image multipart.File) (int, error)
-		DeleteExtracurricular(ctx context.Context, id int) error
-		UpdateExtracurricular(ctx context.Context, req entity.ReqUpdateExtracurricular, image multipart.File) (int, error)
-		AddFaq(ctx context.Context, req entity.ReqAddFaq) (int, error)
-		DeleteFaq(ctx context.Context, id int) error
-		UpdateFaq(ctx context.Context, req entity.ReqUpdateFaq) (int, error)
-		AddPayment(ctx context.Context, req entity.ReqAddPayment, image multipart.File) (int, error)
-		DeletePayment(ctx context.Context, id int) error
-		UpdatePayment(ctx context.Context, req entity.ReqUpdatePayment, image multipart.File) (int, error)
+		AddExtracurricular(ctx context.Context, uid int, req entity.ReqAddExtracurricular, image multipart.File) (int, error)
+		DeleteExtracurricular(ctx context.Context, id int, uid int) error
+		UpdateExtracurricular(ctx context.Context, uid int, req entity.ReqUpdateExtracurricular, image multipart.File) (int, error)
+		AddFaq(ctx context.Context, uid int, req entity.ReqAddFaq) (int, error)
+		DeleteFaq(ctx context.Context, id int, uid int) error
+		UpdateFaq(ctx context.Context, uid int, req entity.ReqUpdateFaq) (int, error)
+		AddPayment(ctx context.Context, uid int, req entity.ReqAddPayment, image multipart.File) (int, error)
+		DeletePayment(ctx context.Context, id int, uid int) error
+		UpdatePayment(ctx context.Context, uid int, req entity.ReqUpdatePayment, image multipart.File) (int, error)
 		CreateSubmission(ctx context.Context, req entity.ReqCreateSubmission, studentph, signstudent, signparent multipart.File) (int, error)
-		UpdateProgressByid(ctx context.Context, id int, status string) (int, error)
+		CreateSubmissionFromProfile(ctx context.Context, uid int, req entity.ReqProfileSubmission) (int, error)
+		UpdateProgressByid(ctx context.Context, id int, uid int, req entity.ReqUpdateProgress) (int, error)
+		BulkUpdateProgress(ctx context.Context, uid int, req entity.ReqBulkProgress) ([]entity.ResBulkProgress, error)
+		AddNote(ctx context.Context, id int, uid int, req entity.ReqAddNote) (int, error)
+		RespondOffer(ctx context.Context, id int, uid int, req entity.ReqRespondOffer) (int, error)
+		Withdraw(ctx context.Context, id int, uid int, req entity.ReqWithdraw) (int, error)
+		ReviseSubmission(ctx context.Context, id int, uid int, req entity.ReqReviseSubmission, files map[string]multipart.File) (int, error)
+		GetProgressTimeline(ctx context.Context, id int, uid int, role string) ([]entity.ResProgressEvent, error)
 		GetAllProgressByUid(ctx context.Context, uid int) ([]entity.ResAllProgress, error)
-		GetProgressById(ctx context.Context, id int) (*entity.ResDetailProgress, error)
-		GetAllProgressAndSubmissionByuid(ctx context.Context, uid int) ([]entity.ResAllProgressSubmission, error)
-		GetSubmissionByid(ctx context.Context, id int) (*entity.ResDetailSubmission, error)
+		GetProgressById(ctx context.Context, id int, uid int, role string) (*entity.ResDetailProgress, error)
+		GetAllProgressAndSubmission(ctx context.Context, uid int) ([]entity.ResAllProgressSubmission, error)
+		GetSubmissionByid(ctx context.Context, id int, uid int) (*entity.ResDetailSubmission, error)
+		GetFile(ctx context.Context, uid int, fname string) (string, error)
+		GetSubmissionPdf(ctx context.Context, id int, uid int, role string) ([]byte, error)
 		AddReview(ctx context.Context, req entity.Reviews) (int, error)
-		DeleteProgressByid(ctx context.Context, id int) error
-		CreateQuiz(ctx context.Context, req []entity.ReqAddQuiz) error
+		DeleteProgressByid(ctx context.Context, id int, uid int) error
+		CreateQuiz(ctx context.Context, uid int, req []entity.ReqAddQuiz) error
 		GetTestResult(ctx context.Context, uid int) ([]pkg.TestResult, error)
+		GetPipeline(ctx context.Context, uid int) (*entity.ResPipeline, error)
+		UpdatePipeline(ctx context.Context, uid int, req entity.ReqUpdatePipeline) (*entity.ResPipeline, error)
+		GetQuotas(ctx context.Context, uid int) ([]entity.ResQuota, error)
+		UpdateQuotas(ctx context.Context, uid int, req entity.ReqUpdateQuota) ([]entity.ResQuota, error)
+		GetWaitlist(ctx context.Context, uid int) ([]entity.ResWaitlist, error)
+		GetPeriods(ctx context.Context, uid int) ([]entity.ResAdmissionPeriod, error)
+		CreatePeriod(ctx context.Context, uid int, req entity.ReqAdmissionPeriod) (int, error)
+		UpdatePeriod(ctx context.Context, id int, uid int, req entity.ReqAdmissionPeriod) error
+		DeletePeriod(ctx context.Context, id int, uid int) error
+		GetZonasiRanking(ctx context.Context, uid int, track string) ([]entity.ResZonasiRank, error)
+		GetSelectionCriteria(ctx context.Context, uid int) ([]entity.ResSelectionCriterion, error)
+		UpdateSelectionCriteria(ctx context.Context, uid int, req entity.ReqUpdateSelection) ([]entity.ResSelectionCriterion, error)
+		GetSelection(ctx context.Context, uid int) ([]entity.ResSelectionRank, error)
+		ExportSelection(ctx context.Context, uid int) ([]byte, error)
+		AdvanceSelection(ctx context.Context, uid int, req entity.ReqAdvanceSelection) ([]entity.ResBulkProgress, error)
+		CreateAppeal(ctx context.Context, uid int, req entity.ReqCreateAppeal, files []multipart.File) (int, error)
+		GetAppealsByUid(ctx context.Context, uid int) ([]entity.ResAppeal, error)
+		GetAppeals(ctx context.Context, uid int, status string) ([]entity.ResAppeal, error)
+		DecideAppeal(ctx context.Context, id int, uid int, req entity.ReqDecideAppeal) (*entity.ResAppeal, error)
+		GetLetterTemplate(ctx context.Context, uid int) (*entity.ResLetterTemplate, error)
+		UpdateLetterTemplate(ctx context.Context, uid int, req entity.ReqLetterTemplate) (*entity.ResLetterTemplate, error)
+		GetAcceptanceLetter(ctx context.Context, id int, uid int) ([]byte, error)
+		VerifyLetter(ctx context.Context, reference string) (*entity.ResLetterVerification, error)
+		Authorize(ctx context.Context, uid int, action authz.Action) (int, error)
+		GetMembers(ctx context.Context, uid int) ([]entity.ResMember, error)
+		UpdateMember(ctx context.Context, id int, uid int, req entity.ReqUpdateMember) (*entity.ResMember, error)
+		DeleteMember(ctx context.Context, id int, uid int) error
+		InviteMember(ctx context.Context, uid int, req entity.ReqInviteMember) (*entity.ResInvitation, error)
+		GetInvitations(ctx context.Context, uid int) ([]entity.ResInvitation, error)
+		DeleteInvitation(ctx context.Context, id int, uid int) error
+		AcceptInvitation(ctx context.Context, uid int, token string) (*entity.ResMember, error)
+		GetAuditLog(ctx context.Context, uid int, role string, req entity.ReqAuditQuery) (*entity.Response, error)
 	}
 )
 
-func NewSchoolService(repo repository.SchoolRepo, dep dependency.Depend, user user.UserRepo) SchoolService {
-	return &school{repo: repo, dep: dep, validator: validator.New(), userrepo: user}
+func NewSchoolService(repo repository.SchoolRepo, dep dependency.Depend, user user.UserRepo, workflow admission.Workflow, authorizer authz.Authorizer, auditlog audit.Log) SchoolService {
+	return &school{repo: repo, dep: dep, validator: validator.New(), userrepo: user, workflow: workflow, authz: authorizer, auditlog: auditlog}
 }
 
 func (s *school) Create(ctx context.Context, req entity.ReqCreateSchool, image multipart.File, pdf multipart.File) (int, error) {
@@ -102,6 +154,9 @@ func (s *school) Create(ctx context.Context, req entity.ReqCreateSchool, image m
 		Phone:         req.Phone,
 		Accreditation: req.Accreditation,
 	}
+	if point := s.locate(ctx, pkg.Address(req.Detail, req.Village, req.District, req.City, req.Province, req.ZipCode)); point != nil {
+		data.Latitude, data.Longitude = &point.Lat, &point.Lng
+	}
 	if image != nil && pdf != nil {
 		img := fmt.Sprintf("%s_%s_%s", "School_", req.Npsn, req.Image)
 		pdff := fmt.Sprintf("%s_%s_%s", "School_", req.Npsn, req.Pdf)
@@ -112,7 +167,7 @@ func (s *school) Create(ctx context.Context, req entity.ReqCreateSchool, image m
 		wg.Add(2)
 		go func() {
 			defer wg.Done()
-			if err1 := s.dep.Gcp.UploadFile(image, img); err1 != nil {
+			if err1 := s.dep.Storage.UploadFile(image, img); err1 != nil {
 				s.dep.Log.Errorf("Error Service : %v", err1)
 				errchan <- err1
 				image.Close()
@@ -125,7 +180,7 @@ func (s *school) Create(ctx context.Context, req entity.ReqCreateSchool, image m
 		}()
 		go func() {
 			defer wg.Done()
-			if err1 := s.dep.Gcp.UploadFile(pdf, pdff); err1 != nil {
+			if err1 := s.dep.Storage.UploadFile(pdf, pdff); err1 != nil {
 				s.dep.Log.Errorf("Error Service : %v", err1)
 				errchan <- err1
 				pdf.Close()
@@ -153,18 +208,27 @@ func (s *school) Create(ctx context.Context, req entity.ReqCreateSchool, image m
 	return id, nil
 }
 func (s *school) Delete(ctx context.Context, id int, uid int) error {
-	if err := s.repo.Delete(s.dep.Db.WithContext(ctx), id, uid); err != nil {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageSchool, authz.On(authz.School, id))
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(s.dep.Db.WithContext(ctx), id); err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return err
 	}
+	audit.Track(ctx, audit.Change{Type: "school", ID: id, Before: schooldata})
 	return nil
 }
-func (s *school) Update(ctx context.Context, req entity.ReqUpdateSchool, image multipart.File, pdf multipart.File) (*entity.ResUpdateSchool, error) {
+func (s *school) Update(ctx context.Context, uid int, req entity.ReqUpdateSchool, image multipart.File, pdf multipart.File) (*entity.ResUpdateSchool, error) {
 	if err := s.validator.Struct(req); err != nil {
 		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE REQUPDATE")
 		s.dep.PromErr["error"] = err.Error()
 		return nil, errorr.NewBad("Missing Or Invalid Request Body")
 	}
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageSchool, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
 	if req.Phone != "" {
 		if !helper.IsValidPhone(req.Phone) {
 			return nil, errorr.NewBad("Invalid Phone Number")
@@ -205,10 +269,10 @@ func (s *school) Update(ctx context.Context, req entity.ReqUpdateSchool, image m
 		QuizLinkPub:     req.QuizLinkPub,
 		QuizLinkPreview: req.QuizLinkPreview,
 	}
-	data.ID = uint(req.Id)
+	data.ID = schooldata.ID
 	if image != nil {
 		filename := fmt.Sprintf("%s_%s_%s", "School_", req.Npsn, req.Image)
-		if err := s.dep.Gcp.UploadFile(image, filename); err != nil {
+		if err := s.dep.Storage.UploadFile(image, filename); err != nil {
 			s.dep.Log.Errorf("Error Service : %v", err)
 			s.dep.PromErr["error"] = err.Error()
 			image.Close()
@@ -219,7 +283,7 @@ func (s *school) Update(ctx context.Context, req entity.ReqUpdateSchool, image m
 	}
 	if pdf != nil {
 		filename := fmt.Sprintf("%s_%s_%s", "School_", req.Npsn, req.Pdf)
-		if err := s.dep.Gcp.UploadFile(pdf, filename); err != nil {
+		if err := s.dep.Storage.UploadFile(pdf, filename); err != nil {
 			s.dep.Log.Errorf("Error Service : %v", err)
 			s.dep.PromErr["error"] = err.Error()
 			pdf.Close()
@@ -233,6 +297,15 @@ func (s *school) Update(ctx context.Context, req entity.ReqUpdateSchool, image m
 		s.dep.PromErr["error"] = err.Error()
 		return nil, err
 	}
+	if req.Province != "" || req.City != "" || req.District != "" || req.Village != "" || req.Detail != "" || req.ZipCode != "" {
+		if point := s.locate(ctx, pkg.Address(resdata.Detail, resdata.Village, resdata.District, resdata.City, resdata.Province, resdata.ZipCode)); point != nil {
+			if err := s.repo.UpdateLocation(s.dep.Db.WithContext(ctx), int(resdata.ID), point.Lat, point.Lng); err != nil {
+				s.dep.PromErr["error"] = err.Error()
+				return nil, err
+			}
+			resdata.Latitude, resdata.Longitude = &point.Lat, &point.Lng
+		}
+	}
 	res := entity.ResUpdateSchool{
 		Id:            int(resdata.ID),
 		Npsn:          resdata.Npsn,
@@ -247,28 +320,48 @@ func (s *school) Update(ctx context.Context, req entity.ReqUpdateSchool, image m
 		Staff:         resdata.Staff,
 		Accreditation: resdata.Accreditation,
 		Location: entity.Location{
-			Province: resdata.Province,
-			City:     resdata.City,
-			District: resdata.District,
-			Village:  resdata.Village,
-			Detail:   resdata.Detail,
-			ZipCode:  resdata.ZipCode,
+			Province:  resdata.Province,
+			City:      resdata.City,
+			District:  resdata.District,
+			Village:   resdata.Village,
+			Detail:    resdata.Detail,
+			ZipCode:   resdata.ZipCode,
+			Latitude:  resdata.Latitude,
+			Longitude: resdata.Longitude,
 		},
 	}
+	audit.Track(ctx, audit.Change{Type: "school", ID: resdata.ID, Before: schooldata, After: resdata})
 	return &res, nil
 }
+
+// SetGmeet stores the meeting created from the calendar callback, the admin was authorized when the meeting was requested.
+func (s *school) SetGmeet(ctx context.Context, id int, link string, date string) error {
+	data := entity.School{Gmeet: link, GmeetDate: date}
+	data.ID = uint(id)
+	if _, err := s.repo.Update(s.dep.Db.WithContext(ctx), data); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	audit.Track(ctx, audit.Change{Type: "school", ID: id, After: map[string]any{"Gmeet": link, "GmeetDate": date}})
+	return nil
+}
+
 func (s *school) Search(searchval string) any {
 	return pkg.NewClientGmaps(s.dep.Config.GmapsKey, s.dep.Log).Search(searchval)
 }
 
-func (s *school) AddAchievement(ctx context.Context, req entity.ReqAddAchievemnt, image multipart.File) (int, error) {
+func (s *school) AddAchievement(ctx context.Context, uid int, req entity.ReqAddAchievemnt, image multipart.File) (int, error) {
 	if err := s.validator.Struct(req); err != nil {
 		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Achievement REQ, Error: %v", err)
 		s.dep.PromErr["error"] = err.Error()
 		return 0, errorr.NewBad("Missing or Invalid Request Body")
 	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageContent, authz.On(authz.School, int(req.SchoolID))); err != nil {
+		image.Close()
+		return 0, err
+	}
 	filename := fmt.Sprintf("%s_%d_%s", "Achv_", req.SchoolID, req.Image)
-	if err := s.dep.Gcp.UploadFile(image, filename); err != nil {
+	if err := s.dep.Storage.UploadFile(image, filename); err != nil {
 		s.dep.Log.Errorf("Error Service : %v", err)
 		s.dep.PromErr["error"] = err.Error()
 		image.Close()
@@ -286,23 +379,36 @@ func (s *school) AddAchievement(ctx context.Context, req entity.ReqAddAchievemnt
 		s.dep.PromErr["error"] = err.Error()
 		return 0, err
 	}
-	return res, err
+	audit.Track(ctx, audit.Change{Type: "achievement", ID: res.ID, After: res})
+	return int(res.SchoolID), nil
 }
 
-func (s *school) DeleteAchievement(ctx context.Context, id int) error {
+func (s *school) DeleteAchievement(ctx context.Context, id int, uid int) error {
+	if _, err := s.authz.Can(ctx, uid, authz.ManageContent, authz.On(authz.Achievement, id)); err != nil {
+		return err
+	}
+	before := entity.Achievement{}
+	if err := s.repo.GetItemById(s.dep.Db.WithContext(ctx), &before, id); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
 	if err := s.repo.DeleteAchievement(s.dep.Db.WithContext(ctx), id); err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return err
 	}
+	audit.Track(ctx, audit.Change{Type: "achievement", ID: id, Before: before})
 	return nil
 }
 
-func (s *school) UpdateAchievement(ctx context.Context, req entity.ReqUpdateAchievemnt, image multipart.File) (int, error) {
+func (s *school) UpdateAchievement(ctx context.Context, uid int, req entity.ReqUpdateAchievemnt, image multipart.File) (int, error) {
 	if err := s.validator.Struct(req); err != nil {
 		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Achievement REQ, Error: %v", err)
 		s.dep.PromErr["error"] = err.Error()
 		return 0, errorr.NewBad("Missing or Invalid Request Body")
 	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageContent, authz.On(authz.Achievement, req.Id)); err != nil {
+		return 0, err
+	}
 	filename := fmt.Sprintf("%s_%d_%s", "Achv_", req.Id, req.Image)
 	if image != nil {
 		req.Image = filename
@@ -313,13 +419,19 @@ func (s *school) UpdateAchievement(ctx context.Context, req entity.ReqUpdateAchi
 		Title:       req.Name,
 	}
 	data.ID = uint(req.Id)
+	before := entity.Achievement{}
+	if err := s.repo.GetItemById(s.dep.Db.WithContext(ctx), &before, req.Id); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
 	res, err := s.repo.UpdateAchievement(s.dep.Db.WithContext(ctx), data)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return 0, err
 	}
+	audit.Track(ctx, audit.Change{Type: "achievement", ID: req.Id, Before: before, After: res})
 	if image != nil {
-		if err := s.dep.Gcp.UploadFile(image, filename); err != nil {
+		if err := s.dep.Storage.UploadFile(image, filename); err != nil {
 			s.dep.Log.Errorf("Error Service : %v", err)
 			s.dep.PromErr["error"] = err.Error()
 			image.Close()
@@ -330,14 +442,18 @@ func (s *school) UpdateAchievement(ctx context.Context, req entity.ReqUpdateAchi
 	return int(res.SchoolID), nil
 }
 
-func (s *school) AddExtracurricular(ctx context.Context, req entity.ReqAddExtracurricular, image multipart.File) (int, error) {
+func (s *school) AddExtracurricular(ctx context.Context, uid int, req entity.ReqAddExtracurricular, image multipart.File) (int, error) {
 	if err := s.validator.Struct(req); err != nil {
 		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Extracurricular REQ, Error: %v", err)
 		s.dep.PromErr["error"] = err.Error()
 		return 0, errorr.NewBad("Missing or Invalid Request Body")
 	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageContent, authz.On(authz.School, int(req.SchoolID))); err != nil {
+		image.Close()
+		return 0, err
+	}
 	filename := fmt.Sprintf("%s_%d_%s", "Extra_", req.SchoolID, req.Image)
-	if err := s.dep.Gcp.UploadFile(image, filename); err != nil {
+	if err := s.dep.Storage.UploadFile(image, filename); err != nil {
 		s.dep.Log.Errorf("Error Service : %v", err)
 		s.dep.PromErr["error"] = err.Error()
 		image.Close()
@@ -355,30 +471,48 @@ func (s *school) AddExtracurricular(ctx context.Context, req entity.ReqAddExtrac
 		s.dep.PromErr["error"] = err.Error()
 		return 0, err
 	}
-	return res, err
+	audit.Track(ctx, audit.Change{Type: "extracurricular", ID: res.ID, After: res})
+	return int(res.SchoolID), nil
 }
 
-func (s *school) DeleteExtracurricular(ctx context.Context, id int) error {
+func (s *school) DeleteExtracurricular(ctx context.Context, id int, uid int) error {
+	if _, err := s.authz.Can(ctx, uid, authz.ManageContent, authz.On(authz.Extracurricular, id)); err != nil {
+		return err
+	}
+	before := entity.Extracurricular{}
+	if err := s.repo.GetItemById(s.dep.Db.WithContext(ctx), &before, id); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
 	if err := s.repo.DeleteExtracurricular(s.dep.Db.WithContext(ctx), id); err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return err
 	}
+	audit.Track(ctx, audit.Change{Type: "extracurricular", ID: id, Before: before})
 	return nil
 }
 
-func (s *school) DeleteProgressByid(ctx context.Context, id int) error {
+func (s *school) DeleteProgressByid(ctx context.Context, id int, uid int) error {
+	prog, err := s.checkProgressOwner(ctx, id, uid, "administrator", authz.ManageAdmission)
+	if err != nil {
+		return err
+	}
 	if err := s.repo.DeleteProgressByid(s.dep.Db.WithContext(ctx), id); err != nil {
 		return err
 	}
+	audit.Track(ctx, audit.Change{Type: "progress", ID: id, Before: prog})
 	return nil
 }
 
-func (s *school) UpdateExtracurricular(ctx context.Context, req entity.ReqUpdateExtracurricular, image multipart.File) (int, error) {
+func (s *school) UpdateExtracurricular(ctx context.Context, uid int, req entity.ReqUpdateExtracurricular, image multipart.File) (int, error) {
 	if err := s.validator.Struct(req); err != nil {
 		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Extracurricular REQ, Error: %v", err)
 		s.dep.PromErr["error"] = err.Error()
 		return 0, errorr.NewBad("Missing or Invalid Request Body")
 	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageContent, authz.On(authz.Extracurricular, req.Id)); err != nil {
+		return 0, err
+	}
 	filename := fmt.Sprintf("%s_%d_%s", "Extra_", req.Id, req.Image)
 	if image != nil {
 		req.Image = filename
@@ -389,13 +523,19 @@ func (s *school) UpdateExtracurricular(ctx context.Context, req entity.ReqUpdate
 		Title:       req.Name,
 	}
 	data.ID = uint(req.Id)
+	before := entity.Extracurricular{}
+	if err := s.repo.GetItemById(s.dep.Db.WithContext(ctx), &before, req.Id); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
 	res, err := s.repo.UpdateExtracurricular(s.dep.Db.WithContext(ctx), data)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return 0, err
 	}
+	audit.Track(ctx, audit.Change{Type: "extracurricular", ID: req.Id, Before: before, After: res})
 	if image != nil {
-		if err := s.dep.Gcp.UploadFile(image, filename); err != nil {
+		if err := s.dep.Storage.UploadFile(image, filename); err != nil {
 			s.dep.Log.Errorf("Error Service : %v", err)
 			image.Close()
 			s.dep.PromErr["error"] = err.Error()
@@ -406,16 +546,15 @@ func (s *school) UpdateExtracurricular(ctx context.Context, req entity.ReqUpdate
 	return int(res.SchoolID), nil
 }
 func (s *school) GetByUid(ctx context.Context, uid int) (*entity.ResDetailSchool, error) {
-	data, err := s.repo.GetByUid(s.dep.Db.WithContext(ctx), uid)
+	data, err := s.authz.Can(ctx, uid, authz.ViewSchool, authz.MySchool)
 	if err != nil {
-		s.dep.PromErr["error"] = err.Error()
 		return nil, err
 	}
 	previewlink := ""
 	if data.QuizLinkPub != "" {
 		previewlink = fmt.Sprintf("https://go-event.online/quiz/%s?preview=1", data.QuizLinkPub)
 	}
-	b64pdf, err := s.dep.Gcp.GetFile(data.Pdf)
+	b64pdf, err := s.dep.Storage.GetFile(data.Pdf)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return nil, errorr.NewBad("pdf doesn't exist")
@@ -441,6 +580,8 @@ func (s *school) GetByUid(ctx context.Context, uid int) (*entity.ResDetailSchool
 		Accreditation:   data.Accreditation,
 		Gmeet:           data.Gmeet,
 		Phone:           strings.Replace(data.Phone, "62", "0", 1),
+		Latitude:        data.Latitude,
+		Longitude:       data.Longitude,
 		GmeetDate:       data.GmeetDate,
 		QuizLinkPub:     data.QuizLinkPub,
 		QuizLinkPreview: previewlink,
@@ -535,7 +676,7 @@ func (s *school) GetByid(ctx context.Context, id int) (*entity.ResDetailSchool,
 		s.dep.PromErr["error"] = err.Error()
 		return nil, err
 	}
-	b64pdf, err := s.dep.Gcp.GetFile(data.Pdf)
+	b64pdf, err := s.dep.Storage.GetFile(data.Pdf)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return nil, errorr.NewBad("pdf doesn't exist")
@@ -562,6 +703,8 @@ func (s *school) GetByid(ctx context.Context, id int) (*entity.ResDetailSchool,
 		Accreditation: data.Accreditation,
 		Gmeet:         data.Gmeet,
 		GmeetDate:     data.GmeetDate,
+		Latitude:      data.Latitude,
+		Longitude:     data.Longitude,
 	}
 
 	for _, val := range data.Achievements {
@@ -621,12 +764,15 @@ func (s *school) GetByid(ctx context.Context, id int) (*entity.ResDetailSchool,
 	return &res, nil
 }
 
-func (s *school) AddFaq(ctx context.Context, req entity.ReqAddFaq) (int, error) {
+func (s *school) AddFaq(ctx context.Context, uid int, req entity.ReqAddFaq) (int, error) {
 	if err := s.validator.Struct(req); err != nil {
 		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Faq REQ, Error: %v", err)
 		s.dep.PromErr["error"] = err.Error()
 		return 0, errorr.NewBad("Missing or Invalid Request Body")
 	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageContent, authz.On(authz.School, req.SchoolId)); err != nil {
+		return 0, err
+	}
 	data := entity.Faq{
 		SchoolID: uint(req.SchoolId),
 		Question: req.Question,
@@ -637,45 +783,68 @@ func (s *school) AddFaq(ctx context.Context, req entity.ReqAddFaq) (int, error)
 		s.dep.PromErr["error"] = err.Error()
 		return 0, err
 	}
-	return res, err
+	audit.Track(ctx, audit.Change{Type: "faq", ID: res.ID, After: res})
+	return int(res.SchoolID), nil
 }
 
-func (s *school) DeleteFaq(ctx context.Context, id int) error {
+func (s *school) DeleteFaq(ctx context.Context, id int, uid int) error {
+	if _, err := s.authz.Can(ctx, uid, authz.ManageContent, authz.On(authz.Faq, id)); err != nil {
+		return err
+	}
+	before := entity.Faq{}
+	if err := s.repo.GetItemById(s.dep.Db.WithContext(ctx), &before, id); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
 	if err := s.repo.DeleteFaq(s.dep.Db.WithContext(ctx), id); err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return err
 	}
+	audit.Track(ctx, audit.Change{Type: "faq", ID: id, Before: before})
 	return nil
 }
 
-func (s *school) UpdateFaq(ctx context.Context, req entity.ReqUpdateFaq) (int, error) {
+func (s *school) UpdateFaq(ctx context.Context, uid int, req entity.ReqUpdateFaq) (int, error) {
 	if err := s.validator.Struct(req); err != nil {
 		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Faq REQ, Error: %v", err)
 		s.dep.PromErr["error"] = err.Error()
 		return 0, errorr.NewBad("Missing or Invalid Request Body")
 	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageContent, authz.On(authz.Faq, req.Id)); err != nil {
+		return 0, err
+	}
 	data := entity.Faq{
 		Question: req.Question,
 		Answer:   req.Answer,
 	}
 	data.ID = uint(req.Id)
+	before := entity.Faq{}
+	if err := s.repo.GetItemById(s.dep.Db.WithContext(ctx), &before, req.Id); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
 	res, err := s.repo.UpdateFaq(s.dep.Db.WithContext(ctx), data)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return 0, err
 	}
+	audit.Track(ctx, audit.Change{Type: "faq", ID: req.Id, Before: before, After: res})
 	return int(res.SchoolID), nil
 }
 
-func (s *school) AddPayment(ctx context.Context, req entity.ReqAddPayment, image multipart.File) (int, error) {
+func (s *school) AddPayment(ctx context.Context, uid int, req entity.ReqAddPayment, image multipart.File) (int, error) {
 	if err := s.validator.Struct(req); err != nil {
 		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Payment REQ, Error: %v", err)
 		s.dep.PromErr["error"] = err.Error()
 
 		return 0, errorr.NewBad("Missing or Invalid Request Body")
 	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageFinance, authz.On(authz.School, int(req.SchoolID))); err != nil {
+		image.Close()
+		return 0, err
+	}
 	filename := fmt.Sprintf("%s_%d_%s", "Payment_", req.SchoolID, req.Image)
-	if err := s.dep.Gcp.UploadFile(image, filename); err != nil {
+	if err := s.dep.Storage.UploadFile(image, filename); err != nil {
 		s.dep.Log.Errorf("Error Service : %v", err)
 		image.Close()
 		s.dep.PromErr["error"] = err.Error()
@@ -699,23 +868,36 @@ func (s *school) AddPayment(ctx context.Context, req entity.ReqAddPayment, image
 		s.dep.PromErr["error"] = err.Error()
 		return 0, err
 	}
-	return res, err
+	audit.Track(ctx, audit.Change{Type: "payment", ID: res.ID, After: res})
+	return int(res.SchoolID), nil
 }
 
-func (s *school) DeletePayment(ctx context.Context, id int) error {
+func (s *school) DeletePayment(ctx context.Context, id int, uid int) error {
+	if _, err := s.authz.Can(ctx, uid, authz.ManageFinance, authz.On(authz.Payment, id)); err != nil {
+		return err
+	}
+	before, err := s.repo.GetPaymentById(s.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
 	if err := s.repo.DeletePayment(s.dep.Db.WithContext(ctx), id); err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return err
 	}
+	audit.Track(ctx, audit.Change{Type: "payment", ID: id, Before: before})
 	return nil
 }
 
-func (s *school) UpdatePayment(ctx context.Context, req entity.ReqUpdatePayment, image multipart.File) (int, error) {
+func (s *school) UpdatePayment(ctx context.Context, uid int, req entity.ReqUpdatePayment, image multipart.File) (int, error) {
 	if err := s.validator.Struct(req); err != nil {
 		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Payment REQ, Error: %v", err)
 		s.dep.PromErr["error"] = err.Error()
 		return 0, errorr.NewBad("Missing or Invalid Request Body")
 	}
+	if _, err := s.authz.Can(ctx, uid, authz.ManageFinance, authz.On(authz.Payment, req.ID)); err != nil {
+		return 0, err
+	}
 	filename := fmt.Sprintf("%s_%d_%s", "Payment_", req.ID, req.Image)
 	if image != nil {
 		req.Image = filename
@@ -740,13 +922,19 @@ func (s *school) UpdatePayment(ctx context.Context, req entity.ReqUpdatePayment,
 		Type:        typee,
 	}
 	data.ID = uint(req.ID)
+	before, err := s.repo.GetPaymentById(s.dep.Db.WithContext(ctx), req.ID)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
 	res, err := s.repo.UpdatePayment(s.dep.Db.WithContext(ctx), data)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return 0, err
 	}
+	audit.Track(ctx, audit.Change{Type: "payment", ID: req.ID, Before: before, After: res})
 	if image != nil {
-		if err := s.dep.Gcp.UploadFile(image, filename); err != nil {
+		if err := s.dep.Storage.UploadFile(image, filename); err != nil {
 			s.dep.PromErr["error"] = err.Error()
 			s.dep.Log.Errorf("Error Service : %v", err)
 			image.Close()
@@ -763,6 +951,10 @@ func (s *school) CreateSubmission(ctx context.Context, req entity.ReqCreateSubmi
 		s.dep.PromErr["error"] = err.Error()
 		return 0, errorr.NewBad("Missing Or Invalid Req Body")
 	}
+	period, err := s.openPeriod(ctx, req.SchoolID, req.Track)
+	if err != nil {
+		return 0, err
+	}
 	studentphoname := fmt.Sprintf("%s_%d_%s", "Student_", req.UserID, req.StudentPhoto)
 	studentsignname := fmt.Sprintf("%s_%d_%s", "StudentSign_", req.UserID, req.StudentSignature)
 	parentsignname := fmt.Sprintf("%s_%d_%s", "ParentSign_", req.UserID, req.ParentSignature)
@@ -771,7 +963,7 @@ func (s *school) CreateSubmission(ctx context.Context, req entity.ReqCreateSubmi
 	errchan := make(chan error, 3)
 	go func() {
 		defer wg.Done()
-		if err := s.dep.Gcp.UploadFile(studentph, studentphoname); err != nil {
+		if err := s.dep.Storage.UploadFile(studentph, studentphoname); err != nil {
 			s.dep.Log.Errorf("Error Service : %v", err)
 			studentph.Close()
 			errchan <- err
@@ -782,7 +974,7 @@ func (s *school) CreateSubmission(ctx context.Context, req entity.ReqCreateSubmi
 	}()
 	go func() {
 		defer wg.Done()
-		if err := s.dep.Gcp.UploadFile(studentsign, studentsignname); err != nil {
+		if err := s.dep.Storage.UploadFile(studentsign, studentsignname); err != nil {
 			s.dep.Log.Errorf("Error Service : %v", err)
 			studentsign.Close()
 			errchan <- err
@@ -793,7 +985,7 @@ func (s *school) CreateSubmission(ctx context.Context, req entity.ReqCreateSubmi
 	}()
 	go func() {
 		defer wg.Done()
-		if err := s.dep.Gcp.UploadFile(parentsign, parentsignname); err != nil {
+		if err := s.dep.Storage.UploadFile(parentsign, parentsignname); err != nil {
 			s.dep.Log.Errorf("Error Service : %v", err)
 			parentsign.Close()
 			errchan <- err
@@ -810,6 +1002,31 @@ func (s *school) CreateSubmission(ctx context.Context, req entity.ReqCreateSubmi
 			return 0, err
 		}
 	}
+	req.StudentPhoto, req.StudentSignature, req.ParentSignature = studentphoname, studentsignname, parentsignname
+	return s.submit(ctx, req, period, 0)
+}
+
+// openPeriod returns the admission period a new submission joins, once its track is checked against the quotas.
+func (s *school) openPeriod(ctx context.Context, schid int, track string) (*entity.AdmissionPeriod, error) {
+	period, err := s.repo.GetOpenPeriod(s.dep.Db.WithContext(ctx), schid, time.Now())
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	quotas, err := s.repo.GetQuotas(s.dep.Db.WithContext(ctx), schid)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if err := admission.CheckTrack(quotas, track); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return period, nil
+}
+
+// submit stores a submission whose documents are already uploaded, along with a snapshot of the data as sent.
+func (s *school) submit(ctx context.Context, req entity.ReqCreateSubmission, period *entity.AdmissionPeriod, profileid uint) (int, error) {
 	parentaddres := entity.ReqAdressSubmission{
 		Province: req.ParentProvince,
 		District: req.ParentDistrict,
@@ -831,24 +1048,59 @@ func (s *school) CreateSubmission(ctx context.Context, req entity.ReqCreateSubmi
 	data := entity.Submission{
 		SchoolID:         uint(req.SchoolID),
 		UserID:           req.UserID,
-		StudentPhoto:     studentphoname,
+		StudentPhoto:     req.StudentPhoto,
 		StudentName:      req.StudentName,
 		ParentName:       req.ParentName,
 		ParentJob:        req.ParentJob,
 		Religion:         req.Religion,
-		ParentReligion:   req.Religion,
+		ParentReligion:   req.ParentReligion,
 		ParentGender:     req.ParentGender,
 		PlaceDate:        req.PlaceDate,
 		Gender:           req.Gender,
 		GraduationFrom:   req.GraduationFrom,
 		NISN:             req.NISN,
 		ParentPhone:      req.ParentPhone,
-		ParentSignature:  parentsignname,
-		StudentSignature: studentsignname,
+		ParentSignature:  req.ParentSignature,
+		StudentSignature: req.StudentSignature,
 		Date:             time.Now().Format("2006-01-02"),
+		Track:            req.Track,
+		PeriodID:         period.ID,
+		ReportAverage:    req.ReportAverage,
+		Achievements:     req.Achievements,
 		ParentAddress:    string(parentadd),
 		StudentAddress:   string(studentadd),
 	}
+	if point := s.locate(ctx, pkg.Address(req.StudentDetail, req.StudentVillage, req.StudentDistrict, req.StudentCity, req.StudentProvince, req.StudentZipCode)); point != nil {
+		data.StudentLatitude, data.StudentLongitude = &point.Lat, &point.Lng
+	}
+	snapshot, _ := json.Marshal(entity.SubmissionSnapshot{
+		ProfileID: profileid,
+		StudentData: entity.StudentData{
+			Photo:          data.StudentPhoto,
+			Name:           data.StudentName,
+			PlaceDate:      data.PlaceDate,
+			NISN:           data.NISN,
+			GraduationFrom: data.GraduationFrom,
+			Religion:       data.Religion,
+			Gender:         data.Gender,
+			Adress:         studentaddres,
+		},
+		ParentData: entity.ParentData{
+			Name:     data.ParentName,
+			Job:      data.ParentJob,
+			Religion: data.ParentReligion,
+			Phone:    data.ParentPhone,
+			Adress:   parentaddres,
+			Gender:   data.ParentGender,
+		},
+		StudentSignature: data.StudentSignature,
+		ParentSignature:  data.ParentSignature,
+		Track:            data.Track,
+		ReportAverage:    data.ReportAverage,
+		Achievements:     data.Achievements,
+		SubmittedAt:      time.Now(),
+	})
+	data.Snapshot = string(snapshot)
 	res, err := s.repo.CreateSubmission(s.dep.Db.WithContext(ctx), data)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
@@ -857,93 +1109,170 @@ func (s *school) CreateSubmission(ctx context.Context, req entity.ReqCreateSubmi
 	return res, nil
 }
 
-func (s *school) UpdateProgressByid(ctx context.Context, id int, status string) (int, error) {
-	if status != "File Approved" && status != "Send Detail Costs Registration" &&
-		status != "Failed File Approved" && status != "Send Test Link" && status != "Send Detail Costs Her-Registration" &&
-		status != "Finish" {
-		s.dep.PromErr["error"] = "Status Not Available"
-		return 0, errorr.NewBad("Invalid Request Body")
+// CreateSubmissionFromProfile registers the student at a school with its profile, the documents are copied
+// for the school so a later change of the profile does not alter the submission.
+func (s *school) CreateSubmissionFromProfile(ctx context.Context, uid int, req entity.ReqProfileSubmission) (int, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR]WHEN VALIDATE PROFILE SUBMISSION REQ, err : %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return 0, errorr.NewBad("Missing Or Invalid Req Body")
 	}
-	res, err := s.repo.UpdateProgress(s.dep.Db.WithContext(ctx), id, status)
+	profile, err := s.userrepo.GetStudentProfile(s.dep.Db.WithContext(ctx), uid)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return 0, err
 	}
-	if status == "File Approved" {
-		user, err := s.userrepo.GetById(s.dep.Db.WithContext(ctx), int(res.UserID))
-		if err != nil {
-			s.dep.Log.Errorf("[ERROR]WHEN GETTING USER DATA: %v", err)
-		}
-		if err := s.dep.Pusher.Publish(map[string]any{"username": user.Username, "type": "admission", "status": status, "progress_id": id}, 2); err != nil {
-			s.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
-		}
-	} else if status == "Send Detail Costs Registration" || status == "Send Detail Costs Her-Registration" {
-		typecost := ""
-		if status == "Send Detail Costs Registration" {
-			typecost = "Registration"
-		} else {
-			typecost = "Her-Registration"
-		}
-		user, err := s.userrepo.GetById(s.dep.Db.WithContext(ctx), int(res.UserID))
-		if err != nil {
-			s.dep.Log.Errorf("[ERROR]WHEN GETTING USER DATA: %v", err)
-		}
-		school, err := s.repo.GetById(s.dep.Db.WithContext(ctx), int(res.SchoolID))
+	subm := profileSubmission(profile, req)
+	if err := s.validator.Struct(subm); err != nil {
+		s.dep.Log.Errorf("[ERROR]WHEN VALIDATE PROFILE SUBMISSION, err : %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return 0, errorr.NewBad("Student profile is incomplete")
+	}
+	period, err := s.openPeriod(ctx, req.SchoolID, req.Track)
+	if err != nil {
+		return 0, err
+	}
+	documents := map[string]*string{"Student_": &subm.StudentPhoto, "StudentSign_": &subm.StudentSignature, "ParentSign_": &subm.ParentSignature}
+	for prefix, name := range documents {
+		file, err := s.dep.Storage.ReadFile(*name)
 		if err != nil {
-			s.dep.Log.Errorf("[ERROR]WHEN GETTING SCHOOL DATA: %v", err)
-		}
-		if err := s.dep.Pusher.Publish(map[string]any{"username": user.Username, "type": "admission", "status": status, "progress_id": id}, 2); err != nil {
-			s.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
+			s.dep.Log.Errorf("[ERROR]WHEN READING PROFILE DOCUMENT, Err : %v", err)
+			s.dep.PromErr["error"] = err.Error()
+			return 0, errorr.NewInternal("Internal Server Error")
 		}
-		encodeddata, _ := json.Marshal(map[string]any{"email": user.Email, "name": user.FirstName + " " + user.SureName, "school": school.Name, "type": typecost, "school_id": school.ID})
-		go func() {
-			if err := s.dep.Nsq.Publish("11", encodeddata); err != nil {
-				s.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
-			}
-		}()
-	} else if status == "Send Test Link" {
-		schooldata, _ := s.repo.GetById(s.dep.Db.WithContext(ctx), int(res.SchoolID))
-		userdata, _ := s.userrepo.GetById(s.dep.Db.WithContext(ctx), int(res.UserID))
-		encodeddata, _ := json.Marshal(map[string]any{"email": userdata.Email, "name": userdata.FirstName + " " + userdata.SureName, "school": schooldata.Name, "test": schooldata.QuizLinkPub})
-		if err := s.dep.Pusher.Publish(map[string]any{"username": userdata.Username, "type": "admission", "status": status, "progress_id": id}, 2); err != nil {
-			s.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
+		copyname := fmt.Sprintf("%s_%d_%d_%s", prefix, uid, req.SchoolID, *name)
+		if err := s.dep.Storage.WriteFile(copyname, file); err != nil {
+			s.dep.PromErr["error"] = err.Error()
+			return 0, err
 		}
-		go func() {
-			if err := s.dep.Nsq.Publish("8", encodeddata); err != nil {
-				s.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
-			}
-		}()
+		*name = copyname
+	}
+	return s.submit(ctx, subm, period, profile.ID)
+}
+
+// profileSubmission fills a submission with the profile of the student and the values given for the school.
+func profileSubmission(profile *entity.StudentProfile, req entity.ReqProfileSubmission) entity.ReqCreateSubmission {
+	studentaddress := entity.ReqAdressSubmission{}
+	parentaddress := entity.ReqAdressSubmission{}
+	json.Unmarshal([]byte(profile.StudentAddress), &studentaddress)
+	json.Unmarshal([]byte(profile.ParentAddress), &parentaddress)
+	res := entity.ReqCreateSubmission{
+		UserID:           profile.UserID,
+		SchoolID:         req.SchoolID,
+		StudentPhoto:     profile.StudentPhoto,
+		StudentName:      profile.StudentName,
+		PlaceDate:        profile.PlaceDate,
+		Gender:           profile.Gender,
+		Religion:         profile.Religion,
+		GraduationFrom:   profile.GraduationFrom,
+		NISN:             profile.NISN,
+		StudentProvince:  studentaddress.Province,
+		StudentDistrict:  studentaddress.District,
+		StudentVillage:   studentaddress.Village,
+		StudentZipCode:   studentaddress.ZipCode,
+		StudentCity:      studentaddress.City,
+		StudentDetail:    studentaddress.Detail,
+		ParentProvince:   parentaddress.Province,
+		ParentDistrict:   parentaddress.District,
+		ParentVillage:    parentaddress.Village,
+		ParentZipCode:    parentaddress.ZipCode,
+		ParentCity:       parentaddress.City,
+		ParentDetail:     parentaddress.Detail,
+		ParentName:       profile.ParentName,
+		ParentGender:     profile.ParentGender,
+		ParentJob:        profile.ParentJob,
+		ParentReligion:   profile.ParentReligion,
+		ParentPhone:      profile.ParentPhone,
+		ParentSignature:  profile.ParentSignature,
+		StudentSignature: profile.StudentSignature,
+		Track:            req.Track,
+		ReportAverage:    profile.ReportAverage,
+		Achievements:     profile.Achievements,
+	}
+	if req.ReportAverage != nil {
+		res.ReportAverage = req.ReportAverage
+	}
+	if req.Achievements != nil {
+		res.Achievements = *req.Achievements
+	}
+	return res
+}
+
+func (s *school) UpdateProgressByid(ctx context.Context, id int, uid int, req entity.ReqUpdateProgress) (int, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE UPDATE PROGRESS REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return 0, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	prog, err := s.checkProgressOwner(ctx, id, uid, "administrator", authz.ManageAdmission)
+	if err != nil {
+		return 0, err
+	}
+	res, err := s.workflow.UpdateProgress(ctx, id, admission.Change{
+		Actor:     uid,
+		Status:    admission.Status(req.ProgressStatus),
+		Reason:    req.Reason,
+		Rejection: admission.RejectionReason(req.RejectionReason),
+		Note:      req.Note,
+		Fields:    req.RevisionFields,
+	})
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
+	audit.Track(ctx, audit.Change{Type: "progress", ID: id, Before: prog, After: res})
+	return int(res.ID), nil
+}
+
+// bulkActions maps the bulk actions to the status they move the progresses to.
+var bulkActions = map[string]admission.Status{
+	"approve":        admission.FileApproved,
+	"reject":         admission.FailedFileApproved,
+	"send_test_link": admission.SendTestLink,
+	"finish":         admission.Finish,
+}
 
-	} else if status == "Finish" {
-		schooldata, _ := s.repo.GetById(s.dep.Db.WithContext(ctx), int(res.SchoolID))
-		userdata, _ := s.userrepo.GetById(s.dep.Db.WithContext(ctx), int(res.UserID))
-		encodeddata, _ := json.Marshal(map[string]any{"email": userdata.Email, "name": userdata.FirstName + " " + userdata.SureName, "school": schooldata.Name, "user_id": int(userdata.ID), "school_id": int(schooldata.ID)})
-		if err := s.dep.Pusher.Publish(map[string]any{"username": userdata.Username, "type": "admission", "status": status, "progress_id": id}, 2); err != nil {
-			s.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
+func (s *school) BulkUpdateProgress(ctx context.Context, uid int, req entity.ReqBulkProgress) ([]entity.ResBulkProgress, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE BULK UPDATE PROGRESS REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	ids := []int{}
+	seen := map[int]bool{}
+	for _, id := range req.ProgressIds {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
 		}
-		go func() {
-			if err := s.dep.Nsq.Publish("12", encodeddata); err != nil {
-				s.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
-			}
-			if err := s.dep.Nsq.Publish("14", encodeddata); err != nil {
-				s.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
-			}
-		}()
+	}
+	results := s.workflow.UpdateProgresses(ctx, int(schooldata.ID), ids, admission.Change{
+		Actor:     uid,
+		Status:    bulkActions[req.Action],
+		Reason:    req.Reason,
+		Rejection: admission.RejectionReason(req.RejectionReason),
+		Note:      req.Note,
+	})
+	res := s.resBulk(results)
+	audit.Track(ctx, audit.Change{Type: "progress", ID: ids, After: res})
+	return res, nil
+}
 
-	} else if status == "Failed File Approved" {
-		schooldata, _ := s.repo.GetById(s.dep.Db.WithContext(ctx), int(res.SchoolID))
-		userdata, _ := s.userrepo.GetById(s.dep.Db.WithContext(ctx), int(res.UserID))
-		encodeddata, _ := json.Marshal(map[string]any{"email": userdata.Email, "name": userdata.FirstName + " " + userdata.SureName, "school": schooldata.Name, "reason": "Berkas Pendaftaran Ditolak"})
-		if err := s.dep.Pusher.Publish(map[string]any{"username": userdata.Username, "type": "admission", "status": status, "progress_id": id}, 2); err != nil {
-			s.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
+func (s *school) resBulk(results []admission.Result) []entity.ResBulkProgress {
+	res := []entity.ResBulkProgress{}
+	for _, val := range results {
+		if val.Err != nil {
+			s.dep.PromErr["error"] = val.Err.Error()
+			res = append(res, entity.ResBulkProgress{ProgressID: val.ProgressID, Message: val.Err.Error()})
+			continue
 		}
-		go func() {
-			if err := s.dep.Nsq.Publish("13", encodeddata); err != nil {
-				s.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
-			}
-		}()
+		res = append(res, entity.ResBulkProgress{ProgressID: val.ProgressID, Success: true, ProgressStatus: val.Progress.Status})
 	}
-	return int(res.ID), nil
+	return res
 }
 
 func (s *school) GetAllProgressByUid(ctx context.Context, uid int) ([]entity.ResAllProgress, error) {
@@ -964,17 +1293,91 @@ func (s *school) GetAllProgressByUid(ctx context.Context, uid int) ([]entity.Res
 	}
 	return res, nil
 }
-func (s *school) GetProgressById(ctx context.Context, id int) (*entity.ResDetailProgress, error) {
-	data, err := s.repo.GetProgressByid(s.dep.Db.WithContext(ctx), id)
+func (s *school) GetProgressById(ctx context.Context, id int, uid int, role string) (*entity.ResDetailProgress, error) {
+	data, err := s.checkProgressOwner(ctx, id, uid, role, authz.ViewAdmission)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := s.workflow.Pipeline(ctx, int(data.SchoolID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	notes, err := s.repo.GetNotes(s.dep.Db.WithContext(ctx), id, false)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return &entity.ResDetailProgress{Id: int(data.ID), Status: data.Status, Pipeline: resPipeline(pipeline).Statuses, RejectionReason: data.RejectionReason, RevisionFields: admission.SplitRevision(data.RevisionFields), Notes: resNotes(notes)}, nil
+}
+
+// checkProgressOwner makes sure the progress belongs to the student, or to the school where the admin has the permission.
+func (s *school) checkProgressOwner(ctx context.Context, id int, uid int, role string, perm authz.Action) (*entity.Progress, error) {
+	prog, err := s.repo.GetProgressByid(s.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	switch role {
+	// parents go through the routes of their child, where the role is student
+	case "student", "parent":
+		if err := authz.Owner(uid, prog.UserID); err != nil {
+			s.dep.PromErr["error"] = "Progress does not belong to the student"
+			return nil, err
+		}
+	case "administrator":
+		if _, err := s.authz.Can(ctx, uid, perm, authz.On(authz.School, int(prog.SchoolID))); err != nil {
+			return nil, err
+		}
+	}
+	return prog, nil
+}
+
+func (s *school) AddNote(ctx context.Context, id int, uid int, req entity.ReqAddNote) (int, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE ADD NOTE REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return 0, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	if _, err := s.checkProgressOwner(ctx, id, uid, "administrator", authz.ManageAdmission); err != nil {
+		return 0, err
+	}
+	note := entity.AdmissionNote{ProgressID: uint(id), UserID: uint(uid), Note: req.Note, Internal: req.Internal}
+	if err := s.repo.CreateNote(s.dep.Db.WithContext(ctx), note); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
+	return id, nil
+}
+
+func (s *school) GetProgressTimeline(ctx context.Context, id int, uid int, role string) ([]entity.ResProgressEvent, error) {
+	if _, err := s.checkProgressOwner(ctx, id, uid, role, authz.ViewAdmission); err != nil {
+		return nil, err
+	}
+	data, err := s.repo.GetProgressEvents(s.dep.Db.WithContext(ctx), id)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return nil, err
 	}
-	return &entity.ResDetailProgress{Id: int(data.ID), Status: data.Status}, nil
+	res := []entity.ResProgressEvent{}
+	for _, val := range data {
+		res = append(res, entity.ResProgressEvent{
+			FromStatus: val.FromStatus,
+			ToStatus:   val.ToStatus,
+			ActorID:    int(val.ActorID),
+			Reason:     val.Reason,
+			CreatedAt:  val.CreatedAt,
+		})
+	}
+	return res, nil
 }
 
-func (s *school) GetAllProgressAndSubmissionByuid(ctx context.Context, uid int) ([]entity.ResAllProgressSubmission, error) {
-	data, err := s.repo.GetAllProgressAndSubmissionByuid(s.dep.Db.WithContext(ctx), uid)
+func (s *school) GetAllProgressAndSubmission(ctx context.Context, uid int) ([]entity.ResAllProgressSubmission, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.repo.GetAllProgressAndSubmission(s.dep.Db.WithContext(ctx), int(schooldata.ID))
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return nil, err
@@ -993,12 +1396,39 @@ func (s *school) GetAllProgressAndSubmissionByuid(ctx context.Context, uid int)
 	}
 	return res, nil
 }
-func (s *school) GetSubmissionByid(ctx context.Context, id int) (*entity.ResDetailSubmission, error) {
+
+// GetFile returns a stored file in base64 once the admin may see the school it belongs to,
+// the files of an admission need the admission view.
+func (s *school) GetFile(ctx context.Context, uid int, fname string) (string, error) {
+	schid, document, err := s.repo.GetFileOwner(s.dep.Db.WithContext(ctx), fname)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return "", err
+	}
+	action := authz.ViewSchool
+	if document {
+		action = authz.ViewAdmission
+	}
+	if _, err := s.authz.Can(ctx, uid, action, authz.On(authz.School, schid)); err != nil {
+		return "", err
+	}
+	res, err := s.dep.Storage.GetFile(fname)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return "", errorr.NewBad("Data Not Found")
+	}
+	return res, nil
+}
+
+func (s *school) GetSubmissionByid(ctx context.Context, id int, uid int) (*entity.ResDetailSubmission, error) {
 	data, err := s.repo.GetSubmissionByid(s.dep.Db.WithContext(ctx), id)
 	if err != nil {
 		s.dep.PromErr["error"] = err.Error()
 		return nil, err
 	}
+	if _, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.On(authz.School, int(data.SchoolID))); err != nil {
+		return nil, err
+	}
 	studentaddress := entity.ReqAdressSubmission{}
 	Parentaddress := entity.ReqAdressSubmission{}
 	json.Unmarshal([]byte(data.StudentAddress), &studentaddress)
@@ -1026,15 +1456,135 @@ func (s *school) GetSubmissionByid(ctx context.Context, id int) (*entity.ResDeta
 			Adress:   Parentaddress,
 			Gender:   data.ParentGender,
 		},
+		Notes: []entity.ResAdmissionNote{},
+	}
+	if data.Snapshot != "" {
+		res.Snapshot = &entity.SubmissionSnapshot{}
+		json.Unmarshal([]byte(data.Snapshot), res.Snapshot)
+	}
+	prog, err := s.repo.GetLastProgress(s.dep.Db.WithContext(ctx), int(data.UserID), int(data.SchoolID))
+	if err == nil {
+		notes, err := s.repo.GetNotes(s.dep.Db.WithContext(ctx), int(prog.ID), true)
+		if err != nil {
+			s.dep.PromErr["error"] = err.Error()
+			return nil, err
+		}
+		res.ProgressStatus = prog.Status
+		res.RejectionReason = prog.RejectionReason
+		res.Notes = resNotes(notes)
 	}
 	return &res, nil
 }
 
-func (s *school) AddReview(ctx context.Context, req entity.Reviews) (int, error) {
-	if err := s.validator.Struct(req); err != nil {
-		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Review REQ, Error: %v", err)
-		s.dep.PromErr["error"] = err.Error()
-		return 0, errorr.NewBad("Missing or Invalid Request Body")
+// revisionPrefixes are the storage prefixes of the submission files, the same ones used by CreateSubmission.
+var revisionPrefixes = map[string]string{"student_photo": "Student_", "student_signature": "StudentSign_", "parent_signature": "ParentSign_"}
+
+// revisionValues reads the corrected value of every revisable submission field, empty when it is missing.
+var revisionValues = map[string]func(req entity.ReqReviseSubmission) string{
+	"student_name":    func(req entity.ReqReviseSubmission) string { return req.StudentName },
+	"place_date":      func(req entity.ReqReviseSubmission) string { return req.PlaceDate },
+	"gender":          func(req entity.ReqReviseSubmission) string { return req.Gender },
+	"religion":        func(req entity.ReqReviseSubmission) string { return req.Religion },
+	"graduation_from": func(req entity.ReqReviseSubmission) string { return req.GraduationFrom },
+	"nisn":            func(req entity.ReqReviseSubmission) string { return req.NISN },
+	"parent_name":     func(req entity.ReqReviseSubmission) string { return req.ParentName },
+	"parent_job":      func(req entity.ReqReviseSubmission) string { return req.ParentJob },
+	"parent_religion": func(req entity.ReqReviseSubmission) string { return req.ParentReligion },
+	"parent_gender":   func(req entity.ReqReviseSubmission) string { return req.ParentGender },
+	"parent_phone":    func(req entity.ReqReviseSubmission) string { return req.ParentPhone },
+	"student_address": func(req entity.ReqReviseSubmission) string {
+		return revisionAddress(entity.ReqAdressSubmission{Province: req.StudentProvince, District: req.StudentDistrict, Village: req.StudentVillage, ZipCode: req.StudentZipCode, City: req.StudentCity, Detail: req.StudentDetail})
+	},
+	"parent_address": func(req entity.ReqReviseSubmission) string {
+		return revisionAddress(entity.ReqAdressSubmission{Province: req.ParentProvince, District: req.ParentDistrict, Village: req.ParentVillage, ZipCode: req.ParentZipCode, City: req.ParentCity, Detail: req.ParentDetail})
+	},
+}
+
+func revisionAddress(addr entity.ReqAdressSubmission) string {
+	if addr.Province == "" || addr.District == "" || addr.Village == "" || addr.ZipCode == "" || addr.City == "" || addr.Detail == "" {
+		return ""
+	}
+	res, _ := json.Marshal(addr)
+	return string(res)
+}
+
+func (s *school) ReviseSubmission(ctx context.Context, id int, uid int, req entity.ReqReviseSubmission, files map[string]multipart.File) (int, error) {
+	defer func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}()
+	subm, err := s.repo.GetSubmissionByid(s.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
+	if err := authz.Owner(uid, subm.UserID); err != nil {
+		s.dep.PromErr["error"] = "Submission does not belong to the student"
+		return 0, err
+	}
+	prog, err := s.repo.GetLastProgress(s.dep.Db.WithContext(ctx), uid, int(subm.SchoolID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
+	if prog.Status != string(admission.NeedsRevision) {
+		s.dep.PromErr["error"] = "Submission does not need revision"
+		return 0, errorr.NewBad("Submission does not need revision")
+	}
+	fields := admission.SplitRevision(prog.RevisionFields)
+	data := map[string]any{}
+	for _, field := range fields {
+		if admission.IsRevisionDocument(field) {
+			if files[field] == nil {
+				s.dep.PromErr["error"] = field + " is missing"
+				return 0, errorr.NewBad(field + " is required")
+			}
+			continue
+		}
+		value := revisionValues[field](req)
+		if value == "" {
+			s.dep.PromErr["error"] = field + " is missing"
+			return 0, errorr.NewBad(field + " is required")
+		}
+		data[field] = value
+		if field != "student_address" {
+			continue
+		}
+		if point := s.locate(ctx, pkg.Address(req.StudentDetail, req.StudentVillage, req.StudentDistrict, req.StudentCity, req.StudentProvince, req.StudentZipCode)); point != nil {
+			data["student_latitude"], data["student_longitude"] = point.Lat, point.Lng
+		}
+	}
+	filenames := map[string]string{"student_photo": req.StudentPhoto, "student_signature": req.StudentSignature, "parent_signature": req.ParentSignature}
+	for _, field := range fields {
+		if !admission.IsRevisionDocument(field) {
+			continue
+		}
+		filename := fmt.Sprintf("%s_%d_%s", revisionPrefixes[field], uid, filenames[field])
+		if err := s.dep.Storage.UploadFile(files[field], filename); err != nil {
+			s.dep.Log.Errorf("Error Service : %v", err)
+			s.dep.PromErr["error"] = err.Error()
+			return 0, err
+		}
+		data[field] = filename
+	}
+	if err := s.repo.UpdateSubmission(s.dep.Db.WithContext(ctx), id, data); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
+	res, err := s.workflow.ResubmitProgressByUid(ctx, uid, int(subm.SchoolID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
+	return int(res.ID), nil
+}
+
+func (s *school) AddReview(ctx context.Context, req entity.Reviews) (int, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE Add Review REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return 0, errorr.NewBad("Missing or Invalid Request Body")
 	}
 	if !s.dep.Validation.Validate(req.Review) {
 		s.dep.PromErr["error"] = "comment contains bad words"
@@ -1048,15 +1598,14 @@ func (s *school) AddReview(ctx context.Context, req entity.Reviews) (int, error)
 	return res, nil
 }
 
-func (s *school) CreateQuiz(ctx context.Context, req []entity.ReqAddQuiz) error {
+func (s *school) CreateQuiz(ctx context.Context, uid int, req []entity.ReqAddQuiz) error {
 
 	if len(req) == 0 {
 		s.dep.PromErr["error"] = "the length of the data is 0"
 		return errorr.NewBad("Missing or Invalid Request Body")
 	}
-	data, err := s.repo.GetById(s.dep.Db.WithContext(ctx), req[0].SchoolID)
+	data, err := s.authz.Can(ctx, uid, authz.ManageAdmission, authz.On(authz.School, req[0].SchoolID))
 	if err != nil {
-		s.dep.PromErr["error"] = "school id doesn't exist"
 		return err
 	}
 	quizlink, prev, result, err := s.dep.Quiz.CreateQuiz(data.Name, s.dep.Log)
@@ -1081,6 +1630,7 @@ func (s *school) CreateQuiz(ctx context.Context, req []entity.ReqAddQuiz) error
 		s.dep.PromErr["error"] = err.Error()
 		return err
 	}
+	audit.Track(ctx, audit.Change{Type: "school", ID: newdata.ID, Before: quizLinks(*data), After: quizLinks(newdata)})
 	encodeddata, _ := json.Marshal(reqdata)
 	go func() {
 		if err := s.dep.Nsq.Publish("9", encodeddata); err != nil {
@@ -1092,11 +1642,14 @@ func (s *school) CreateQuiz(ctx context.Context, req []entity.ReqAddQuiz) error
 	return err
 }
 
+func quizLinks(data entity.School) map[string]any {
+	return map[string]any{"QuizLinkPub": data.QuizLinkPub, "QuizLinkPreview": data.QuizLinkPreview, "QuizLinkResult": data.QuizLinkResult}
+}
+
 func (s *school) GetTestResult(ctx context.Context, uid int) ([]pkg.TestResult, error) {
 
-	schooldata, err := s.repo.GetByUid(s.dep.Db.WithContext(ctx), uid)
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
 	if err != nil {
-		s.dep.PromErr["error"] = err.Error()
 		return nil, err
 	}
 	res, err := s.dep.Quiz.GetResult(schooldata.QuizLinkResult, s.dep.Log)
@@ -1110,3 +1663,671 @@ func (s *school) GetTestResult(ctx context.Context, uid int) ([]pkg.TestResult,
 	}
 	return res, nil
 }
+
+func (s *school) GetPipeline(ctx context.Context, uid int) (*entity.ResPipeline, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := s.workflow.Pipeline(ctx, int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return resPipeline(pipeline), nil
+}
+
+func (s *school) UpdatePipeline(ctx context.Context, uid int, req entity.ReqUpdatePipeline) (*entity.ResPipeline, error) {
+	pipeline, err := admission.NewPipeline(req.Stages)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if len(pipeline) == 0 {
+		pipeline = admission.DefaultPipeline
+	}
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.repo.GetActiveStatusBySchool(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	for _, val := range statuses {
+		if !pipeline.Contains(admission.Status(val)) {
+			s.dep.PromErr["error"] = "Pipeline drops an active status"
+			return nil, errorr.NewBad("There are still participants with status " + val)
+		}
+	}
+	before, err := s.workflow.Pipeline(ctx, int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if err := s.repo.UpdatePipeline(s.dep.Db.WithContext(ctx), int(schooldata.ID), pipeline.Steps(schooldata.ID)); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res := resPipeline(pipeline)
+	audit.Track(ctx, audit.Change{Type: "pipeline", ID: schooldata.ID, Before: resPipeline(before), After: res})
+	return res, nil
+}
+
+func (s *school) GetQuotas(ctx context.Context, uid int) ([]entity.ResQuota, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	return s.resQuotas(ctx, int(schooldata.ID))
+}
+
+func (s *school) UpdateQuotas(ctx context.Context, uid int, req entity.ReqUpdateQuota) ([]entity.ResQuota, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE UPDATE QUOTA REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	quotas := []entity.Quota{}
+	seen := map[string]bool{}
+	for _, val := range req.Quotas {
+		if seen[val.Track] {
+			s.dep.PromErr["error"] = "Duplicate quota track"
+			return nil, errorr.NewBad("Quota for track " + val.Track + " is defined more than once")
+		}
+		seen[val.Track] = true
+		quotas = append(quotas, entity.Quota{SchoolID: schooldata.ID, Track: val.Track, Capacity: val.Capacity})
+	}
+	before, err := s.repo.GetQuotas(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if err := s.repo.UpdateQuotas(s.dep.Db.WithContext(ctx), int(schooldata.ID), quotas); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	audit.Track(ctx, audit.Change{Type: "quota", ID: schooldata.ID, Before: before, After: quotas})
+	if err := s.workflow.Promote(ctx, int(schooldata.ID)); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN PROMOTING WAITLIST, Error: %v", err)
+	}
+	return s.resQuotas(ctx, int(schooldata.ID))
+}
+
+func (s *school) GetWaitlist(ctx context.Context, uid int) ([]entity.ResWaitlist, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.repo.GetWaitlist(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res := []entity.ResWaitlist{}
+	for i, val := range data {
+		waitlist := entity.ResWaitlist{Rank: i + 1, ProgressId: int(val.ID), UserId: int(val.UserID), Track: val.Track}
+		if val.WaitlistedAt != nil {
+			waitlist.WaitlistedAt = *val.WaitlistedAt
+		}
+		res = append(res, waitlist)
+	}
+	return res, nil
+}
+
+func (s *school) GetPeriods(ctx context.Context, uid int) ([]entity.ResAdmissionPeriod, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.repo.GetPeriods(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	now := time.Now()
+	res := []entity.ResAdmissionPeriod{}
+	for _, val := range data {
+		res = append(res, entity.ResAdmissionPeriod{
+			Id:              int(val.ID),
+			Name:            val.Name,
+			OpenAt:          val.OpenAt,
+			CloseAt:         val.CloseAt,
+			StepDeadline:    val.StepDeadline,
+			RegistrationFee: val.RegistrationFee,
+			Open:            !now.Before(val.OpenAt) && now.Before(val.CloseAt),
+		})
+	}
+	return res, nil
+}
+
+func (s *school) CreatePeriod(ctx context.Context, uid int, req entity.ReqAdmissionPeriod) (int, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE CREATE PERIOD REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return 0, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageAdmission, authz.MySchool)
+	if err != nil {
+		return 0, err
+	}
+	period := entity.AdmissionPeriod{SchoolID: schooldata.ID}
+	if err := s.savePeriod(ctx, &period, req); err != nil {
+		return 0, err
+	}
+	audit.Track(ctx, audit.Change{Type: "admission_period", ID: period.ID, After: period})
+	return int(period.ID), nil
+}
+
+func (s *school) UpdatePeriod(ctx context.Context, id int, uid int, req entity.ReqAdmissionPeriod) error {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE UPDATE PERIOD REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return errorr.NewBad("Missing or Invalid Request Body")
+	}
+	period, err := s.checkPeriodOwner(ctx, id, uid, authz.ManageAdmission)
+	if err != nil {
+		return err
+	}
+	before := *period
+	if err := s.savePeriod(ctx, period, req); err != nil {
+		return err
+	}
+	audit.Track(ctx, audit.Change{Type: "admission_period", ID: id, Before: before, After: period})
+	return nil
+}
+
+func (s *school) DeletePeriod(ctx context.Context, id int, uid int) error {
+	period, err := s.checkPeriodOwner(ctx, id, uid, authz.ManageAdmission)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeletePeriod(s.dep.Db.WithContext(ctx), id); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	audit.Track(ctx, audit.Change{Type: "admission_period", ID: id, Before: period})
+	return nil
+}
+
+func (s *school) checkPeriodOwner(ctx context.Context, id int, uid int, perm authz.Action) (*entity.AdmissionPeriod, error) {
+	period, err := s.repo.GetPeriodById(s.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if _, err := s.authz.Can(ctx, uid, perm, authz.On(authz.School, int(period.SchoolID))); err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+// savePeriod stores the period once it is sure no other period of the school is open at the same time.
+func (s *school) savePeriod(ctx context.Context, period *entity.AdmissionPeriod, req entity.ReqAdmissionPeriod) error {
+	period.Name = req.Name
+	period.OpenAt = req.OpenAt
+	period.CloseAt = req.CloseAt
+	period.StepDeadline = req.StepDeadline
+	period.RegistrationFee = req.RegistrationFee
+	overlap, err := s.repo.IsPeriodOverlapping(s.dep.Db.WithContext(ctx), *period)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if overlap {
+		s.dep.PromErr["error"] = "Admission period overlaps"
+		return errorr.NewBad("Admission period overlaps with another period")
+	}
+	if period.ID == 0 {
+		id, err := s.repo.CreatePeriod(s.dep.Db.WithContext(ctx), *period)
+		if err != nil {
+			s.dep.PromErr["error"] = err.Error()
+			return err
+		}
+		period.ID = uint(id)
+		return nil
+	}
+	if err := s.repo.UpdatePeriod(s.dep.Db.WithContext(ctx), *period); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	return nil
+}
+
+func (s *school) resQuotas(ctx context.Context, schid int) ([]entity.ResQuota, error) {
+	quotas, err := s.repo.GetQuotas(s.dep.Db.WithContext(ctx), schid)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res := []entity.ResQuota{}
+	for _, val := range quotas {
+		used, err := s.repo.CountSeats(s.dep.Db.WithContext(ctx), schid, val.Track)
+		if err != nil {
+			s.dep.PromErr["error"] = err.Error()
+			return nil, err
+		}
+		res = append(res, entity.ResQuota{Track: val.Track, Capacity: val.Capacity, Used: used})
+	}
+	return res, nil
+}
+
+func resPipeline(pipeline admission.Pipeline) *entity.ResPipeline {
+	res := entity.ResPipeline{Stages: []string{}, Statuses: []string{}}
+	for _, val := range pipeline {
+		res.Stages = append(res.Stages, string(val))
+	}
+	for _, val := range pipeline.Statuses() {
+		res.Statuses = append(res.Statuses, string(val))
+	}
+	return &res
+}
+
+func resNotes(notes []entity.AdmissionNote) []entity.ResAdmissionNote {
+	res := []entity.ResAdmissionNote{}
+	for _, val := range notes {
+		res = append(res, entity.ResAdmissionNote{Id: int(val.ID), Note: val.Note, Internal: val.Internal, AuthorID: int(val.UserID), CreatedAt: val.CreatedAt})
+	}
+	return res
+}
+
+// locate geocodes an address, a failing lookup only leaves the coordinate empty so it never blocks the request.
+func (s *school) locate(ctx context.Context, address string) *pkg.Coordinate {
+	if s.dep.Geocoder == nil || address == "" {
+		return nil
+	}
+	point, err := s.dep.Geocoder.Geocode(ctx, address)
+	if err != nil {
+		s.dep.Log.Errorf("[ERROR]WHEN GEOCODING ADDRESS %q, Err : %v", address, err)
+		return nil
+	}
+	return point
+}
+
+func (s *school) GetZonasiRanking(ctx context.Context, uid int, track string) ([]entity.ResZonasiRank, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	if schooldata.Latitude == nil || schooldata.Longitude == nil {
+		s.dep.PromErr["error"] = "School location is not set"
+		return nil, errorr.NewBad("School location is not set")
+	}
+	applicants, err := s.repo.GetApplicants(s.dep.Db.WithContext(ctx), int(schooldata.ID), track)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return admission.RankByDistance(pkg.Coordinate{Lat: *schooldata.Latitude, Lng: *schooldata.Longitude}, applicants), nil
+}
+
+func (s *school) GetSelectionCriteria(ctx context.Context, uid int) ([]entity.ResSelectionCriterion, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	return s.resCriteria(ctx, int(schooldata.ID))
+}
+
+func (s *school) UpdateSelectionCriteria(ctx context.Context, uid int, req entity.ReqUpdateSelection) ([]entity.ResSelectionCriterion, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE UPDATE SELECTION CRITERIA REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	criteria := []entity.SelectionCriterion{}
+	seen := map[string]bool{}
+	for _, val := range req.Criteria {
+		if seen[val.Criterion] {
+			s.dep.PromErr["error"] = "Duplicate selection criterion"
+			return nil, errorr.NewBad("Selection criterion " + val.Criterion + " is defined more than once")
+		}
+		seen[val.Criterion] = true
+		criteria = append(criteria, entity.SelectionCriterion{SchoolID: schooldata.ID, Criterion: val.Criterion, Weight: val.Weight})
+	}
+	before, err := s.repo.GetSelectionCriteria(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if err := s.repo.UpdateSelectionCriteria(s.dep.Db.WithContext(ctx), int(schooldata.ID), criteria); err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	audit.Track(ctx, audit.Change{Type: "selection_criteria", ID: schooldata.ID, Before: before, After: criteria})
+	return s.resCriteria(ctx, int(schooldata.ID))
+}
+
+func (s *school) resCriteria(ctx context.Context, schid int) ([]entity.ResSelectionCriterion, error) {
+	data, err := s.repo.GetSelectionCriteria(s.dep.Db.WithContext(ctx), schid)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res := []entity.ResSelectionCriterion{}
+	for _, val := range data {
+		res = append(res, entity.ResSelectionCriterion{Criterion: val.Criterion, Weight: val.Weight})
+	}
+	return res, nil
+}
+
+func (s *school) GetSelection(ctx context.Context, uid int) ([]entity.ResSelectionRank, error) {
+	_, _, res, err := s.selection(ctx, uid, authz.ViewAdmission)
+	return res, err
+}
+
+func (s *school) ExportSelection(ctx context.Context, uid int) ([]byte, error) {
+	_, criteria, ranks, err := s.selection(ctx, uid, authz.ViewAdmission)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	header := []string{"rank", "progress_id", "submission_id", "student_name", "progress_status"}
+	for _, val := range criteria {
+		header = append(header, val.Criterion)
+	}
+	writer.Write(append(header, "score"))
+	for _, val := range ranks {
+		row := []string{strconv.Itoa(val.Rank), strconv.Itoa(val.ProgressId), strconv.Itoa(val.SubmissionId), csvText(val.StudentName), val.Status}
+		for _, crit := range criteria {
+			row = append(row, strconv.FormatFloat(val.Scores[crit.Criterion], 'f', 2, 64))
+		}
+		writer.Write(append(row, strconv.FormatFloat(val.Score, 'f', 2, 64)))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN WRITING SELECTION CSV, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return buf.Bytes(), nil
+}
+
+// AdvanceSelection moves the best ranked applicants that are not yet at the requested status.
+// csvText keeps a text typed by an applicant from being read as a formula by a spreadsheet.
+func csvText(val string) string {
+	if val != "" && strings.ContainsAny(val[:1], "=+-@\t\r") {
+		return "'" + val
+	}
+	return val
+}
+
+func (s *school) AdvanceSelection(ctx context.Context, uid int, req entity.ReqAdvanceSelection) ([]entity.ResBulkProgress, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE ADVANCE SELECTION REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	schooldata, _, ranks, err := s.selection(ctx, uid, authz.ManageAdmission)
+	if err != nil {
+		return nil, err
+	}
+	status := bulkActions[req.Action]
+	ids := []int{}
+	for _, val := range ranks {
+		if len(ids) == req.Top {
+			break
+		}
+		if val.Status != string(status) {
+			ids = append(ids, val.ProgressId)
+		}
+	}
+	if len(ids) == 0 {
+		s.dep.PromErr["error"] = "No applicant to advance"
+		return nil, errorr.NewBad("No applicant to advance")
+	}
+	results := s.workflow.UpdateProgresses(ctx, int(schooldata.ID), ids, admission.Change{
+		Actor:  uid,
+		Status: status,
+		Reason: "Selection ranking",
+		Note:   req.Note,
+	})
+	return s.resBulk(results), nil
+}
+
+// selection scores every running applicant of the school of an admin with the criteria set by the school.
+// selection ranks the active participants of the school the user works at, the school is returned
+// along so the caller does not authorize the user again.
+func (s *school) selection(ctx context.Context, uid int, perm authz.Action) (*entity.School, []entity.SelectionCriterion, []entity.ResSelectionRank, error) {
+	schooldata, err := s.authz.Can(ctx, uid, perm, authz.MySchool)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	data, err := s.repo.GetAllProgressAndSubmission(s.dep.Db.WithContext(ctx), int(schooldata.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, nil, nil, err
+	}
+	criteria, err := s.repo.GetSelectionCriteria(s.dep.Db.WithContext(ctx), int(data.ID))
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, nil, nil, err
+	}
+	if len(criteria) == 0 {
+		s.dep.PromErr["error"] = "Selection criteria have not been set"
+		return nil, nil, nil, errorr.NewBad("Selection criteria have not been set")
+	}
+	scores := map[string]float64{}
+	for _, val := range criteria {
+		if admission.Criterion(val.Criterion) != admission.CriterionTestScore || data.QuizLinkResult == "" {
+			continue
+		}
+		results, err := s.dep.Quiz.GetResult(data.QuizLinkResult, s.dep.Log)
+		if err != nil {
+			s.dep.PromErr["error"] = err.Error()
+			return nil, nil, nil, err
+		}
+		for _, res := range results {
+			if score, ok := admission.ParseTestScore(res.Result); ok {
+				scores[strings.ToLower(strings.TrimSpace(res.Email))] = score
+			}
+		}
+	}
+	submissions := map[uint]entity.Submission{}
+	for _, val := range data.Submissions {
+		if val.ID > submissions[val.UserID].ID {
+			submissions[val.UserID] = val
+		}
+	}
+	candidates := []admission.Candidate{}
+	for _, val := range data.Progresses {
+		subm, ok := submissions[val.UserID]
+		if !ok || admission.IsClosed(admission.Status(val.Status)) {
+			continue
+		}
+		candidate := admission.Candidate{
+			ProgressID:    int(val.ID),
+			SubmissionID:  int(subm.ID),
+			UserID:        int(val.UserID),
+			Name:          subm.StudentName,
+			Status:        val.Status,
+			ReportAverage: subm.ReportAverage,
+			Achievements:  subm.Achievements,
+		}
+		if score, ok := scores[strings.ToLower(val.User.Email)]; ok {
+			candidate.TestScore = &score
+		}
+		if data.Latitude != nil && data.Longitude != nil && subm.StudentLatitude != nil && subm.StudentLongitude != nil {
+			distance := pkg.Distance(pkg.Coordinate{Lat: *data.Latitude, Lng: *data.Longitude}, pkg.Coordinate{Lat: *subm.StudentLatitude, Lng: *subm.StudentLongitude})
+			candidate.Distance = &distance
+		}
+		candidates = append(candidates, candidate)
+	}
+	return schooldata, criteria, admission.Score(criteria, candidates), nil
+}
+
+func (s *school) CreateAppeal(ctx context.Context, uid int, req entity.ReqCreateAppeal, files []multipart.File) (int, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE CREATE APPEAL REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return 0, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	if len(req.Attachments) != len(files) {
+		s.dep.PromErr["error"] = "attachment names do not match the files"
+		return 0, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	attachments := []string{}
+	for i, name := range req.Attachments {
+		filename, err := appealFile(uid, req.ProgressId, i, name)
+		if err != nil {
+			s.dep.PromErr["error"] = err.Error()
+			return 0, err
+		}
+		attachments = append(attachments, filename)
+	}
+	for i, file := range files {
+		if err := s.dep.Storage.UploadFile(file, attachments[i]); err != nil {
+			s.dep.Log.Errorf("Error Service : %v", err)
+			s.dep.PromErr["error"] = err.Error()
+			s.deleteFiles(attachments[:i])
+			return 0, err
+		}
+	}
+	encoded, _ := json.Marshal(attachments)
+	res, err := s.workflow.Appeal(ctx, entity.Appeal{ProgressID: uint(req.ProgressId), UserID: uint(uid), Justification: req.Justification, Attachments: string(encoded)})
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		s.deleteFiles(attachments)
+		return 0, err
+	}
+	return int(res.ID), nil
+}
+
+// appealFile names the stored attachment of an appeal, only images and pdf are accepted.
+func appealFile(uid int, progid int, i int, name string) (string, error) {
+	name = filepath.Base(name)
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".jpg", ".jpeg", ".png", ".pdf":
+	default:
+		return "", errorr.NewBad("Attachment must be an image or a pdf")
+	}
+	return fmt.Sprintf("Appeal_%d_%d_%d_%s", uid, progid, i+1, name), nil
+}
+
+// deleteFiles removes the files uploaded for a request that failed afterwards.
+func (s *school) deleteFiles(names []string) {
+	for _, name := range names {
+		if err := s.dep.Storage.DeleteFile(name); err != nil {
+			s.dep.Log.Errorf("[ERROR]WHEN DELETING FILE %s, Err : %v", name, err)
+		}
+	}
+}
+
+func (s *school) GetAppealsByUid(ctx context.Context, uid int) ([]entity.ResAppeal, error) {
+	data, err := s.repo.GetAppealsByUid(s.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return resAppeals(data), nil
+}
+
+func (s *school) GetAppeals(ctx context.Context, uid int, status string) ([]entity.ResAppeal, error) {
+	schooldata, err := s.authz.Can(ctx, uid, authz.ViewAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.repo.GetAppealsBySchool(s.dep.Db.WithContext(ctx), int(schooldata.ID), status)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return resAppeals(data), nil
+}
+
+func (s *school) DecideAppeal(ctx context.Context, id int, uid int, req entity.ReqDecideAppeal) (*entity.ResAppeal, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE DECIDE APPEAL REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	if req.Decision == "accept" && req.ProgressStatus == "" {
+		s.dep.PromErr["error"] = "progress status is missing"
+		return nil, errorr.NewBad("Progress status is required to accept an appeal")
+	}
+	schooldata, err := s.authz.Can(ctx, uid, authz.ManageAdmission, authz.MySchool)
+	if err != nil {
+		return nil, err
+	}
+	before, err := s.repo.GetAppealById(s.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res, err := s.workflow.DecideAppeal(ctx, int(schooldata.ID), id, admission.Decision{
+		Actor:  uid,
+		Accept: req.Decision == "accept",
+		Status: admission.Status(req.ProgressStatus),
+		Reason: req.Reason,
+	})
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	audit.Track(ctx, audit.Change{Type: "appeal", ID: id, Before: before, After: res})
+	return &resAppeals([]entity.Appeal{*res})[0], nil
+}
+
+func resAppeals(data []entity.Appeal) []entity.ResAppeal {
+	res := []entity.ResAppeal{}
+	for _, val := range data {
+		attachments := []string{}
+		json.Unmarshal([]byte(val.Attachments), &attachments)
+		res = append(res, entity.ResAppeal{
+			Id:            int(val.ID),
+			ProgressId:    int(val.ProgressID),
+			UserId:        int(val.UserID),
+			FromStatus:    val.FromStatus,
+			Justification: val.Justification,
+			Attachments:   attachments,
+			Status:        val.Status,
+			ReopenStatus:  val.ReopenStatus,
+			Response:      val.Response,
+			CreatedAt:     val.CreatedAt,
+			DecidedAt:     val.DecidedAt,
+		})
+	}
+	return res
+}
+
+var offerDecisions = map[string]admission.Status{
+	"accept":  admission.OfferAccepted,
+	"decline": admission.OfferDeclined,
+}
+
+func (s *school) RespondOffer(ctx context.Context, id int, uid int, req entity.ReqRespondOffer) (int, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE RESPOND OFFER REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return 0, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	return s.respond(ctx, id, uid, offerDecisions[req.Decision], req.Reason)
+}
+
+func (s *school) Withdraw(ctx context.Context, id int, uid int, req entity.ReqWithdraw) (int, error) {
+	if err := s.validator.Struct(req); err != nil {
+		s.dep.Log.Errorf("[ERROR] WHEN VALIDATE WITHDRAW REQ, Error: %v", err)
+		s.dep.PromErr["error"] = err.Error()
+		return 0, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	return s.respond(ctx, id, uid, admission.Withdrawn, req.Reason)
+}
+
+func (s *school) respond(ctx context.Context, id int, uid int, status admission.Status, reason string) (int, error) {
+	res, err := s.workflow.Respond(ctx, uid, id, status, reason)
+	if err != nil {
+		s.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
+	return int(res.ID), nil
+}
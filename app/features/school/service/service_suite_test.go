@@ -2,19 +2,30 @@ package service_test
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/csv"
 	"errors"
+	"image"
+	"image/png"
 	"io"
 	"mime/multipart"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/education-hub/BE/app/admission"
+	mocksw "github.com/education-hub/BE/app/admission/mocks"
+	"github.com/education-hub/BE/app/audit"
+	mocksa "github.com/education-hub/BE/app/audit/mocks"
+	"github.com/education-hub/BE/app/authz"
 	entity "github.com/education-hub/BE/app/entities"
 	mocks "github.com/education-hub/BE/app/features/school/mocks/repository"
 	school "github.com/education-hub/BE/app/features/school/service"
 	mocksu "github.com/education-hub/BE/app/features/user/mocks/repository"
 	"github.com/education-hub/BE/config"
 	dependcy "github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
 	"github.com/education-hub/BE/pkg"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -52,9 +63,17 @@ func TestService(t *testing.T) {
 var _ = Describe("school", func() {
 	var Mock *mocks.SchoolRepo
 	var Mocks *mocksu.UserRepo
+	var Workflow *mocksw.Workflow
+	var Audit *mocksa.Log
 	var SchoolService school.SchoolService
 	var Depend dependcy.Depend
 	var ctx context.Context
+	// asMember lets the admin of the spec act at the school with the role.
+	asMember := func(role string, schid uint) {
+		member := entity.SchoolMember{SchoolID: schid, Role: role}
+		member.School.ID = schid
+		Mock.On("GetMember", mock.Anything, mock.Anything).Return(&member, nil).Once()
+	}
 	var reqsub = entity.ReqCreateSubmission{
 		UserID:           1,
 		SchoolID:         123,
@@ -93,7 +112,9 @@ var _ = Describe("school", func() {
 		ctx = context.Background()
 		Mock = mocks.NewSchoolRepo(GinkgoT())
 		Mocks = mocksu.NewUserRepo(GinkgoT())
-		SchoolService = school.NewSchoolService(Mock, Depend, Mocks)
+		Workflow = mocksw.NewWorkflow(GinkgoT())
+		Audit = mocksa.NewLog(GinkgoT())
+		SchoolService = school.NewSchoolService(Mock, Depend, Mocks, Workflow, authz.NewAuthorizer(Mock, Depend), Audit)
 		Depend.Config = &config.Config{GmapsKey: os.Getenv("GMAPS")}
 		Depend.PromErr = make(map[string]string, 1)
 		Depend.Validation = NewValidation()
@@ -281,7 +302,7 @@ var _ = Describe("school", func() {
 				var pdf multipart.File
 				image = os.NewFile(uintptr(2), "2")
 				pdf = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.Update(ctx, entity.ReqUpdateSchool{}, image, pdf)
+				_, err := SchoolService.Update(ctx, 1, entity.ReqUpdateSchool{}, image, pdf)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Missing Or Invalid Request Body"))
 			})
@@ -289,6 +310,7 @@ var _ = Describe("school", func() {
 
 		When("Npsn sudah terdaftar pada database", func() {
 			BeforeEach(func() {
+				asMember("owner", 1)
 				Mock.On("FindByNPSN", mock.Anything, mock.Anything).Return(nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
@@ -303,13 +325,14 @@ var _ = Describe("school", func() {
 					Image:         "animal3.jpg",
 					Pdf:           "motivasion letter.pdf",
 					Accreditation: "A"}
-				_, err := SchoolService.Update(ctx, req, image, pdf)
+				_, err := SchoolService.Update(ctx, 1, req, image, pdf)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("School Already Registered"))
 			})
 		})
 		When("Npsn tidak terdaftar pada data kementrian pendidikan", func() {
 			BeforeEach(func() {
+				asMember("owner", 1)
 				Mock.On("FindByNPSN", mock.Anything, mock.Anything).Return(errors.New("error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
@@ -324,13 +347,14 @@ var _ = Describe("school", func() {
 					Image:         "animal3.jpg",
 					Pdf:           "motivasion letter.pdf",
 					Accreditation: "A"}
-				_, err := SchoolService.Update(ctx, req, image, pdf)
+				_, err := SchoolService.Update(ctx, 1, req, image, pdf)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("NPSN not registered"))
 			})
 		})
 		When("Format gambar tidak sesuai", func() {
 			BeforeEach(func() {
+				asMember("owner", 1)
 				Mock.On("FindByNPSN", mock.Anything, mock.Anything).Return(errors.New("error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
@@ -345,13 +369,14 @@ var _ = Describe("school", func() {
 					Image:         "animal3.php",
 					Pdf:           "motivasion letter.pdf",
 					Accreditation: "A"}
-				_, err := SchoolService.Update(ctx, req, image, pdf)
+				_, err := SchoolService.Update(ctx, 1, req, image, pdf)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("File type not allowed"))
 			})
 		})
 		When("Format pdf tidak sesuai", func() {
 			BeforeEach(func() {
+				asMember("owner", 1)
 				Mock.On("FindByNPSN", mock.Anything, mock.Anything).Return(errors.New("error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
@@ -366,13 +391,14 @@ var _ = Describe("school", func() {
 					Image:         "animal3.jpg",
 					Pdf:           "brochure.php",
 					Accreditation: "A"}
-				_, err := SchoolService.Update(ctx, req, image, pdf)
+				_, err := SchoolService.Update(ctx, 1, req, image, pdf)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("File type not allowed"))
 			})
 		})
 		When("Terjadi kesalahn qury database", func() {
 			BeforeEach(func() {
+				asMember("owner", 1)
 				Mock.On("FindByNPSN", mock.Anything, mock.Anything).Return(errors.New("error")).Once()
 				Mock.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
@@ -388,7 +414,7 @@ var _ = Describe("school", func() {
 					Image:         "animal3.jpg",
 					Pdf:           "brochure.pdf",
 					Accreditation: "A"}
-				_, err := SchoolService.Update(ctx, req, image, pdf)
+				_, err := SchoolService.Update(ctx, 1, req, image, pdf)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
@@ -402,6 +428,7 @@ var _ = Describe("school", func() {
 					Pdf:           "brochure.php",
 					Accreditation: "A"}
 				res.ID = uint(1)
+				asMember("owner", 1)
 				Mock.On("FindByNPSN", mock.Anything, mock.Anything).Return(errors.New("error")).Once()
 				Mock.On("Update", mock.Anything, mock.Anything).Return(&res, nil).Once()
 			})
@@ -417,7 +444,7 @@ var _ = Describe("school", func() {
 					Image:         "animal3.jpg",
 					Pdf:           "brochure.pdf",
 					Accreditation: "A"}
-				res, err := SchoolService.Update(ctx, req, image, pdf)
+				res, err := SchoolService.Update(ctx, 1, req, image, pdf)
 				Expect(err).Should(BeNil())
 				Expect(res.Npsn).To(Equal("20100251"))
 			})
@@ -442,7 +469,7 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.AddAchievement(ctx, entity.ReqAddAchievemnt{}, image)
+				_, err := SchoolService.AddAchievement(ctx, 1, entity.ReqAddAchievemnt{}, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
@@ -450,8 +477,9 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
+				asMember("content", 1)
 				req := entity.ReqAddAchievemnt{SchoolID: 1, Description: "test", Image: "gambar.php", Title: "tes"}
-				_, err := SchoolService.AddAchievement(ctx, req, image)
+				_, err := SchoolService.AddAchievement(ctx, 1, req, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
@@ -460,34 +488,37 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
+				asMember("content", 1)
 				req := entity.ReqAddAchievemnt{SchoolID: 1, Description: "test", Image: "gambar.php", Title: "tes"}
-				_, err := SchoolService.AddAchievement(ctx, req, image)
+				_, err := SchoolService.AddAchievement(ctx, 1, req, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 
 		When("Terjadi Kesalahan Query Database", func() {
 			BeforeEach(func() {
-				Mock.On("AddAchievement", mock.Anything, mock.Anything).Return(0, errors.New("Internal Server Error")).Once()
+				Mock.On("AddAchievement", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
+				asMember("content", 1)
 				req := entity.ReqAddAchievemnt{SchoolID: 1, Description: "test", Image: "gambar.jpg", Title: "tes"}
-				_, err := SchoolService.AddAchievement(ctx, req, image)
+				_, err := SchoolService.AddAchievement(ctx, 1, req, image)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil Menambahakan Prestasi", func() {
 			BeforeEach(func() {
-				Mock.On("AddAchievement", mock.Anything, mock.Anything).Return(1, nil).Once()
+				Mock.On("AddAchievement", mock.Anything, mock.Anything).Return(&entity.Achievement{SchoolID: 1}, nil).Once()
 			})
 			It("Akan Mengembalikan Id Sekolah", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
+				asMember("content", 1)
 				req := entity.ReqAddAchievemnt{SchoolID: 1, Description: "test", Image: "gambar.jpg", Title: "tes"}
-				res, err := SchoolService.AddAchievement(ctx, req, image)
+				res, err := SchoolService.AddAchievement(ctx, 1, req, image)
 				Expect(err).Should(BeNil())
 				Expect(res).To(Equal(1))
 			})
@@ -500,42 +531,51 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.UpdateAchievement(ctx, entity.ReqUpdateAchievemnt{}, image)
+				_, err := SchoolService.UpdateAchievement(ctx, 1, entity.ReqUpdateAchievemnt{}, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 		When("Format gambar tidak sesuai", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Achievement{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Achievement{}, mock.Anything).Return(nil).Once()
 				Mock.On("UpdateAchievement", mock.Anything, mock.Anything).Return(&entity.Achievement{}, nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.UpdateAchievement(ctx, entity.ReqUpdateAchievemnt{Id: 1, Image: "backdoor.aspx"}, image)
+				_, err := SchoolService.UpdateAchievement(ctx, 1, entity.ReqUpdateAchievemnt{Id: 1, Image: "backdoor.aspx"}, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 
 		When("Terjadi Kesalahn Query Database", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Achievement{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Achievement{}, mock.Anything).Return(nil).Once()
 				Mock.On("UpdateAchievement", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.UpdateAchievement(ctx, entity.ReqUpdateAchievemnt{Id: 1, Image: "img.jpg"}, image)
+				_, err := SchoolService.UpdateAchievement(ctx, 1, entity.ReqUpdateAchievemnt{Id: 1, Image: "img.jpg"}, image)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil memperbahrui data achievement", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Achievement{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Achievement{}, mock.Anything).Return(nil).Once()
 				Mock.On("UpdateAchievement", mock.Anything, mock.Anything).Return(&entity.Achievement{SchoolID: 1}, nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				res, err := SchoolService.UpdateAchievement(ctx, entity.ReqUpdateAchievemnt{Id: 1, Image: "img.jpg"}, image)
+				res, err := SchoolService.UpdateAchievement(ctx, 1, entity.ReqUpdateAchievemnt{Id: 1, Image: "img.jpg"}, image)
 				Expect(err).Should(BeNil())
 				Expect(res).To(Equal(1))
 			})
@@ -545,30 +585,46 @@ var _ = Describe("school", func() {
 	Context("Delete Achievement", func() {
 		When("Id tidak ditemukan", func() {
 			BeforeEach(func() {
-				Mock.On("DeleteAchievement", mock.Anything, mock.Anything).Return(errors.New("Id not found")).Once()
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Achievement{}, 9999).Return(0, errors.New("Id not found")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeleteAchievement(ctx, 9999)
+				err := SchoolService.DeleteAchievement(ctx, 9999, 1)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Id not found"))
 			})
 		})
+		When("Milik Sekolah Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Achievement{}, 1).Return(1, nil).Once()
+				asMember("owner", 2)
+			})
+			It("Akan Mengembalikan Error Forbidden", func() {
+				err := SchoolService.DeleteAchievement(ctx, 1, 1)
+				Expect(err).To(Equal(authz.ErrForbidden))
+			})
+		})
 		When("Terjadi kesalahan query database", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Achievement{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Achievement{}, mock.Anything).Return(nil).Once()
 				Mock.On("DeleteAchievement", mock.Anything, mock.Anything).Return(errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeleteAchievement(ctx, 1)
+				err := SchoolService.DeleteAchievement(ctx, 1, 1)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil Menghapus data achievement", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Achievement{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Achievement{}, mock.Anything).Return(nil).Once()
 				Mock.On("DeleteAchievement", mock.Anything, mock.Anything).Return(nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeleteAchievement(ctx, 1)
+				err := SchoolService.DeleteAchievement(ctx, 1, 1)
 				Expect(err).Should(BeNil())
 			})
 		})
@@ -579,7 +635,7 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.AddExtracurricular(ctx, entity.ReqAddExtracurricular{}, image)
+				_, err := SchoolService.AddExtracurricular(ctx, 1, entity.ReqAddExtracurricular{}, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
@@ -587,8 +643,9 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
+				asMember("content", 1)
 				req := entity.ReqAddExtracurricular{SchoolID: 1, Description: "test", Image: "gambar.php", Title: "tes"}
-				_, err := SchoolService.AddExtracurricular(ctx, req, image)
+				_, err := SchoolService.AddExtracurricular(ctx, 1, req, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
@@ -597,34 +654,37 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
+				asMember("content", 1)
 				req := entity.ReqAddExtracurricular{SchoolID: 1, Description: "test", Image: "gambar.php", Title: "tes"}
-				_, err := SchoolService.AddExtracurricular(ctx, req, image)
+				_, err := SchoolService.AddExtracurricular(ctx, 1, req, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 
 		When("Terjadi Kesalahan Query Database", func() {
 			BeforeEach(func() {
-				Mock.On("AddExtracurricular", mock.Anything, mock.Anything).Return(0, errors.New("Internal Server Error")).Once()
+				Mock.On("AddExtracurricular", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
+				asMember("content", 1)
 				req := entity.ReqAddExtracurricular{SchoolID: 1, Description: "test", Image: "gambar.jpg", Title: "tes"}
-				_, err := SchoolService.AddExtracurricular(ctx, req, image)
+				_, err := SchoolService.AddExtracurricular(ctx, 1, req, image)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil Menambahakan Prestasi", func() {
 			BeforeEach(func() {
-				Mock.On("AddExtracurricular", mock.Anything, mock.Anything).Return(1, nil).Once()
+				Mock.On("AddExtracurricular", mock.Anything, mock.Anything).Return(&entity.Extracurricular{SchoolID: 1}, nil).Once()
 			})
 			It("Akan Mengembalikan Id Sekolah", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
+				asMember("content", 1)
 				req := entity.ReqAddExtracurricular{SchoolID: 1, Description: "test", Image: "gambar.jpg", Title: "tes"}
-				res, err := SchoolService.AddExtracurricular(ctx, req, image)
+				res, err := SchoolService.AddExtracurricular(ctx, 1, req, image)
 				Expect(err).Should(BeNil())
 				Expect(res).To(Equal(1))
 			})
@@ -637,42 +697,51 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.UpdateExtracurricular(ctx, entity.ReqUpdateExtracurricular{}, image)
+				_, err := SchoolService.UpdateExtracurricular(ctx, 1, entity.ReqUpdateExtracurricular{}, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 		When("Format gambar tidak sesuai", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Extracurricular{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Extracurricular{}, mock.Anything).Return(nil).Once()
 				Mock.On("UpdateExtracurricular", mock.Anything, mock.Anything).Return(&entity.Extracurricular{}, nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.UpdateExtracurricular(ctx, entity.ReqUpdateExtracurricular{Id: 1, Image: "backdoor.aspx"}, image)
+				_, err := SchoolService.UpdateExtracurricular(ctx, 1, entity.ReqUpdateExtracurricular{Id: 1, Image: "backdoor.aspx"}, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 
 		When("Terjadi Kesalahn Query Database", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Extracurricular{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Extracurricular{}, mock.Anything).Return(nil).Once()
 				Mock.On("UpdateExtracurricular", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.UpdateExtracurricular(ctx, entity.ReqUpdateExtracurricular{Id: 1, Image: "img.jpg"}, image)
+				_, err := SchoolService.UpdateExtracurricular(ctx, 1, entity.ReqUpdateExtracurricular{Id: 1, Image: "img.jpg"}, image)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil memperbahrui data Extracurricular", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Extracurricular{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Extracurricular{}, mock.Anything).Return(nil).Once()
 				Mock.On("UpdateExtracurricular", mock.Anything, mock.Anything).Return(&entity.Extracurricular{SchoolID: 1}, nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				res, err := SchoolService.UpdateExtracurricular(ctx, entity.ReqUpdateExtracurricular{Id: 1, Image: "img.jpg"}, image)
+				res, err := SchoolService.UpdateExtracurricular(ctx, 1, entity.ReqUpdateExtracurricular{Id: 1, Image: "img.jpg"}, image)
 				Expect(err).Should(BeNil())
 				Expect(res).To(Equal(1))
 			})
@@ -682,30 +751,36 @@ var _ = Describe("school", func() {
 	Context("Delete Extracurricular", func() {
 		When("Id tidak ditemukan", func() {
 			BeforeEach(func() {
-				Mock.On("DeleteExtracurricular", mock.Anything, mock.Anything).Return(errors.New("Id not found")).Once()
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Extracurricular{}, 9999).Return(0, errors.New("Id not found")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeleteExtracurricular(ctx, 9999)
+				err := SchoolService.DeleteExtracurricular(ctx, 9999, 1)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Id not found"))
 			})
 		})
 		When("Terjadi kesalahan query database", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Extracurricular{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Extracurricular{}, mock.Anything).Return(nil).Once()
 				Mock.On("DeleteExtracurricular", mock.Anything, mock.Anything).Return(errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeleteExtracurricular(ctx, 1)
+				err := SchoolService.DeleteExtracurricular(ctx, 1, 1)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil Menghapus data Extracurricular", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Extracurricular{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Extracurricular{}, mock.Anything).Return(nil).Once()
 				Mock.On("DeleteExtracurricular", mock.Anything, mock.Anything).Return(nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeleteExtracurricular(ctx, 1)
+				err := SchoolService.DeleteExtracurricular(ctx, 1, 1)
 				Expect(err).Should(BeNil())
 			})
 		})
@@ -714,30 +789,32 @@ var _ = Describe("school", func() {
 	Context("Add Faq", func() {
 		When("Request Body kosong", func() {
 			It("Akan Mengembalikan Erorr", func() {
-				_, err := SchoolService.AddFaq(ctx, entity.ReqAddFaq{})
+				_, err := SchoolService.AddFaq(ctx, 1, entity.ReqAddFaq{})
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 
 		When("Terjadi Kesalahan Query Database", func() {
 			BeforeEach(func() {
-				Mock.On("AddFaq", mock.Anything, mock.Anything).Return(0, errors.New("Internal Server Error")).Once()
+				Mock.On("AddFaq", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 
+				asMember("content", 1)
 				req := entity.ReqAddFaq{SchoolId: 1, Question: "test", Answer: "tes"}
-				_, err := SchoolService.AddFaq(ctx, req)
+				_, err := SchoolService.AddFaq(ctx, 1, req)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil Menambahakan Faq", func() {
 			BeforeEach(func() {
-				Mock.On("AddFaq", mock.Anything, mock.Anything).Return(1, nil).Once()
+				Mock.On("AddFaq", mock.Anything, mock.Anything).Return(&entity.Faq{SchoolID: 1}, nil).Once()
 			})
 			It("Akan Mengembalikan Id Sekolah", func() {
+				asMember("content", 1)
 				req := entity.ReqAddFaq{SchoolId: 1, Question: "test", Answer: "tes"}
-				res, err := SchoolService.AddFaq(ctx, req)
+				res, err := SchoolService.AddFaq(ctx, 1, req)
 				Expect(err).Should(BeNil())
 				Expect(res).To(Equal(1))
 			})
@@ -749,28 +826,34 @@ var _ = Describe("school", func() {
 		When("id tidak ada", func() {
 			It("Akan Mengembalikan Erorr", func() {
 
-				_, err := SchoolService.UpdateFaq(ctx, entity.ReqUpdateFaq{})
+				_, err := SchoolService.UpdateFaq(ctx, 1, entity.ReqUpdateFaq{})
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 		When("Terjadi Kesalahn Query Database", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Faq{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Faq{}, mock.Anything).Return(nil).Once()
 				Mock.On("UpdateFaq", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 
-				_, err := SchoolService.UpdateFaq(ctx, entity.ReqUpdateFaq{Id: 1, Question: "tes"})
+				_, err := SchoolService.UpdateFaq(ctx, 1, entity.ReqUpdateFaq{Id: 1, Question: "tes"})
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil memperbahrui data Faq", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Faq{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Faq{}, mock.Anything).Return(nil).Once()
 				Mock.On("UpdateFaq", mock.Anything, mock.Anything).Return(&entity.Faq{SchoolID: 1}, nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 
-				res, err := SchoolService.UpdateFaq(ctx, entity.ReqUpdateFaq{Id: 1, Question: "tes"})
+				res, err := SchoolService.UpdateFaq(ctx, 1, entity.ReqUpdateFaq{Id: 1, Question: "tes"})
 				Expect(err).Should(BeNil())
 				Expect(res).To(Equal(1))
 			})
@@ -780,30 +863,46 @@ var _ = Describe("school", func() {
 	Context("Delete Faq", func() {
 		When("Id tidak ditemukan", func() {
 			BeforeEach(func() {
-				Mock.On("DeleteFaq", mock.Anything, mock.Anything).Return(errors.New("Id not found")).Once()
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Faq{}, 9999).Return(0, errors.New("Id not found")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeleteFaq(ctx, 9999)
+				err := SchoolService.DeleteFaq(ctx, 9999, 1)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Id not found"))
 			})
 		})
+		When("Milik Sekolah Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Faq{}, 1).Return(1, nil).Once()
+				asMember("owner", 2)
+			})
+			It("Akan Mengembalikan Error Forbidden", func() {
+				err := SchoolService.DeleteFaq(ctx, 1, 1)
+				Expect(err).To(Equal(authz.ErrForbidden))
+			})
+		})
 		When("Terjadi kesalahan query database", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Faq{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Faq{}, mock.Anything).Return(nil).Once()
 				Mock.On("DeleteFaq", mock.Anything, mock.Anything).Return(errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeleteFaq(ctx, 1)
+				err := SchoolService.DeleteFaq(ctx, 1, 1)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil Menghapus data Faq", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Faq{}, 1).Return(1, nil).Once()
+				asMember("content", 1)
+				Mock.On("GetItemById", mock.Anything, &entity.Faq{}, mock.Anything).Return(nil).Once()
 				Mock.On("DeleteFaq", mock.Anything, mock.Anything).Return(nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeleteFaq(ctx, 1)
+				err := SchoolService.DeleteFaq(ctx, 1, 1)
 				Expect(err).Should(BeNil())
 			})
 		})
@@ -814,7 +913,7 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.AddPayment(ctx, entity.ReqAddPayment{}, image)
+				_, err := SchoolService.AddPayment(ctx, 1, entity.ReqAddPayment{}, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
@@ -823,8 +922,9 @@ var _ = Describe("school", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
 				interval := 0
+				asMember("finance", 1)
 				req := entity.ReqAddPayment{SchoolID: 1, Description: "test", Image: "gambar.php", Price: 20000, Interval: &interval}
-				_, err := SchoolService.AddPayment(ctx, req, image)
+				_, err := SchoolService.AddPayment(ctx, 1, req, image)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("File type not allowed"))
 			})
@@ -832,28 +932,30 @@ var _ = Describe("school", func() {
 
 		When("Terjadi Kesalahan Query Database", func() {
 			BeforeEach(func() {
-				Mock.On("AddPayment", mock.Anything, mock.Anything).Return(0, errors.New("Internal Server Error")).Once()
+				Mock.On("AddPayment", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
 				interval := 0
+				asMember("finance", 1)
 				req := entity.ReqAddPayment{SchoolID: 1, Description: "test", Image: "gambar.jpg", Price: 20000, Interval: &interval}
-				_, err := SchoolService.AddPayment(ctx, req, image)
+				_, err := SchoolService.AddPayment(ctx, 1, req, image)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil Menambahakan Payment", func() {
 			BeforeEach(func() {
-				Mock.On("AddPayment", mock.Anything, mock.Anything).Return(1, nil).Once()
+				Mock.On("AddPayment", mock.Anything, mock.Anything).Return(&entity.Payment{SchoolID: 1}, nil).Once()
 			})
 			It("Akan Mengembalikan Id Sekolah", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
 				interval := 1
+				asMember("finance", 1)
 				req := entity.ReqAddPayment{SchoolID: 1, Description: "test", Image: "gambar.jpg", Price: 20000, Interval: &interval}
-				res, err := SchoolService.AddPayment(ctx, req, image)
+				res, err := SchoolService.AddPayment(ctx, 1, req, image)
 				Expect(err).Should(BeNil())
 				Expect(res).To(Equal(1))
 			})
@@ -866,45 +968,54 @@ var _ = Describe("school", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.UpdatePayment(ctx, entity.ReqUpdatePayment{}, image)
+				_, err := SchoolService.UpdatePayment(ctx, 1, entity.ReqUpdatePayment{}, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 		When("Format gambar tidak sesuai", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Payment{}, 1).Return(1, nil).Once()
+				asMember("finance", 1)
+				Mock.On("GetPaymentById", mock.Anything, 1).Return(&entity.Payment{SchoolID: 1, Price: 100000}, nil).Once()
 				Mock.On("UpdatePayment", mock.Anything, mock.Anything).Return(&entity.Payment{}, nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				interval := 1
 				image = os.NewFile(uintptr(2), "2")
-				_, err := SchoolService.UpdatePayment(ctx, entity.ReqUpdatePayment{ID: 1, Image: "backdoor.aspx", Interval: &interval}, image)
+				_, err := SchoolService.UpdatePayment(ctx, 1, entity.ReqUpdatePayment{ID: 1, Image: "backdoor.aspx", Interval: &interval}, image)
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 
 		When("Terjadi Kesalahn Query Database", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Payment{}, 1).Return(1, nil).Once()
+				asMember("finance", 1)
+				Mock.On("GetPaymentById", mock.Anything, 1).Return(&entity.Payment{SchoolID: 1, Price: 100000}, nil).Once()
 				Mock.On("UpdatePayment", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				image = os.NewFile(uintptr(2), "2")
 				interval := 0
-				_, err := SchoolService.UpdatePayment(ctx, entity.ReqUpdatePayment{ID: 1, Image: "backdoor.jpg", Interval: &interval}, image)
+				_, err := SchoolService.UpdatePayment(ctx, 1, entity.ReqUpdatePayment{ID: 1, Image: "backdoor.jpg", Interval: &interval}, image)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil memperbahrui data Payment", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Payment{}, 1).Return(1, nil).Once()
+				asMember("finance", 1)
+				Mock.On("GetPaymentById", mock.Anything, 1).Return(&entity.Payment{SchoolID: 1, Price: 100000}, nil).Once()
 				Mock.On("UpdatePayment", mock.Anything, mock.Anything).Return(&entity.Payment{SchoolID: 1}, nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				interval := 1
 				image = os.NewFile(uintptr(2), "2")
-				res, err := SchoolService.UpdatePayment(ctx, entity.ReqUpdatePayment{ID: 1, Image: "backdoor.jpg", Interval: &interval}, image)
+				res, err := SchoolService.UpdatePayment(ctx, 1, entity.ReqUpdatePayment{ID: 1, Image: "backdoor.jpg", Interval: &interval}, image)
 				Expect(err).Should(BeNil())
 				Expect(res).To(Equal(1))
 			})
@@ -914,30 +1025,46 @@ var _ = Describe("school", func() {
 	Context("Delete Payment", func() {
 		When("Id tidak ditemukan", func() {
 			BeforeEach(func() {
-				Mock.On("DeletePayment", mock.Anything, mock.Anything).Return(errors.New("Id not found")).Once()
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Payment{}, 9999).Return(0, errors.New("Id not found")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeletePayment(ctx, 9999)
+				err := SchoolService.DeletePayment(ctx, 9999, 1)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Id not found"))
 			})
 		})
+		When("Milik Sekolah Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Payment{}, 1).Return(1, nil).Once()
+				asMember("owner", 2)
+			})
+			It("Akan Mengembalikan Error Forbidden", func() {
+				err := SchoolService.DeletePayment(ctx, 1, 1)
+				Expect(err).To(Equal(authz.ErrForbidden))
+			})
+		})
 		When("Terjadi kesalahan query database", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Payment{}, 1).Return(1, nil).Once()
+				asMember("finance", 1)
+				Mock.On("GetPaymentById", mock.Anything, 1).Return(&entity.Payment{SchoolID: 1, Price: 100000}, nil).Once()
 				Mock.On("DeletePayment", mock.Anything, mock.Anything).Return(errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeletePayment(ctx, 1)
+				err := SchoolService.DeletePayment(ctx, 1, 1)
 				Expect(err).ShouldNot(BeNil())
 				Expect(err.Error()).To(Equal("Internal Server Error"))
 			})
 		})
 		When("Berhasil Menghapus data Payment", func() {
 			BeforeEach(func() {
+				Mock.On("GetSchoolIdOf", mock.Anything, &entity.Payment{}, 1).Return(1, nil).Once()
+				asMember("finance", 1)
+				Mock.On("GetPaymentById", mock.Anything, 1).Return(&entity.Payment{SchoolID: 1, Price: 100000}, nil).Once()
 				Mock.On("DeletePayment", mock.Anything, mock.Anything).Return(nil).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				err := SchoolService.DeletePayment(ctx, 1)
+				err := SchoolService.DeletePayment(ctx, 1, 1)
 				Expect(err).Should(BeNil())
 			})
 		})
@@ -958,7 +1085,47 @@ var _ = Describe("school", func() {
 			})
 		})
 
+		When("Pendaftaran Belum Dibuka", func() {
+			BeforeEach(func() {
+				Mock.On("GetOpenPeriod", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("Admission is not open at this school")).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				var image multipart.File
+				var sign1 multipart.File
+				var sign2 multipart.File
+				image = os.NewFile(uintptr(2), "2")
+				sign1 = os.NewFile(uintptr(2), "2")
+				sign2 = os.NewFile(uintptr(2), "2")
+				_, err := SchoolService.CreateSubmission(ctx, reqsub, image, sign1, sign2)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Admission is not open at this school"))
+			})
+		})
+		When("Jalur Pendaftaran Tidak Tersedia", func() {
+			BeforeEach(func() {
+				Mock.On("GetOpenPeriod", mock.Anything, mock.Anything, mock.Anything).Return(&entity.AdmissionPeriod{ID: 1}, nil).Once()
+				Mock.On("GetQuotas", mock.Anything, mock.Anything).Return([]entity.Quota{{Track: "zonasi", Capacity: 10}}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				var image multipart.File
+				var sign1 multipart.File
+				var sign2 multipart.File
+				image = os.NewFile(uintptr(2), "2")
+				sign1 = os.NewFile(uintptr(2), "2")
+				sign2 = os.NewFile(uintptr(2), "2")
+				req := reqsub
+				req.Track = "prestasi"
+				_, err := SchoolService.CreateSubmission(ctx, req, image, sign1, sign2)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Track not available at this school"))
+			})
+		})
+
 		When("Format File Tidak Sesuai", func() {
+			BeforeEach(func() {
+				Mock.On("GetOpenPeriod", mock.Anything, mock.Anything, mock.Anything).Return(&entity.AdmissionPeriod{ID: 1}, nil).Once()
+				Mock.On("GetQuotas", mock.Anything, mock.Anything).Return([]entity.Quota{}, nil).Once()
+			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				var sign1 multipart.File
@@ -972,6 +1139,10 @@ var _ = Describe("school", func() {
 			})
 		})
 		When("Format File Tidak Sesuai", func() {
+			BeforeEach(func() {
+				Mock.On("GetOpenPeriod", mock.Anything, mock.Anything, mock.Anything).Return(&entity.AdmissionPeriod{ID: 1}, nil).Once()
+				Mock.On("GetQuotas", mock.Anything, mock.Anything).Return([]entity.Quota{}, nil).Once()
+			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				var sign1 multipart.File
@@ -985,6 +1156,10 @@ var _ = Describe("school", func() {
 			})
 		})
 		When("Format File Tidak Sesuai", func() {
+			BeforeEach(func() {
+				Mock.On("GetOpenPeriod", mock.Anything, mock.Anything, mock.Anything).Return(&entity.AdmissionPeriod{ID: 1}, nil).Once()
+				Mock.On("GetQuotas", mock.Anything, mock.Anything).Return([]entity.Quota{}, nil).Once()
+			})
 			It("Akan Mengembalikan Erorr", func() {
 				var image multipart.File
 				var sign1 multipart.File
@@ -1000,6 +1175,8 @@ var _ = Describe("school", func() {
 
 		When("Terjadi Kesalahan Query Database", func() {
 			BeforeEach(func() {
+				Mock.On("GetOpenPeriod", mock.Anything, mock.Anything, mock.Anything).Return(&entity.AdmissionPeriod{ID: 1}, nil).Once()
+				Mock.On("GetQuotas", mock.Anything, mock.Anything).Return([]entity.Quota{}, nil).Once()
 				Mock.On("CreateSubmission", mock.Anything, mock.Anything).Return(0, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
@@ -1018,7 +1195,8 @@ var _ = Describe("school", func() {
 		})
 		When("Berhasil Membuat Submission", func() {
 			BeforeEach(func() {
-
+				Mock.On("GetOpenPeriod", mock.Anything, mock.Anything, mock.Anything).Return(&entity.AdmissionPeriod{ID: 1}, nil).Once()
+				Mock.On("GetQuotas", mock.Anything, mock.Anything).Return([]entity.Quota{}, nil).Once()
 				Mock.On("CreateSubmission", mock.Anything, mock.Anything).Return(1, nil).Once()
 			})
 			It("Akan Mengembalikan Progress Id", func() {
@@ -1040,28 +1218,47 @@ var _ = Describe("school", func() {
 	})
 
 	Context("Update Progress", func() {
+		When("Progress Milik Sekolah Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(&entity.Progress{ID: 1, SchoolID: 1}, nil).Once()
+				asMember("owner", 2)
+			})
+			It("Akan Mengembalikan Error Forbidden", func() {
+				_, err := SchoolService.UpdateProgressByid(ctx, 1, 1, entity.ReqUpdateProgress{ProgressStatus: "File Approved"})
+				Expect(err).To(Equal(authz.ErrForbidden))
+			})
+		})
 		When("Req Body Tidak Ada Dalam List Status", func() {
+			BeforeEach(func() {
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(&entity.Progress{ID: 1, SchoolID: 1}, nil).Once()
+				asMember("admissions", 1)
+				Workflow.On("UpdateProgress", mock.Anything, mock.Anything, admission.Change{Actor: 1, Status: "Berangkat"}).Return(nil, errors.New("Status Not Available")).Once()
+			})
 			It("Akan Mengembalikan Erorr", func() {
-				_, err := SchoolService.UpdateProgressByid(ctx, 1, "Berangkat")
+				_, err := SchoolService.UpdateProgressByid(ctx, 1, 1, entity.ReqUpdateProgress{ProgressStatus: "Berangkat"})
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 
 		When("Kesalahan Query Database", func() {
 			BeforeEach(func() {
-				Mock.On("UpdateProgress", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(&entity.Progress{ID: 1, SchoolID: 1}, nil).Once()
+				asMember("admissions", 1)
+				Workflow.On("UpdateProgress", mock.Anything, mock.Anything, admission.Change{Actor: 1, Status: admission.FileApproved}).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
-				_, err := SchoolService.UpdateProgressByid(ctx, 1, "File Approved")
+				_, err := SchoolService.UpdateProgressByid(ctx, 1, 1, entity.ReqUpdateProgress{ProgressStatus: "File Approved"})
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
 		When("Berhasil Mengupdate Data Progress", func() {
 			BeforeEach(func() {
-				Mock.On("UpdateProgress", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Progress{ID: 1}, nil).Once()
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(&entity.Progress{ID: 1, SchoolID: 1}, nil).Once()
+				asMember("admissions", 1)
+				Workflow.On("UpdateProgress", mock.Anything, mock.Anything, admission.Change{Actor: 1, Status: admission.FileApproved}).Return(&entity.Progress{ID: 1}, nil).Once()
 			})
 			It("Akan Mengembalikan progress id", func() {
-				progid, err := SchoolService.UpdateProgressByid(ctx, 1, "File Approved")
+				progid, err := SchoolService.UpdateProgressByid(ctx, 1, 1, entity.ReqUpdateProgress{ProgressStatus: "File Approved"})
 				Expect(err).Should(BeNil())
 				Expect(progid).To(Equal(1))
 			})
@@ -1098,17 +1295,28 @@ var _ = Describe("school", func() {
 				Mock.On("GetProgressByid", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Error", func() {
-				_, err := SchoolService.GetProgressById(ctx, 1)
+				_, err := SchoolService.GetProgressById(ctx, 1, 1, "student")
 				Expect(err).ShouldNot(BeNil())
 			})
 
 		})
+		When("Progress Milik Siswa Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetProgressByid", mock.Anything, mock.Anything).Return(&entity.Progress{ID: 1, UserID: 2, SchoolID: 1}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr Forbidden", func() {
+				_, err := SchoolService.GetProgressById(ctx, 1, 1, "student")
+				Expect(err).To(Equal(authz.ErrForbidden))
+			})
+		})
 		When("Terdapat Data Progress", func() {
 			BeforeEach(func() {
-				Mock.On("GetProgressByid", mock.Anything, mock.Anything).Return(&entity.Progress{ID: 1, Status: "File Approved"}, nil).Once()
+				Mock.On("GetProgressByid", mock.Anything, mock.Anything).Return(&entity.Progress{ID: 1, UserID: 1, SchoolID: 1, Status: "File Approved"}, nil).Once()
+				Workflow.On("Pipeline", mock.Anything, 1).Return(admission.DefaultPipeline, nil).Once()
+				Mock.On("GetNotes", mock.Anything, 1, false).Return([]entity.AdmissionNote{{Note: "Berkas lengkap"}}, nil).Once()
 			})
 			It("Akan Mengembalikan Data Progress", func() {
-				data, err := SchoolService.GetProgressById(ctx, 1)
+				data, err := SchoolService.GetProgressById(ctx, 1, 1, "student")
 				Expect(err).Should(BeNil())
 				Expect(data.Id).To(Equal(1))
 			})
@@ -1116,13 +1324,449 @@ var _ = Describe("school", func() {
 		})
 
 	})
+	Context("Revise Submission", func() {
+		When("Submission Milik Siswa Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetSubmissionByid", mock.Anything, 1).Return(&entity.Submission{ID: 1, UserID: 2, SchoolID: 1}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.ReviseSubmission(ctx, 1, 1, entity.ReqReviseSubmission{}, nil)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Submission Tidak Perlu Direvisi", func() {
+			BeforeEach(func() {
+				Mock.On("GetSubmissionByid", mock.Anything, 1).Return(&entity.Submission{ID: 1, UserID: 1, SchoolID: 1}, nil).Once()
+				Mock.On("GetLastProgress", mock.Anything, 1, 1).Return(&entity.Progress{ID: 1, Status: "File Approved"}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.ReviseSubmission(ctx, 1, 1, entity.ReqReviseSubmission{StudentName: "John Doe"}, nil)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Data Yang Diminta Tidak Dikirim", func() {
+			BeforeEach(func() {
+				Mock.On("GetSubmissionByid", mock.Anything, 1).Return(&entity.Submission{ID: 1, UserID: 1, SchoolID: 1}, nil).Once()
+				Mock.On("GetLastProgress", mock.Anything, 1, 1).Return(&entity.Progress{ID: 1, Status: "Needs Revision", RevisionFields: "student_name,student_photo"}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.ReviseSubmission(ctx, 1, 1, entity.ReqReviseSubmission{StudentName: "John Doe"}, nil)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Berhasil Merevisi Submission", func() {
+			BeforeEach(func() {
+				Mock.On("GetSubmissionByid", mock.Anything, 1).Return(&entity.Submission{ID: 1, UserID: 1, SchoolID: 1}, nil).Once()
+				Mock.On("GetLastProgress", mock.Anything, 1, 1).Return(&entity.Progress{ID: 1, Status: "Needs Revision", RevisionFields: "student_name,nisn"}, nil).Once()
+				Mock.On("UpdateSubmission", mock.Anything, 1, map[string]any{"student_name": "John Doe", "nisn": "1234567890"}).Return(nil).Once()
+				Workflow.On("ResubmitProgressByUid", mock.Anything, 1, 1).Return(&entity.Progress{ID: 1, Status: "Check File Registration"}, nil).Once()
+			})
+			It("Akan Mengembalikan progress id", func() {
+				id, err := SchoolService.ReviseSubmission(ctx, 1, 1, entity.ReqReviseSubmission{StudentName: "John Doe", NISN: "1234567890", Gender: "Male"}, nil)
+				Expect(err).Should(BeNil())
+				Expect(id).To(Equal(1))
+			})
+		})
+	})
+	Context("Add Admission Note", func() {
+		When("Request Body kosong", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.AddNote(ctx, 1, 1, entity.ReqAddNote{})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Progress Milik Sekolah Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(&entity.Progress{ID: 1, SchoolID: 1}, nil).Once()
+				asMember("owner", 2)
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.AddNote(ctx, 1, 1, entity.ReqAddNote{Note: "Cek ulang ijazah", Internal: true})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Berhasil Menambah Catatan", func() {
+			BeforeEach(func() {
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(&entity.Progress{ID: 1, SchoolID: 1}, nil).Once()
+				asMember("admissions", 1)
+				Mock.On("CreateNote", mock.Anything, entity.AdmissionNote{ProgressID: 1, UserID: 1, Note: "Cek ulang ijazah", Internal: true}).Return(nil).Once()
+			})
+			It("Akan Mengembalikan progress id", func() {
+				id, err := SchoolService.AddNote(ctx, 1, 1, entity.ReqAddNote{Note: "Cek ulang ijazah", Internal: true})
+				Expect(err).Should(BeNil())
+				Expect(id).To(Equal(1))
+			})
+		})
+	})
+	Context("Get Progress Timeline", func() {
+		When("Progress Milik Siswa Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(&entity.Progress{ID: 1, UserID: 2}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.GetProgressTimeline(ctx, 1, 1, "student")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Progress Milik Sekolah Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(&entity.Progress{ID: 1, SchoolID: 1}, nil).Once()
+				asMember("owner", 2)
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.GetProgressTimeline(ctx, 1, 1, "administrator")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Berhasil Mendapatkan Timeline", func() {
+			BeforeEach(func() {
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(&entity.Progress{ID: 1, UserID: 1}, nil).Once()
+				Mock.On("GetProgressEvents", mock.Anything, 1).Return([]entity.ProgressEvent{{ProgressID: 1, ToStatus: "Check File Registration", ActorID: 1}, {ProgressID: 1, FromStatus: "Check File Registration", ToStatus: "File Approved", ActorID: 4}}, nil).Once()
+			})
+			It("Akan Mengembalikan Riwayat Progress", func() {
+				res, err := SchoolService.GetProgressTimeline(ctx, 1, 1, "student")
+				Expect(err).Should(BeNil())
+				Expect(len(res)).To(Equal(2))
+				Expect(res[1].ToStatus).To(Equal("File Approved"))
+			})
+		})
+	})
+	Context("Update Pipeline", func() {
+		When("Tahapan Tidak Dikenal", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.UpdatePipeline(ctx, 1, entity.ReqUpdatePipeline{Stages: []string{"dance"}})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Masih Ada Peserta Di Tahapan Yang Dihapus", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Mock.On("GetActiveStatusBySchool", mock.Anything, mock.Anything).Return([]string{"Send Test Link"}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.UpdatePipeline(ctx, 1, entity.ReqUpdatePipeline{Stages: []string{"interview"}})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Berhasil Mengupdate Pipeline", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Mock.On("GetActiveStatusBySchool", mock.Anything, mock.Anything).Return([]string{"File Approved"}, nil).Once()
+				Workflow.On("Pipeline", mock.Anything, mock.Anything).Return(admission.DefaultPipeline, nil).Once()
+				Mock.On("UpdatePipeline", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			})
+			It("Akan Mengembalikan Pipeline Baru", func() {
+				res, err := SchoolService.UpdatePipeline(ctx, 1, entity.ReqUpdatePipeline{Stages: []string{"interview"}})
+				Expect(err).Should(BeNil())
+				Expect(res.Stages).To(Equal([]string{"interview"}))
+			})
+		})
+	})
+	Context("Bulk Update Progress", func() {
+		When("Aksi Tidak Dikenal", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.BulkUpdateProgress(ctx, 1, entity.ReqBulkProgress{ProgressIds: []int{1}, Action: "delete"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Sebagian Progress Gagal Dipindahkan", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Workflow.On("UpdateProgresses", mock.Anything, mock.Anything, []int{1, 2}, admission.Change{Actor: 1, Status: admission.FileApproved}).Return([]admission.Result{
+					{ProgressID: 1, Progress: &entity.Progress{ID: 1, Status: "File Approved"}},
+					{ProgressID: 2, Err: errors.New("Admission has already been closed")},
+				}).Once()
+			})
+			It("Akan Mengembalikan Hasil Per Progress", func() {
+				res, err := SchoolService.BulkUpdateProgress(ctx, 1, entity.ReqBulkProgress{ProgressIds: []int{1, 2, 1}, Action: "approve"})
+				Expect(err).Should(BeNil())
+				Expect(res).To(Equal([]entity.ResBulkProgress{
+					{ProgressID: 1, Success: true, ProgressStatus: "File Approved"},
+					{ProgressID: 2, Message: "Admission has already been closed"},
+				}))
+			})
+		})
+	})
+	Context("Update Quota", func() {
+		When("Jalur Tidak Dikenal", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.UpdateQuotas(ctx, 1, entity.ReqUpdateQuota{Quotas: []entity.ReqQuota{{Track: "umum", Capacity: 10}}})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Jalur Duplikat", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.UpdateQuotas(ctx, 1, entity.ReqUpdateQuota{Quotas: []entity.ReqQuota{{Track: "zonasi", Capacity: 10}, {Track: "zonasi", Capacity: 5}}})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Berhasil Mengupdate Kuota", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Mock.On("GetQuotas", mock.Anything, mock.Anything).Return([]entity.Quota{{Capacity: 80}}, nil).Once()
+				Mock.On("UpdateQuotas", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				Workflow.On("Promote", mock.Anything, mock.Anything).Return(nil).Once()
+				Mock.On("GetQuotas", mock.Anything, mock.Anything).Return([]entity.Quota{{Capacity: 100}, {Track: "zonasi", Capacity: 50}}, nil).Once()
+				Mock.On("CountSeats", mock.Anything, mock.Anything, "").Return(80, nil).Once()
+				Mock.On("CountSeats", mock.Anything, mock.Anything, "zonasi").Return(50, nil).Once()
+			})
+			It("Akan Mengembalikan Kuota Dan Kursi Terpakai", func() {
+				res, err := SchoolService.UpdateQuotas(ctx, 1, entity.ReqUpdateQuota{Quotas: []entity.ReqQuota{{Capacity: 100}, {Track: "zonasi", Capacity: 50}}})
+				Expect(err).Should(BeNil())
+				Expect(res).To(Equal([]entity.ResQuota{{Capacity: 100, Used: 80}, {Track: "zonasi", Capacity: 50, Used: 50}}))
+			})
+		})
+	})
+	Context("Admission Period", func() {
+		When("Tanggal Tutup Sebelum Tanggal Buka", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.CreatePeriod(ctx, 1, entity.ReqAdmissionPeriod{Name: "Gelombang 1", OpenAt: time.Now(), CloseAt: time.Now().AddDate(0, 0, -1)})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Periode Bertabrakan", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Mock.On("IsPeriodOverlapping", mock.Anything, mock.Anything).Return(true, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.CreatePeriod(ctx, 1, entity.ReqAdmissionPeriod{Name: "Gelombang 1", OpenAt: time.Now(), CloseAt: time.Now().AddDate(0, 1, 0)})
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Admission period overlaps with another period"))
+			})
+		})
+		When("Berhasil Membuat Periode", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Mock.On("IsPeriodOverlapping", mock.Anything, mock.Anything).Return(false, nil).Once()
+				Mock.On("CreatePeriod", mock.Anything, mock.Anything).Return(3, nil).Once()
+			})
+			It("Akan Mengembalikan Id Periode", func() {
+				id, err := SchoolService.CreatePeriod(ctx, 1, entity.ReqAdmissionPeriod{Name: "Gelombang 1", OpenAt: time.Now(), CloseAt: time.Now().AddDate(0, 1, 0), StepDeadline: 3})
+				Expect(err).Should(BeNil())
+				Expect(id).To(Equal(3))
+			})
+		})
+		When("Periode Milik Sekolah Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Mock.On("GetPeriodById", mock.Anything, 5).Return(&entity.AdmissionPeriod{ID: 5, SchoolID: 9}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				err := SchoolService.DeletePeriod(ctx, 5, 1)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+	})
+	Context("Get Waitlist", func() {
+		When("Terdapat Peserta Di Daftar Tunggu", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Mock.On("GetWaitlist", mock.Anything, mock.Anything).Return([]entity.Progress{{ID: 4, UserID: 2, Track: "zonasi"}, {ID: 7, UserID: 3, Track: "prestasi"}}, nil).Once()
+			})
+			It("Akan Mengembalikan Peringkat Berurutan", func() {
+				res, err := SchoolService.GetWaitlist(ctx, 1)
+				Expect(err).Should(BeNil())
+				Expect(res).To(Equal([]entity.ResWaitlist{{Rank: 1, ProgressId: 4, UserId: 2, Track: "zonasi"}, {Rank: 2, ProgressId: 7, UserId: 3, Track: "prestasi"}}))
+			})
+		})
+	})
+	Context("Peringkat Zonasi", func() {
+		When("Lokasi Sekolah Belum Diatur", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.GetZonasiRanking(ctx, 1, "zonasi")
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("School location is not set"))
+			})
+		})
+		When("Terdapat Peserta Zonasi", func() {
+			BeforeEach(func() {
+				lat, lng := -6.2, 106.8
+				near, far := -6.21, -6.3
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1", Latitude: &lat, Longitude: &lng}}, nil).Once()
+				Mock.On("GetApplicants", mock.Anything, mock.Anything, "zonasi").Return([]entity.Applicant{
+					{ProgressID: 4, SubmissionID: 10, Latitude: &far, Longitude: &lng},
+					{ProgressID: 7, SubmissionID: 11, Latitude: &near, Longitude: &lng},
+				}, nil).Once()
+			})
+			It("Akan Mengembalikan Peringkat Berdasarkan Jarak", func() {
+				res, err := SchoolService.GetZonasiRanking(ctx, 1, "zonasi")
+				Expect(err).Should(BeNil())
+				Expect(res).To(HaveLen(2))
+				Expect(res[0].ProgressId).To(Equal(7))
+				Expect(res[1].Rank).To(Equal(2))
+			})
+		})
+	})
+	Context("Kriteria Seleksi", func() {
+		When("Kriteria Duplikat", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.UpdateSelectionCriteria(ctx, 1, entity.ReqUpdateSelection{Criteria: []entity.ReqSelectionCriterion{{Criterion: "distance", Weight: 50}, {Criterion: "distance", Weight: 50}}})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Kriteria Valid", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Mock.On("GetSelectionCriteria", mock.Anything, mock.Anything).Return([]entity.SelectionCriterion{}, nil).Once()
+				Mock.On("UpdateSelectionCriteria", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				Mock.On("GetSelectionCriteria", mock.Anything, mock.Anything).Return([]entity.SelectionCriterion{{Criterion: "distance", Weight: 50}}, nil).Once()
+			})
+			It("Akan Mengembalikan Kriteria Tersimpan", func() {
+				res, err := SchoolService.UpdateSelectionCriteria(ctx, 1, entity.ReqUpdateSelection{Criteria: []entity.ReqSelectionCriterion{{Criterion: "distance", Weight: 50}}})
+				Expect(err).Should(BeNil())
+				Expect(res).To(Equal([]entity.ResSelectionCriterion{{Criterion: "distance", Weight: 50}}))
+			})
+		})
+	})
+	Context("Peringkat Seleksi", func() {
+		When("Kriteria Belum Diatur", func() {
+			BeforeEach(func() {
+				asMember("owner", 1)
+				Mock.On("GetAllProgressAndSubmission", mock.Anything, 1).Return(&entity.School{Name: "SMA 1"}, nil).Once()
+				Mock.On("GetSelectionCriteria", mock.Anything, mock.Anything).Return([]entity.SelectionCriterion{}, nil).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.GetSelection(ctx, 1)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Selection criteria have not been set"))
+			})
+		})
+		When("Terdapat Peserta", func() {
+			BeforeEach(func() {
+				high, low := 90.0, 70.0
+				data := entity.School{Name: "SMA 1"}
+				data.Progresses = []entity.Progress{{ID: 4, UserID: 2, Status: "File Approved"}, {ID: 5, UserID: 3, Status: "File Approved"}, {ID: 6, UserID: 7, Status: "Failed File Approved"}}
+				data.Submissions = []entity.Submission{{ID: 10, UserID: 2, ReportAverage: &low}, {ID: 11, UserID: 3, StudentName: "=HYPERLINK(A1)", ReportAverage: &high}, {ID: 12, UserID: 7, ReportAverage: &high}}
+				asMember("owner", 1)
+				Mock.On("GetAllProgressAndSubmission", mock.Anything, 1).Return(&data, nil).Once()
+				Mock.On("GetSelectionCriteria", mock.Anything, mock.Anything).Return([]entity.SelectionCriterion{{Criterion: "report_average", Weight: 100}}, nil).Once()
+			})
+			It("Akan Mengembalikan Peringkat Peserta Aktif", func() {
+				res, err := SchoolService.GetSelection(ctx, 1)
+				Expect(err).Should(BeNil())
+				Expect(res).To(HaveLen(2))
+				Expect(res[0].ProgressId).To(Equal(5))
+				Expect(res[0].Score).To(Equal(90.0))
+			})
+			It("Akan Mengekspor Peringkat Ke CSV", func() {
+				res, err := SchoolService.ExportSelection(ctx, 1)
+				Expect(err).Should(BeNil())
+				Expect(string(res)).To(HavePrefix("rank,progress_id,submission_id,student_name,progress_status,report_average,score\n1,5,11,'=HYPERLINK(A1),File Approved,"))
+			})
+			It("Akan Meloloskan Peserta Teratas", func() {
+				Workflow.On("UpdateProgresses", mock.Anything, 1, []int{5}, mock.Anything).Return([]admission.Result{{ProgressID: 5, Progress: &entity.Progress{ID: 5, Status: "Send Test Link"}}}).Once()
+				res, err := SchoolService.AdvanceSelection(ctx, 1, entity.ReqAdvanceSelection{Top: 1, Action: "send_test_link"})
+				Expect(err).Should(BeNil())
+				Expect(res).To(Equal([]entity.ResBulkProgress{{ProgressID: 5, Success: true, ProgressStatus: "Send Test Link"}}))
+			})
+		})
+	})
+	Context("Banding", func() {
+		When("Alasan Banding Terlalu Pendek", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.CreateAppeal(ctx, 2, entity.ReqCreateAppeal{ProgressId: 4, Justification: "tolong"}, nil)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Banding Berhasil Dibuat", func() {
+			BeforeEach(func() {
+				Workflow.On("Appeal", mock.Anything, entity.Appeal{ProgressID: 4, UserID: 2, Justification: "Nilai tes saya tidak tercatat dengan benar", Attachments: "[]"}).Return(&entity.Appeal{ID: 9}, nil).Once()
+			})
+			It("Akan Mengembalikan Id Banding", func() {
+				res, err := SchoolService.CreateAppeal(ctx, 2, entity.ReqCreateAppeal{ProgressId: 4, Justification: "Nilai tes saya tidak tercatat dengan benar"}, nil)
+				Expect(err).Should(BeNil())
+				Expect(res).To(Equal(9))
+			})
+		})
+		When("Lampiran Bukan Gambar Atau Pdf", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				file, _ := os.Open(os.DevNull)
+				_, err := SchoolService.CreateAppeal(ctx, 2, entity.ReqCreateAppeal{ProgressId: 4, Justification: "Nilai tes saya tidak tercatat dengan benar", Attachments: []string{"../../skrip.sh"}}, []multipart.File{file})
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Attachment must be an image or a pdf"))
+			})
+		})
+		When("Progress Tidak Bisa Dibanding", func() {
+			var dir string
+			BeforeEach(func() {
+				dir = GinkgoT().TempDir()
+				Depend.Storage = &pkg.LocalStorage{Dir: dir}
+				SchoolService = school.NewSchoolService(Mock, Depend, Mocks, Workflow, authz.NewAuthorizer(Mock, Depend), Audit)
+				Workflow.On("Appeal", mock.Anything, entity.Appeal{ProgressID: 4, UserID: 2, Justification: "Nilai tes saya tidak tercatat dengan benar", Attachments: `["Appeal_2_4_1_nilai.pdf"]`}).Return(nil, errors.New("Only a failed progress can be appealed")).Once()
+			})
+			It("Akan Menghapus Lampiran Yang Sudah Diunggah", func() {
+				file, _ := os.Open(os.DevNull)
+				_, err := SchoolService.CreateAppeal(ctx, 2, entity.ReqCreateAppeal{ProgressId: 4, Justification: "Nilai tes saya tidak tercatat dengan benar", Attachments: []string{"dokumen/nilai.pdf"}}, []multipart.File{file})
+				Expect(err).ShouldNot(BeNil())
+				Expect(filepath.Join(dir, "Appeal_2_4_1_nilai.pdf")).ShouldNot(BeAnExistingFile())
+			})
+		})
+		When("Banding Diterima Tanpa Status", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.DecideAppeal(ctx, 9, 1, entity.ReqDecideAppeal{Decision: "accept"})
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Progress status is required to accept an appeal"))
+			})
+		})
+		When("Banding Ditolak", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{Role: "owner", School: entity.School{Name: "SMA 1"}}, nil).Once()
+				Mock.On("GetAppealById", mock.Anything, 9).Return(&entity.Appeal{ID: 9, ProgressID: 4, Status: admission.AppealPending}, nil).Once()
+				Workflow.On("DecideAppeal", mock.Anything, mock.Anything, 9, admission.Decision{Actor: 1, Reason: "Nilai sudah benar"}).Return(&entity.Appeal{ID: 9, ProgressID: 4, Status: admission.AppealDenied, Response: "Nilai sudah benar"}, nil).Once()
+			})
+			It("Akan Mengembalikan Keputusan", func() {
+				res, err := SchoolService.DecideAppeal(ctx, 9, 1, entity.ReqDecideAppeal{Decision: "deny", Reason: "Nilai sudah benar"})
+				Expect(err).Should(BeNil())
+				Expect(res.Status).To(Equal("denied"))
+				Expect(res.Attachments).To(BeEmpty())
+			})
+		})
+	})
+	Context("Respon Siswa", func() {
+		When("Keputusan Penawaran Tidak Valid", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.RespondOffer(ctx, 4, 2, entity.ReqRespondOffer{Decision: "maybe"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Siswa Menerima Penawaran", func() {
+			BeforeEach(func() {
+				Workflow.On("Respond", mock.Anything, 2, 4, admission.OfferAccepted, "").Return(&entity.Progress{ID: 4, Status: string(admission.OfferAccepted)}, nil).Once()
+			})
+			It("Akan Mengembalikan Id Progress", func() {
+				res, err := SchoolService.RespondOffer(ctx, 4, 2, entity.ReqRespondOffer{Decision: "accept"})
+				Expect(err).Should(BeNil())
+				Expect(res).To(Equal(4))
+			})
+		})
+		When("Pendaftaran Sudah Ditutup", func() {
+			BeforeEach(func() {
+				Workflow.On("Respond", mock.Anything, 2, 4, admission.Withdrawn, "Pindah kota").Return(nil, errors.New("Admission has already been closed")).Once()
+			})
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := SchoolService.Withdraw(ctx, 4, 2, entity.ReqWithdraw{Reason: "Pindah kota"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+	})
 	Context("Get Admission Data By Uid", func() {
 		When("Terdapat Kesalahn Query Database", func() {
 			BeforeEach(func() {
-				Mock.On("GetAllProgressAndSubmissionByuid", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
+				asMember("finance", 1)
+				Mock.On("GetAllProgressAndSubmission", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Error", func() {
-				_, err := SchoolService.GetAllProgressAndSubmissionByuid(ctx, 90)
+				_, err := SchoolService.GetAllProgressAndSubmission(ctx, 90)
 				Expect(err).ShouldNot(BeNil())
 			})
 
@@ -1136,10 +1780,11 @@ var _ = Describe("school", func() {
 				data.Submissions = datasubmission
 				data.Progresses = dataprogres
 				data.User = &datauser
-				Mock.On("GetAllProgressAndSubmissionByuid", mock.Anything, mock.Anything).Return(&data, nil).Once()
+				asMember("admissions", 1)
+				Mock.On("GetAllProgressAndSubmission", mock.Anything, 1).Return(&data, nil).Once()
 			})
 			It("Akan Mengembalikan Seluruh Data Admission", func() {
-				data, err := SchoolService.GetAllProgressAndSubmissionByuid(ctx, 1)
+				data, err := SchoolService.GetAllProgressAndSubmission(ctx, 1)
 				Expect(err).Should(BeNil())
 				Expect(data).ShouldNot(BeNil())
 			})
@@ -1153,31 +1798,341 @@ var _ = Describe("school", func() {
 				Mock.On("GetSubmissionByid", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Error", func() {
-				_, err := SchoolService.GetSubmissionByid(ctx, 90)
+				_, err := SchoolService.GetSubmissionByid(ctx, 90, 1)
 				Expect(err).ShouldNot(BeNil())
 			})
 
 		})
+		When("Submission Milik Sekolah Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetSubmissionByid", mock.Anything, 1).Return(&entity.Submission{ID: 1, SchoolID: 1}, nil).Once()
+				asMember("owner", 2)
+			})
+			It("Akan Mengembalikan Error Forbidden", func() {
+				_, err := SchoolService.GetSubmissionByid(ctx, 1, 1)
+				Expect(err).To(Equal(authz.ErrForbidden))
+			})
+		})
 		When("Terdapat Data Submission", func() {
 			BeforeEach(func() {
 				data := entity.Submission{}
 				data.StudentAddress = `{"province": "Jakarta","city": "cibubur","district": "cibubur","village": "cibubur","detail": "cibubur","zip_code": "16223"}`
 				data.ParentAddress = `{"province": "Jakarta","city": "cibubur","district": "cibubur","village": "cibubur","detail": "cibubur","zip_code": "16223"}`
 				Mock.On("GetSubmissionByid", mock.Anything, mock.Anything).Return(&data, nil).Once()
+				asMember("admissions", 0)
+				Mock.On("GetLastProgress", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Progress{ID: 1, Status: "Failed File Approved", RejectionReason: "invalid_documents"}, nil).Once()
+				Mock.On("GetNotes", mock.Anything, 1, true).Return([]entity.AdmissionNote{{Note: "Ijazah buram", Internal: true}}, nil).Once()
 			})
 			It("Akan Mengembalikan Seluruh Data Admission", func() {
-				data, err := SchoolService.GetSubmissionByid(ctx, 1)
+				data, err := SchoolService.GetSubmissionByid(ctx, 1, 1)
 				Expect(err).Should(BeNil())
 				Expect(data).ShouldNot(BeNil())
+				Expect(data.RejectionReason).To(Equal("invalid_documents"))
+				Expect(len(data.Notes)).To(Equal(1))
 			})
 
 		})
 
 	})
+	Context("Berkas Tersimpan", func() {
+		BeforeEach(func() {
+			dir := GinkgoT().TempDir()
+			os.WriteFile(filepath.Join(dir, "Student_1_foto.png"), []byte("foto"), 0o644)
+			Depend.Storage = &pkg.LocalStorage{Dir: dir}
+			SchoolService = school.NewSchoolService(Mock, Depend, Mocks, Workflow, authz.NewAuthorizer(Mock, Depend), Audit)
+		})
+		When("Berkas Tidak Dimiliki Siapapun", func() {
+			BeforeEach(func() {
+				Mock.On("GetFileOwner", mock.Anything, "rahasia.png").Return(0, false, errorr.NewBad("Data Not Found")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.GetFile(ctx, 1, "rahasia.png")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Berkas Pendaftaran Milik Sekolah Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetFileOwner", mock.Anything, "Student_1_foto.png").Return(1, true, nil).Once()
+				asMember("owner", 2)
+			})
+			It("Akan Mengembalikan Error Forbidden", func() {
+				_, err := SchoolService.GetFile(ctx, 1, "Student_1_foto.png")
+				Expect(err).To(Equal(authz.ErrForbidden))
+			})
+		})
+		When("Peran Tidak Bisa Melihat Pendaftaran", func() {
+			BeforeEach(func() {
+				Mock.On("GetFileOwner", mock.Anything, "Student_1_foto.png").Return(1, true, nil).Once()
+				asMember("content", 1)
+			})
+			It("Akan Mengembalikan Error Forbidden", func() {
+				_, err := SchoolService.GetFile(ctx, 1, "Student_1_foto.png")
+				Expect(err).To(Equal(authz.ErrForbidden))
+			})
+		})
+		When("Berkas Milik Sekolah Admin", func() {
+			BeforeEach(func() {
+				Mock.On("GetFileOwner", mock.Anything, "Student_1_foto.png").Return(1, true, nil).Once()
+				asMember("admissions", 1)
+			})
+			It("Akan Mengembalikan Isi Berkas", func() {
+				res, err := SchoolService.GetFile(ctx, 1, "Student_1_foto.png")
+				Expect(err).Should(BeNil())
+				Expect(res).To(Equal(base64.StdEncoding.EncodeToString([]byte("foto"))))
+			})
+		})
+	})
+	Context("Formulir Pendaftaran PDF", func() {
+		var data entity.Submission
+		BeforeEach(func() {
+			dir := GinkgoT().TempDir()
+			img := image.NewRGBA(image.Rect(0, 0, 30, 40))
+			file, _ := os.Create(filepath.Join(dir, "Student_1_foto.png"))
+			png.Encode(file, img)
+			file.Close()
+			Depend.Storage = &pkg.LocalStorage{Dir: dir}
+			SchoolService = school.NewSchoolService(Mock, Depend, Mocks, Workflow, authz.NewAuthorizer(Mock, Depend), Audit)
+			data = entity.Submission{ID: 1, UserID: 1, SchoolID: 3, StudentName: "Budi (Anak)", StudentPhoto: "Student_1_foto.png", ParentSignature: "ParentSign_1_hilang.png", Date: "2023-06-01"}
+			data.School.Name = "SMA Negeri 1"
+			data.StudentAddress = `{"province": "Jakarta","city": "cibubur","district": "cibubur","village": "cibubur","detail": "cibubur","zip_code": "16223"}`
+		})
+		When("Submission bukan milik siswa", func() {
+			BeforeEach(func() {
+				Mock.On("GetSubmissionByid", mock.Anything, 1).Return(&data, nil).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.GetSubmissionPdf(ctx, 1, 2, "student")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Submission bukan milik sekolah admin", func() {
+			BeforeEach(func() {
+				Mock.On("GetSubmissionByid", mock.Anything, 1).Return(&data, nil).Once()
+				asMember("owner", 4)
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.GetSubmissionPdf(ctx, 1, 6, "administrator")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Siswa mengunduh formulirnya", func() {
+			BeforeEach(func() {
+				Mock.On("GetSubmissionByid", mock.Anything, 1).Return(&data, nil).Once()
+			})
+			It("Akan Mengembalikan PDF walaupun tanda tangan tidak ditemukan", func() {
+				res, err := SchoolService.GetSubmissionPdf(ctx, 1, 1, "student")
+				Expect(err).Should(BeNil())
+				Expect(string(res[:8])).To(Equal("%PDF-1.4"))
+				Expect(string(res)).To(ContainSubstring(`Budi \(Anak\)`))
+				Expect(string(res)).To(ContainSubstring("/Subtype /Image"))
+			})
+		})
+		When("Admin sekolah mengunduh formulir", func() {
+			BeforeEach(func() {
+				Mock.On("GetSubmissionByid", mock.Anything, 1).Return(&data, nil).Once()
+				asMember("admissions", 3)
+			})
+			It("Akan Mengembalikan PDF", func() {
+				res, err := SchoolService.GetSubmissionPdf(ctx, 1, 5, "administrator")
+				Expect(err).Should(BeNil())
+				Expect(string(res)).To(ContainSubstring("SMA Negeri 1"))
+			})
+		})
+	})
+	Context("Surat Penerimaan", func() {
+		When("Sekolah Belum Membuat Template", func() {
+			BeforeEach(func() {
+				Mock.On("GetMember", mock.Anything, 5).Return(&entity.SchoolMember{Role: "owner", School: entity.School{}}, nil).Once()
+				Mock.On("GetLetterTemplate", mock.Anything, mock.Anything).Return(nil, errorr.NewBad("Data Not Found")).Once()
+			})
+			It("Akan Mengembalikan Template Bawaan", func() {
+				res, err := SchoolService.GetLetterTemplate(ctx, 5)
+				Expect(err).Should(BeNil())
+				Expect(res.Title).To(Equal(admission.DefaultLetter.Title))
+			})
+		})
+		When("Template Tidak Lengkap", func() {
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.UpdateLetterTemplate(ctx, 5, entity.ReqLetterTemplate{Title: "SURAT"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Progress Belum Selesai", func() {
+			BeforeEach(func() {
+				prog := &entity.Progress{ID: 1, UserID: 1, Status: string(admission.AlreadyPaidHerRegistration)}
+				Mock.On("GetProgressByid", mock.Anything, 1).Return(prog, nil).Once()
+				Workflow.On("IssueLetter", mock.Anything, prog).Return(nil, errorr.NewBad("Acceptance letter is not available yet")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.GetAcceptanceLetter(ctx, 1, 1)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Nomor Surat Terdaftar", func() {
+			BeforeEach(func() {
+				Mock.On("GetLetterByReference", mock.Anything, "SK-20100001-2023-0A1B2C3D4E5F").Return(&entity.AcceptanceLetter{Reference: "SK-20100001-2023-0A1B2C3D4E5F", NISN: "0012345678"}, nil).Once()
+			})
+			It("Akan Mengembalikan Data Surat Dengan NISN Tersamarkan", func() {
+				res, err := SchoolService.VerifyLetter(ctx, " sk-20100001-2023-0a1b2c3d4e5f")
+				Expect(err).Should(BeNil())
+				Expect(res.NISN).To(Equal("******5678"))
+			})
+		})
+		When("Nomor Surat Tidak Terdaftar", func() {
+			BeforeEach(func() {
+				Mock.On("GetLetterByReference", mock.Anything, mock.Anything).Return(nil, errorr.NewBad("Data Not Found")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.VerifyLetter(ctx, "SK-PALSU")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+	})
+	Context("Pendaftaran Dari Profil Siswa", func() {
+		When("Profil Belum Diisi", func() {
+			BeforeEach(func() {
+				Mocks.On("GetStudentProfile", mock.Anything, 1).Return(nil, errorr.NewBad("Student profile is not filled in yet")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.CreateSubmissionFromProfile(ctx, 1, entity.ReqProfileSubmission{SchoolID: 123})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Profil Belum Memiliki Dokumen", func() {
+			BeforeEach(func() {
+				Mocks.On("GetStudentProfile", mock.Anything, 1).Return(&entity.StudentProfile{ID: 1, UserID: 1, StudentName: "Budi"}, nil).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.CreateSubmissionFromProfile(ctx, 1, entity.ReqProfileSubmission{SchoolID: 123})
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Student profile is incomplete"))
+			})
+		})
+		When("Jalur Tidak Valid", func() {
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.CreateSubmissionFromProfile(ctx, 1, entity.ReqProfileSubmission{SchoolID: 123, Track: "titipan"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+	})
+	Context("Anggota Sekolah", func() {
+		When("Peran Tidak Memiliki Izin", func() {
+			BeforeEach(func() {
+				asMember("content", 1)
+			})
+			It("Akan Mengembalikan Error Forbidden", func() {
+				_, err := SchoolService.InviteMember(ctx, 1, entity.ReqInviteMember{Email: "staf@sma1.sch.id", Role: "finance"})
+				Expect(err).Should(BeAssignableToTypeOf(errorr.Forbidden{}))
+			})
+		})
+		When("Peran Tidak Dikenal", func() {
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.InviteMember(ctx, 1, entity.ReqInviteMember{Email: "staf@sma1.sch.id", Role: "kepala"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Pemilik Terakhir Keluar", func() {
+			BeforeEach(func() {
+				Mock.On("GetMemberById", mock.Anything, 3).Return(&entity.SchoolMember{ID: 3, SchoolID: 1, UserID: 1, Role: "owner"}, nil).Once()
+				Mock.On("DeleteMember", mock.Anything, 3).Return(errorr.NewBad("A school needs at least one owner")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				err := SchoolService.DeleteMember(ctx, 3, 1)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("A school needs at least one owner"))
+			})
+		})
+		When("Pemilik Mengubah Peran Anggota", func() {
+			BeforeEach(func() {
+				Mock.On("GetMemberById", mock.Anything, 4).Return(&entity.SchoolMember{ID: 4, SchoolID: 1, UserID: 7, Role: "admissions"}, nil).Once()
+				asMember("owner", 1)
+				Mock.On("UpdateMember", mock.Anything, mock.Anything).Return(nil).Once()
+			})
+			It("Akan Mengembalikan Peran Baru", func() {
+				res, err := SchoolService.UpdateMember(ctx, 4, 1, entity.ReqUpdateMember{Role: "finance"})
+				Expect(err).Should(BeNil())
+				Expect(res.Role).To(Equal("finance"))
+			})
+		})
+		When("Undangan Untuk Email Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetInvitationByToken", mock.Anything, mock.Anything).Return(&entity.SchoolInvitation{SchoolID: 1, Email: "staf@sma1.sch.id", Role: "finance", ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+				Mocks.On("GetById", mock.Anything, 1).Return(&entity.User{Email: "lain@gmail.com"}, nil).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.AcceptInvitation(ctx, 1, "token")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Undangan Kedaluwarsa", func() {
+			BeforeEach(func() {
+				Mock.On("GetInvitationByToken", mock.Anything, mock.Anything).Return(&entity.SchoolInvitation{SchoolID: 1, Email: "staf@sma1.sch.id", Role: "finance", ExpiresAt: time.Now().Add(-time.Hour)}, nil).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.AcceptInvitation(ctx, 1, "token")
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Invitation has expired"))
+			})
+		})
+		When("Berhasil Menerima Undangan", func() {
+			BeforeEach(func() {
+				Mock.On("GetInvitationByToken", mock.Anything, mock.Anything).Return(&entity.SchoolInvitation{SchoolID: 1, Email: "staf@sma1.sch.id", Role: "finance", ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+				Mocks.On("GetById", mock.Anything, 1).Return(&entity.User{Email: "Staf@SMA1.sch.id"}, nil).Once()
+				Mock.On("GetMember", mock.Anything, 1).Return(nil, errorr.NewBad("Data Not Found")).Once()
+				Mock.On("AcceptInvitation", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			})
+			It("Akan Mengembalikan Keanggotaan Baru", func() {
+				res, err := SchoolService.AcceptInvitation(ctx, 1, "token")
+				Expect(err).Should(BeNil())
+				Expect(res.Role).To(Equal("finance"))
+			})
+		})
+	})
+	Context("Log Audit", func() {
+		When("Super Admin Membaca Log", func() {
+			BeforeEach(func() {
+				Audit.On("Query", mock.Anything, audit.Filter{ActorID: 3, Limit: 20}).Return([]entity.AuditEntry{{ActorID: 3}}, 1, nil).Once()
+			})
+			It("Akan Mengembalikan Semua Entri", func() {
+				res, err := SchoolService.GetAuditLog(ctx, 1, "su", entity.ReqAuditQuery{ActorID: 3})
+				Expect(err).Should(BeNil())
+				Expect(res.TotalData).To(Equal(1))
+				Expect(res.Page).To(Equal(1))
+			})
+		})
+		When("Pemilik Sekolah Membaca Log", func() {
+			BeforeEach(func() {
+				asMember("owner", 1)
+				from := time.Date(2023, 5, 1, 0, 0, 0, 0, time.Local)
+				Audit.On("Query", mock.Anything, audit.Filter{School: 1, ResourceType: "payment", From: from, To: from.AddDate(0, 1, 0), Limit: 50, Offset: 50}).Return([]entity.AuditEntry{}, 51, nil).Once()
+			})
+			It("Hanya Entri Sekolahnya", func() {
+				res, err := SchoolService.GetAuditLog(ctx, 1, "administrator", entity.ReqAuditQuery{ResourceType: "payment", From: "2023-05-01", To: "2023-05-31", Page: 2, Limit: 50})
+				Expect(err).Should(BeNil())
+				Expect(res.TotalPage).To(Equal(2))
+			})
+		})
+		When("Pengurus Bukan Pemilik", func() {
+			BeforeEach(func() {
+				asMember("finance", 1)
+			})
+			It("Akan Mengembalikan Error Forbidden", func() {
+				_, err := SchoolService.GetAuditLog(ctx, 1, "administrator", entity.ReqAuditQuery{})
+				Expect(err).Should(BeAssignableToTypeOf(errorr.Forbidden{}))
+			})
+		})
+		When("Format Tanggal Salah", func() {
+			It("Akan Mengembalikan Error", func() {
+				_, err := SchoolService.GetAuditLog(ctx, 1, "su", entity.ReqAuditQuery{From: "01-05-2023"})
+				Expect(err).To(Equal(errorr.NewBad("Invalid Date, Use YYYY-MM-DD")))
+			})
+		})
+	})
 	Context("Delete School", func() {
 		When("Terdapat Kesalahn Query Database", func() {
 			BeforeEach(func() {
-				Mock.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("Internal Server Error")).Once()
+				asMember("owner", 90)
+				Mock.On("Delete", mock.Anything, 90).Return(errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Error", func() {
 				err := SchoolService.Delete(ctx, 90, 99)
@@ -1185,9 +2140,19 @@ var _ = Describe("school", func() {
 			})
 
 		})
+		When("Admin Bukan Pemilik Sekolah", func() {
+			BeforeEach(func() {
+				asMember("content", 90)
+			})
+			It("Akan Mengembalikan Error", func() {
+				err := SchoolService.Delete(ctx, 90, 99)
+				Expect(err).Should(BeAssignableToTypeOf(errorr.Forbidden{}))
+			})
+		})
 		When("Terdapat Data Submission", func() {
 			BeforeEach(func() {
-				Mock.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				asMember("owner", 90)
+				Mock.On("Delete", mock.Anything, 90).Return(nil).Once()
 			})
 			It("Akan Mengembalikan Error", func() {
 				err := SchoolService.Delete(ctx, 90, 99)
@@ -1201,7 +2166,7 @@ var _ = Describe("school", func() {
 	Context("Get Detail School Admin", func() {
 		When("Terdapat Kesalahn Query Database", func() {
 			BeforeEach(func() {
-				Mock.On("GetByUid", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
+				Mock.On("GetMember", mock.Anything, mock.Anything).Return(nil, errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Error", func() {
 				_, err := SchoolService.GetByUid(ctx, 10)
@@ -1218,7 +2183,7 @@ var _ = Describe("school", func() {
 				data.Achievements = []entity.Achievement{entity.Achievement{}}
 				data.Payments = []entity.Payment{entity.Payment{Type: "one"}, entity.Payment{Type: "interval"}}
 				data.QuizLinkPub = "preview"
-				Mock.On("GetByUid", mock.Anything, mock.Anything, mock.Anything).Return(&data, nil).Once()
+				Mock.On("GetMember", mock.Anything, mock.Anything).Return(&entity.SchoolMember{Role: "owner", School: data}, nil).Once()
 			})
 			It("Akan Mengembalikan Data Sekolah Admin", func() {
 				data, err := SchoolService.GetByUid(ctx, 1)
@@ -1331,42 +2296,42 @@ var _ = Describe("school", func() {
 		When("Req Body Kosong", func() {
 			It("Akan Mengembalikan Error", func() {
 				req := []entity.ReqAddQuiz{}
-				err := SchoolService.CreateQuiz(ctx, req)
+				err := SchoolService.CreateQuiz(ctx, 1, req)
 				Expect(err).ShouldNot(BeNil())
 			})
 
 		})
 		When("Tidak Terdapat Data Sekolah", func() {
 			BeforeEach(func() {
-				Mock.On("GetById", mock.Anything, mock.Anything).Return(nil, errors.New("err")).Once()
+				Mock.On("GetMember", mock.Anything, mock.Anything).Return(nil, errors.New("err")).Once()
 			})
 			It("Akan Mengembalikan Error", func() {
 				req := []entity.ReqAddQuiz{entity.ReqAddQuiz{SchoolID: 1}}
-				err := SchoolService.CreateQuiz(ctx, req)
+				err := SchoolService.CreateQuiz(ctx, 1, req)
 				Expect(err).ShouldNot(BeNil())
 			})
 
 		})
 		When("Kesalahan Query Database", func() {
 			BeforeEach(func() {
-				Mock.On("GetById", mock.Anything, mock.Anything).Return(nil, nil).Once()
+				asMember("admissions", 1)
 				Mock.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("err")).Once()
 			})
 			It("Akan Mengembalikan error", func() {
 				req := []entity.ReqAddQuiz{entity.ReqAddQuiz{SchoolID: 1}}
-				err := SchoolService.CreateQuiz(ctx, req)
+				err := SchoolService.CreateQuiz(ctx, 1, req)
 				Expect(err).ShouldNot(BeNil())
 			})
 
 		})
 		When("Berhasil Menambahkan Quiz", func() {
 			BeforeEach(func() {
-				Mock.On("GetById", mock.Anything, mock.Anything).Return(nil, nil).Once()
+				asMember("admissions", 1)
 				Mock.On("Update", mock.Anything, mock.Anything).Return(&entity.School{}, nil).Once()
 			})
 			It("Akan Mengembalikan nil error", func() {
 				req := []entity.ReqAddQuiz{entity.ReqAddQuiz{SchoolID: 1}}
-				err := SchoolService.CreateQuiz(ctx, req)
+				err := SchoolService.CreateQuiz(ctx, 1, req)
 				Expect(err).Should(BeNil())
 
 			})
@@ -1378,7 +2343,7 @@ var _ = Describe("school", func() {
 	Context("Get Test Result", func() {
 		When("Jika Tidak Terdapat Data Link", func() {
 			BeforeEach(func() {
-				Mock.On("GetByUid", mock.Anything, mock.Anything).Return(nil, errors.New("tes")).Once()
+				Mock.On("GetMember", mock.Anything, mock.Anything).Return(nil, errors.New("tes")).Once()
 			})
 			It("Akan Mengembalikan Error", func() {
 				_, err := SchoolService.GetTestResult(ctx, 2)
@@ -1389,7 +2354,7 @@ var _ = Describe("school", func() {
 		})
 		When("Terdapat Data Link", func() {
 			BeforeEach(func() {
-				Mock.On("GetByUid", mock.Anything, mock.Anything).Return(&entity.School{}, nil).Once()
+				Mock.On("GetMember", mock.Anything, mock.Anything).Return(&entity.SchoolMember{Role: "owner", School: entity.School{}}, nil).Once()
 			})
 			It("Akan Mengembalikan Data Sekolah Admin", func() {
 				res, err := SchoolService.GetTestResult(ctx, 2)
@@ -8,7 +8,6 @@ import (
 	"github.com/education-hub/BE/app/features/transaction/service"
 	"github.com/education-hub/BE/config/dependency"
 	"github.com/education-hub/BE/helper"
-	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
@@ -20,8 +19,7 @@ type Transaction struct {
 }
 
 func (u *Transaction) GetTransactionStudent(c echo.Context) error {
-	uid := helper.GetUid(c.Get("user").(*jwt.Token))
-	res, err := u.Service.GetAllTrasactionCart(c.Request().Context(), uid)
+	res, err := u.Service.GetAllTrasactionCart(c.Request().Context(), helper.GetStudentUid(c))
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -36,7 +34,7 @@ func (u *Transaction) CreateTransaction(c echo.Context) error {
 		u.Dep.Log.Errorf("Error service: %v", err)
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
 	}
-	res, err := u.Service.CreateTransaction(c.Request().Context(), req, helper.GetUid(c.Get("user").(*jwt.Token)))
+	res, err := u.Service.CreateTransaction(c.Request().Context(), req, helper.GetStudentUid(c))
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -53,7 +51,7 @@ func (u *Transaction) GetDetailTransaction(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Progress Id", nil))
 	}
-	res, err := u.Service.GetDetailTransaction(c.Request().Context(), newschid, helper.GetUid(c.Get("user").(*jwt.Token)))
+	res, err := u.Service.GetDetailTransaction(c.Request().Context(), newschid, helper.GetStudentUid(c))
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
@@ -14,13 +14,13 @@ type TransactionRepo struct {
 	mock.Mock
 }
 
-// CreateTranscation provides a mock function with given fields: db, data, typee
-func (_m *TransactionRepo) CreateTranscation(db *gorm.DB, data entities.Transaction, typee string) error {
-	ret := _m.Called(db, data, typee)
+// CreateTranscation provides a mock function with given fields: db, data
+func (_m *TransactionRepo) CreateTranscation(db *gorm.DB, data entities.Transaction) error {
+	ret := _m.Called(db, data)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Transaction, string) error); ok {
-		r0 = rf(db, data, typee)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.Transaction) error); ok {
+		r0 = rf(db, data)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -94,6 +94,32 @@ func (_m *TransactionRepo) GetCart(db *gorm.DB, schid int, userid int) (*entitie
 	return r0, r1
 }
 
+// GetRegistrationFee provides a mock function with given fields: db, schid, uid
+func (_m *TransactionRepo) GetRegistrationFee(db *gorm.DB, schid int, uid int) (*int, error) {
+	ret := _m.Called(db, schid, uid)
+
+	var r0 *int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) (*int, error)); ok {
+		return rf(db, schid, uid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) *int); ok {
+		r0 = rf(db, schid, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, int) error); ok {
+		r1 = rf(db, schid, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSchoolPayment provides a mock function with given fields: db, schid
 func (_m *TransactionRepo) GetSchoolPayment(db *gorm.DB, schid int) (*entities.School, error) {
 	ret := _m.Called(db, schid)
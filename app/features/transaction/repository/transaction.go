@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/education-hub/BE/app/admission"
 	entity "github.com/education-hub/BE/app/entities"
 	"github.com/education-hub/BE/errorr"
 	"github.com/sirupsen/logrus"
@@ -13,7 +14,7 @@ type (
 	}
 
 	TransactionRepo interface {
-		CreateTranscation(db *gorm.DB, data entity.Transaction, typee string) error
+		CreateTranscation(db *gorm.DB, data entity.Transaction) error
 		GetTransaction(db *gorm.DB, schoolid int, userid int) (*entity.Transaction, error)
 		GetAllCartByuid(db *gorm.DB, uid int) ([]entity.Carts, error)
 		GetCart(db *gorm.DB, schid int, userid int) (*entity.Carts, error)
@@ -21,6 +22,7 @@ type (
 		UpdateStatus(db *gorm.DB, invoice string, status string) error
 		GetSchoolPayment(db *gorm.DB, schid int) (*entity.School, error)
 		GetTransactionByInvoice(db *gorm.DB, invoice string) (*entity.Transaction, error)
+		GetRegistrationFee(db *gorm.DB, schid int, uid int) (*int, error)
 	}
 )
 
@@ -28,24 +30,12 @@ func NewTransactionRepo(log *logrus.Logger) TransactionRepo {
 	return &transaction{log: log}
 }
 
-func (t *transaction) CreateTranscation(db *gorm.DB, data entity.Transaction, typee string) error {
-	return db.Transaction(func(db *gorm.DB) error {
-		if err := db.Create(&data).Error; err != nil {
-			t.log.Errorf("[ERROR]WHEN Creating Transaction Data, Err: %v", err)
-			return errorr.NewInternal("Internal Server Error")
-		}
-		status := ""
-		if typee == "registration" {
-			status = "Send Detail Costs Registration"
-		} else {
-			status = "Send Detail Costs Her-Registration"
-		}
-		if err := db.Model(&entity.Progress{}).Where("user_id=? AND school_id=? AND status != 'Finish' AND status != 'Failed Test Result' AND status != 'Failed File Approved'", data.UserID, data.SchoolID).Update("status", status).Error; err != nil {
-			t.log.Errorf("[ERROR]WHEN UPDATING PROGRESS STUDENT, Err : %v", err)
-			return errorr.NewInternal("Internal Server Error")
-		}
-		return nil
-	})
+func (t *transaction) CreateTranscation(db *gorm.DB, data entity.Transaction) error {
+	if err := db.Create(&data).Error; err != nil {
+		t.log.Errorf("[ERROR]WHEN Creating Transaction Data, Err: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
 }
 
 func (t *transaction) GetTransaction(db *gorm.DB, schoolid int, userid int) (*entity.Transaction, error) {
@@ -132,3 +122,16 @@ func (t *transaction) GetSchoolPayment(db *gorm.DB, schid int) (*entity.School,
 	}
 	return &res, nil
 }
+
+// GetRegistrationFee returns the registration fee of the admission period the student applied in, nil when it has none.
+func (t *transaction) GetRegistrationFee(db *gorm.DB, schid int, uid int) (*int, error) {
+	res := []*int{}
+	if err := db.Model(&entity.AdmissionPeriod{}).Joins("JOIN progresses p ON p.period_id = admission_periods.id").Where("p.user_id=? AND p.school_id=? AND p.status NOT IN ? AND p.deleted_at IS NULL", uid, schid, admission.Closed).Pluck("admission_periods.registration_fee", &res).Error; err != nil {
+		t.log.Errorf("[ERROR]WHEN GETTING REGISTRATION FEE, Err: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return res[0], nil
+}
@@ -6,8 +6,8 @@ import (
 	"os"
 	"testing"
 
+	mocks "github.com/education-hub/BE/app/admission/mocks"
 	"github.com/education-hub/BE/app/entities"
-	mocks "github.com/education-hub/BE/app/features/school/mocks/repository"
 	mocksuu "github.com/education-hub/BE/app/features/transaction/mocks/repository"
 	transaction "github.com/education-hub/BE/app/features/transaction/service"
 	mocksu "github.com/education-hub/BE/app/features/user/mocks/repository"
@@ -26,7 +26,7 @@ func TestService(t *testing.T) {
 }
 
 var _ = Describe("transaction", func() {
-	var Mock *mocks.SchoolRepo
+	var Mock *mocks.Workflow
 	var Mocks *mocksu.UserRepo
 	var Mockss *mocksuu.TransactionRepo
 	var TransactionService transaction.TransactionService
@@ -38,7 +38,7 @@ var _ = Describe("transaction", func() {
 		log := logrus.New()
 		Depend.Log = log
 		ctx = context.Background()
-		Mock = mocks.NewSchoolRepo(GinkgoT())
+		Mock = mocks.NewWorkflow(GinkgoT())
 		Mocks = mocksu.NewUserRepo(GinkgoT())
 		Mockss = mocksuu.NewTransactionRepo(GinkgoT())
 		TransactionService = transaction.NewTransactionService(Mockss, Depend, Mocks, Mock)
@@ -67,6 +67,18 @@ var _ = Describe("transaction", func() {
 				Expect(err).ShouldNot(BeNil())
 			})
 		})
+		When("Registrasi Gratis", func() {
+			BeforeEach(func() {
+				Mockss.On("GetCart", mock.Anything, 1, 1).Return(&entities.Carts{Type: "registration"}, nil).Once()
+				fee := 0
+				Mockss.On("GetRegistrationFee", mock.Anything, 1, 1).Return(&fee, nil).Once()
+			})
+			It("Tidak Ada Yang Perlu Dibayar", func() {
+				_, err := TransactionService.CreateTransaction(ctx, entities.ReqCheckout{SchoolID: 1, Type: "registration", PaymentMethod: "bca"}, 1)
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(Equal("Registration at this school is free, there is nothing to pay"))
+			})
+		})
 		When("Terjadi kesalah query database pada saat mengambil data payment school", func() {
 			BeforeEach(func() {
 				Mockss.On("GetSchoolPayment", mock.Anything, mock.Anything).Return(nil, errors.New("Error")).Once()
@@ -81,7 +93,7 @@ var _ = Describe("transaction", func() {
 			BeforeEach(func() {
 				data := []entities.Payment{entities.Payment{Description: "Tool", Price: 1000}}
 				Mockss.On("GetSchoolPayment", mock.Anything, mock.Anything).Return(&entities.School{Payments: data}, nil).Once()
-				Mockss.On("CreateTranscation", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("Error")).Once()
+				Mockss.On("CreateTranscation", mock.Anything, mock.Anything).Return(errors.New("Error")).Once()
 			})
 			It("Akan Mengembalikan Erorr", func() {
 				_, err := TransactionService.CreateTransaction(ctx, entities.ReqCheckout{SchoolID: 1, Type: "herregistration", PaymentMethod: "bca"}, 1)
@@ -93,7 +105,7 @@ var _ = Describe("transaction", func() {
 			BeforeEach(func() {
 				data := []entities.Payment{entities.Payment{Description: "Tool", Price: 1000}}
 				Mockss.On("GetSchoolPayment", mock.Anything, mock.Anything).Return(&entities.School{Payments: data}, nil).Once()
-				Mockss.On("CreateTranscation", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				Mockss.On("CreateTranscation", mock.Anything, mock.Anything).Return(nil).Once()
 			})
 			It("Akan Mengembalikan Data Transaksi", func() {
 				res, err := TransactionService.CreateTransaction(ctx, entities.ReqCheckout{SchoolID: 1, Type: "herregistration", PaymentMethod: "indomaret"}, 1)
@@ -150,10 +162,38 @@ var _ = Describe("transaction", func() {
 			BeforeEach(func() {
 				Mockss.On("GetTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("Data Not Found")).Once()
 				Mockss.On("GetCart", mock.Anything, mock.Anything, mock.Anything).Return(&entities.Carts{Type: "registration"}, nil).Once()
+				Mockss.On("GetRegistrationFee", mock.Anything, 2, 1).Return(nil, nil).Once()
 			})
 			It("Akan Mengembalikan Data Cart Registrasi", func() {
-				_, err := TransactionService.GetDetailTransaction(ctx, 2, 1)
+				res, err := TransactionService.GetDetailTransaction(ctx, 2, 1)
+				Expect(err).Should(BeNil())
+				Expect(res.(entities.ResDetailRegisCart).Total).To(Equal(200000))
+			})
+		})
+		When("Periode Pendaftaran Mengganti Biaya Registrasi", func() {
+			BeforeEach(func() {
+				Mockss.On("GetTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("Data Not Found")).Once()
+				Mockss.On("GetCart", mock.Anything, mock.Anything, mock.Anything).Return(&entities.Carts{Type: "registration"}, nil).Once()
+				fee := 150000
+				Mockss.On("GetRegistrationFee", mock.Anything, 2, 1).Return(&fee, nil).Once()
+			})
+			It("Akan Mengembalikan Biaya Periode", func() {
+				res, err := TransactionService.GetDetailTransaction(ctx, 2, 1)
+				Expect(err).Should(BeNil())
+				Expect(res.(entities.ResDetailRegisCart).Total).To(Equal(150000))
+			})
+		})
+		When("Periode Pendaftaran Menggratiskan Registrasi", func() {
+			BeforeEach(func() {
+				Mockss.On("GetTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("Data Not Found")).Once()
+				Mockss.On("GetCart", mock.Anything, mock.Anything, mock.Anything).Return(&entities.Carts{Type: "registration"}, nil).Once()
+				fee := 0
+				Mockss.On("GetRegistrationFee", mock.Anything, 2, 1).Return(&fee, nil).Once()
+			})
+			It("Total Biaya Akan Nol", func() {
+				res, err := TransactionService.GetDetailTransaction(ctx, 2, 1)
 				Expect(err).Should(BeNil())
+				Expect(res.(entities.ResDetailRegisCart).Total).To(Equal(0))
 			})
 		})
 		When("Jika Terdapat Data Cart Dan tipenya Her Registration", func() {
@@ -5,8 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/education-hub/BE/app/admission"
+	"github.com/education-hub/BE/app/audit"
 	entity "github.com/education-hub/BE/app/entities"
-	school "github.com/education-hub/BE/app/features/school/repository"
 	"github.com/education-hub/BE/app/features/transaction/repository"
 	user "github.com/education-hub/BE/app/features/user/repository"
 	"github.com/education-hub/BE/config/dependency"
@@ -16,13 +17,16 @@ import (
 	"github.com/midtrans/midtrans-go"
 )
 
+// defaultRegistrationFee is charged when the admission period does not override it.
+const defaultRegistrationFee = 200000
+
 type (
 	transaction struct {
 		repo       repository.TransactionRepo
 		validator  *validator.Validate
 		dep        dependency.Depend
 		userrepo   user.UserRepo
-		schoolrepo school.SchoolRepo
+		workflow   admission.Workflow
 	}
 	TransactionService interface {
 		CreateTransaction(ctx context.Context, req entity.ReqCheckout, uid int) (*entity.ResTransaction, error)
@@ -32,13 +36,13 @@ type (
 	}
 )
 
-func NewTransactionService(repo repository.TransactionRepo, dep dependency.Depend, userrepo user.UserRepo, sch school.SchoolRepo) TransactionService {
+func NewTransactionService(repo repository.TransactionRepo, dep dependency.Depend, userrepo user.UserRepo, workflow admission.Workflow) TransactionService {
 	return &transaction{
 		repo:       repo,
 		dep:        dep,
 		validator:  validator.New(),
 		userrepo:   userrepo,
-		schoolrepo: sch,
+		workflow:   workflow,
 	}
 }
 
@@ -62,9 +66,18 @@ func (t *transaction) CreateTransaction(ctx context.Context, req entity.ReqCheck
 		return nil, errorr.NewBad("Invalid Req Body")
 	}
 	if req.Type == "registration" {
-		total = 200000
-		itemdetails = append(itemdetails, midtrans.ItemDetails{ID: "1", Name: "First Registration", Price: 200000, Qty: 1})
-		transactionitems = append(transactionitems, entity.TransactionItems{ItemName: "First Registration", ItemPrice: 200000, TransactionInvoice: invoice})
+		fee, err := t.registrationFee(ctx, req.SchoolID, uid)
+		if err != nil {
+			t.dep.PromErr["error"] = err.Error()
+			return nil, err
+		}
+		if fee == 0 {
+			t.dep.PromErr["error"] = "registration is free"
+			return nil, errorr.NewBad("Registration at this school is free, there is nothing to pay")
+		}
+		total = fee
+		itemdetails = append(itemdetails, midtrans.ItemDetails{ID: "1", Name: "First Registration", Price: int64(fee), Qty: 1})
+		transactionitems = append(transactionitems, entity.TransactionItems{ItemName: "First Registration", ItemPrice: fee, TransactionInvoice: invoice})
 	} else {
 		data, err := t.repo.GetSchoolPayment(t.dep.Db.WithContext(ctx), req.SchoolID)
 		if err != nil {
@@ -114,16 +127,12 @@ func (t *transaction) CreateTransaction(ctx context.Context, req entity.ReqCheck
 		TransactionItems: transactionitems,
 	}
 
-	if err := t.repo.CreateTranscation(t.dep.Db.WithContext(ctx), trxdata, req.Type); err != nil {
+	if err := t.repo.CreateTranscation(t.dep.Db.WithContext(ctx), trxdata); err != nil {
 		t.dep.PromErr["error"] = err.Error()
 		return nil, err
 	}
 	userdetail, _ := t.userrepo.GetById(t.dep.Db.WithContext(ctx), uid)
-	encodeddata, _ := json.Marshal(map[string]any{"invoice": invoice, "total": total, "name": userdetail.FirstName + " " + userdetail.SureName, "email": userdetail.Email, "payment_code": res.PaymentCode, "payment_method": req.PaymentMethod, "expire": res.Expire})
-	err = t.dep.Nsq.Publish("1", encodeddata)
-	if err != nil {
-		t.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
-	}
+	t.mail(ctx, "1", uid, map[string]any{"invoice": invoice, "total": total, "name": userdetail.FirstName + " " + userdetail.SureName, "email": userdetail.Email, "payment_code": res.PaymentCode, "payment_method": req.PaymentMethod, "expire": res.Expire})
 	return &entity.ResTransaction{Invoice: invoice, PaymentMethod: req.PaymentMethod, Total: total, PaymentCode: res.PaymentCode, ExpireDate: res.Expire}, nil
 }
 
@@ -163,7 +172,12 @@ func (t *transaction) GetDetailTransaction(ctx context.Context, schid, uid int)
 		return nil, err
 	}
 	if trxcart.Type == "registration" {
-		return entity.ResDetailRegisCart{ItemName: "First Registraion", ItemPrice: 200000, Type: "registration", Total: 200000}, nil
+		fee, err := t.registrationFee(ctx, schid, uid)
+		if err != nil {
+			t.dep.PromErr["error"] = err.Error()
+			return nil, err
+		}
+		return entity.ResDetailRegisCart{ItemName: "First Registraion", ItemPrice: fee, Type: "registration", Total: fee}, nil
 	}
 	total := 0
 	restrx := entity.ResDetailHerRegisCart{Type: "herregistration"}
@@ -185,12 +199,13 @@ func (t *transaction) UpdateStatus(ctx context.Context, status, invoice string)
 	if err != nil {
 		return err
 	}
-	encodeddata, _ := json.Marshal(map[string]any{"invoice": invoice, "email": trxdata.User.Email, "name": trxdata.User.FirstName + " " + trxdata.User.SureName})
+	maildata := map[string]any{"invoice": invoice, "email": trxdata.User.Email, "name": trxdata.User.FirstName + " " + trxdata.User.SureName}
 	cartdata, err := t.repo.GetCart(t.dep.Db.WithContext(ctx), int(trxdata.SchoolID), int(trxdata.UserID))
 	if err != nil {
 		t.dep.Log.Errorf("[ERROR]WHEN GETTING CART DATA,Err : %v", err)
 		return err
 	}
+	audit.AtSchool(ctx, trxdata.SchoolID)
 	switch status {
 	case "paid":
 		err := t.repo.UpdateStatus(t.dep.Db.WithContext(ctx), invoice, "paid")
@@ -198,37 +213,14 @@ func (t *transaction) UpdateStatus(ctx context.Context, status, invoice string)
 			t.dep.Log.Errorf("[ERROR]WHEN UPDATING TRASACTION STATUS,Err : %v", err)
 			return err
 		}
+		progstatus := admission.AlreadyPaidHerRegistration
 		if cartdata.Type == "registration" {
-			id, err := t.schoolrepo.UpdateProgressByUid(t.dep.Db.WithContext(ctx), int(trxdata.UserID), int(trxdata.SchoolID), "Done Payment")
-			if err != nil {
-				t.dep.Log.Errorf("[ERROR]WHEN UPDATING PROGRESS STATUS,Err : %v", err)
-			}
-			if err := t.dep.Pusher.Publish(map[string]any{"progress_id": id, "status": "Done Payment"}, 3); err != nil {
-				t.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
-			}
-			if err := t.dep.Pusher.Publish(map[string]any{"progress_id": id, "status": "Done Payment", "username": trxdata.User.Username}, 2); err != nil {
-				t.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
-			}
-		} else {
-			id, err := t.schoolrepo.UpdateProgressByUid(t.dep.Db.WithContext(ctx), int(trxdata.UserID), int(trxdata.SchoolID), "Already Paid Her-Registration")
-			if err != nil {
-
-				t.dep.Log.Errorf("[ERROR]WHEN UPDATING PROGRESS STATUS,Err : %v", err)
-			}
-			if err := t.dep.Pusher.Publish(map[string]any{"progress_id": id, "status": "Already Paid Her-Registration"}, 3); err != nil {
-				t.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
-			}
-			if err := t.dep.Pusher.Publish(map[string]any{"progress_id": id, "status": "Already Paid Her-Registration", "username": trxdata.User.Username}, 2); err != nil {
-				t.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
-			}
+			progstatus = admission.DonePayment
 		}
-		go func() {
-			encodeddata, _ := json.Marshal(map[string]any{"invoice": invoice, "email": trxdata.User.Email, "name": trxdata.User.FirstName + " " + trxdata.User.SureName})
-			if err := t.dep.Nsq.Publish("2", encodeddata); err != nil {
-
-				t.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
-			}
-		}()
+		if _, err := t.workflow.UpdateProgressByUid(ctx, int(trxdata.UserID), int(trxdata.SchoolID), progstatus, "Payment "+invoice+" paid"); err != nil {
+			t.dep.Log.Errorf("[ERROR]WHEN UPDATING PROGRESS STATUS,Err : %v", err)
+		}
+		t.mail(ctx, "2", int(trxdata.UserID), maildata)
 		err = t.repo.DeleteCart(t.dep.Db.WithContext(ctx), int(trxdata.SchoolID), int(trxdata.UserID))
 		if err != nil {
 
@@ -245,30 +237,40 @@ func (t *transaction) UpdateStatus(ctx context.Context, status, invoice string)
 			t.dep.Log.Errorf("[ERROR]WHEN DELETE CART,Err : %v", err)
 			return err
 		}
-		status = ""
-		progstatus := ""
-		if cartdata.Type == "registration" {
-			status = "File Approved"
-			progstatus = "Failed Done Payment"
-		} else {
-			status = "Test Result"
-			progstatus = "Failed Already Paid Her-Registration"
-		}
-		progid, err := t.schoolrepo.UpdateProgressByUid(t.dep.Db.WithContext(ctx), int(trxdata.UserID), int(trxdata.SchoolID), status)
-		if err != nil {
+		if _, err := t.workflow.RevertProgressByUid(ctx, int(trxdata.UserID), int(trxdata.SchoolID), "Payment "+invoice+" cancelled"); err != nil {
 			t.dep.Log.Errorf("[ERROR]WHEN UPDATING PROGRESS STATUS,Err : %v", err)
 		}
-		go func() {
-			if err := t.dep.Nsq.Publish("3", encodeddata); err != nil {
-				t.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
-			}
-		}()
-		if err := t.dep.Pusher.Publish(map[string]any{"progress_id": progid, "status": progstatus}, 3); err != nil {
-			t.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
-		}
-		if err := t.dep.Pusher.Publish(map[string]any{"progress_id": progid, "status": progstatus, "username": trxdata.User.Username}, 2); err != nil {
-			t.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
-		}
+		t.mail(ctx, "3", int(trxdata.UserID), maildata)
 	}
+	audit.Track(ctx, audit.Change{Type: "transaction", ID: invoice, Before: map[string]any{"status": trxdata.Status}, After: map[string]any{"status": status}})
 	return nil
 }
+
+func (t *transaction) registrationFee(ctx context.Context, schid int, uid int) (int, error) {
+	fee, err := t.repo.GetRegistrationFee(t.dep.Db.WithContext(ctx), schid, uid)
+	if err != nil {
+		return 0, err
+	}
+	if fee == nil {
+		return defaultRegistrationFee, nil
+	}
+	return *fee, nil
+}
+
+// mail sends a payment mail to the student and a copy to each of its linked parents, who may be the ones paying.
+func (t *transaction) mail(ctx context.Context, topic string, uid int, data map[string]any) {
+	encodeddata, _ := json.Marshal(data)
+	messages := [][]byte{encodeddata}
+	parents, err := t.userrepo.GetParents(t.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		t.dep.Log.Errorf("[ERROR]WHEN GETTING PARENTS DATA,Err : %v", err)
+	}
+	for _, parent := range parents {
+		messages = append(messages, admission.ParentMail(data, parent))
+	}
+	go func() {
+		if err := t.dep.Nsq.MultiPublish(topic, messages); err != nil {
+			t.dep.Log.Errorf("Failed to publish to NSQ: %v", err)
+		}
+	}()
+}
\ No newline at end of file
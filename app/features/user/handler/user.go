@@ -3,9 +3,12 @@ package handler
 import (
 	"mime/multipart"
 	"net/http"
+	"strconv"
 
 	entity "github.com/education-hub/BE/app/entities"
 	"github.com/education-hub/BE/app/features/user/service"
+	"github.com/education-hub/BE/app/oidc"
+	"github.com/education-hub/BE/app/session"
 	"github.com/education-hub/BE/config/dependency"
 	"github.com/education-hub/BE/helper"
 	"github.com/golang-jwt/jwt"
@@ -16,24 +19,148 @@ import (
 type User struct {
 	dig.In
 	Service service.UserService
+	Session session.Manager
+	OIDC    oidc.Flow
 	Dep     dependency.Depend
 }
 
 func (u *User) Login(c echo.Context) error {
 	var req entity.LoginReq
-	var token string
 	if err := c.Bind(&req); err != nil {
 		c.Set("err", err.Error())
 		u.Dep.Log.Errorf("[ERROR] WHEN BINDING LOGIN, ERROR: %v", err)
 		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
 	}
+	req.IP = c.RealIP()
 	user, err := u.Service.Login(c.Request().Context(), req)
 	if err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
 	}
-	token = helper.GenerateJWT(int(user.ID), user.Role, "true", u.Dep)
-	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"token": token, "role": user.Role, "username": user.Username}))
+	return u.signIn(c, user)
+}
+
+// GoogleLogin returns the url of the Google sign in page, role is the one a new account is created with.
+func (u *User) GoogleLogin(c echo.Context) error {
+	url, err := u.OIDC.Begin(c.Request().Context(), c.QueryParam("role"))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"url": url}))
+}
+
+// GoogleCallback takes the state and the code Google sent back to the frontend.
+func (u *User) GoogleCallback(c echo.Context) error {
+	req := entity.ReqSocialLogin{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING GOOGLE LOGIN, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	identity, role, err := u.OIDC.Finish(c.Request().Context(), req.State, req.Code)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	user, err := u.Service.SocialLogin(c.Request().Context(), *identity, role)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return u.signIn(c, user)
+}
+
+// signIn issues the tokens of a user whose first step of the login passed, or the challenge
+// of the second step when the user enabled it.
+func (u *User) signIn(c echo.Context, user *entity.User) error {
+	if user.TOTPEnabled {
+		// the password is right, the tokens are only issued once the code passes at /login/2fa
+		challenge, err := u.Session.Challenge(c.Request().Context(), int(user.ID))
+		if err != nil {
+			c.Set("err", u.Dep.PromErr["error"])
+			return CreateErrorResponse(err, c)
+		}
+		return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"mfa_required": true, "challenge": challenge, "expires_in": int(session.ChallengeTTL.Seconds())}))
+	}
+	tokens, err := u.Session.Create(c.Request().Context(), user, false)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"token": tokens.Token, "refresh_token": tokens.RefreshToken, "expires_in": tokens.ExpiresIn, "role": user.Role, "username": user.Username, "mfa_required": false, "mfa_setup_required": service.RequiresTwoFactor(user.Role)}))
+}
+
+func (u *User) LoginTwoFactor(c echo.Context) error {
+	req := entity.ReqLoginTwoFactor{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING LOGIN TWO FACTOR, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	if req.Challenge == "" {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Missing Challenge", nil))
+	}
+	uid, err := u.Session.GetChallenge(c.Request().Context(), req.Challenge)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	user, err := u.Service.VerifyTwoFactor(c.Request().Context(), uid, req.Code)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		// the store logs its own failure, the wrong code is what the user has to know about
+		u.Session.FailChallenge(c.Request().Context(), req.Challenge)
+		return CreateErrorResponse(err, c)
+	}
+	if err := u.Session.CloseChallenge(c.Request().Context(), req.Challenge); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	tokens, err := u.Session.Create(c.Request().Context(), user, true)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"token": tokens.Token, "refresh_token": tokens.RefreshToken, "expires_in": tokens.ExpiresIn, "role": user.Role, "username": user.Username, "mfa_required": false, "mfa_setup_required": false}))
+}
+
+func (u *User) Refresh(c echo.Context) error {
+	req := struct {
+		RefreshToken string `json:"refresh_token"`
+	}{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING REFRESH, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	if req.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Missing Refresh Token", nil))
+	}
+	tokens, err := u.Session.Refresh(c.Request().Context(), req.RefreshToken)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", tokens))
+}
+
+func (u *User) Logout(c echo.Context) error {
+	token := c.Get("user").(*jwt.Token)
+	if err := u.Session.Revoke(c.Request().Context(), helper.GetUid(token), helper.GetSession(token)); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
+
+// LogoutAll ends the sessions of the user on every device, the current one included.
+func (u *User) LogoutAll(c echo.Context) error {
+	if err := u.Session.RevokeAll(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
 }
 
 func (u *User) Register(c echo.Context) error {
@@ -74,6 +201,25 @@ func (u *User) UpdateVerif(c echo.Context) error {
 	return c.Redirect(http.StatusFound, URLFRONTENDUPDATE)
 }
 
+func (u *User) ResendVerification(c echo.Context) error {
+	req := struct {
+		Email string `json:"email"`
+	}{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING RESEND VERIFICATION, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	if req.Email == "" {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Missing Email Request", nil))
+	}
+	if err := u.Service.ResendVerification(c.Request().Context(), req.Email); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
+
 func (u *User) Forgotpass(c echo.Context) error {
 	req := struct {
 		Email string `json:"email"`
@@ -168,25 +314,150 @@ func (u *User) Update(c echo.Context) error {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
 	}
-	newtoken := ""
+	newtoken, refreshtoken := "", ""
 	if req.Email != "" {
-		newtoken = helper.GenerateJWT(int(data.ID), data.Role, "false", u.Dep)
+		// the sessions were opened with the old email, the new one has to be verified first
+		if err := u.Session.RevokeAll(c.Request().Context(), req.Id); err != nil {
+			c.Set("err", u.Dep.PromErr["error"])
+			return CreateErrorResponse(err, c)
+		}
+		unverified := *data
+		unverified.IsVerified = false
+		tokens, err := u.Session.Create(c.Request().Context(), &unverified, helper.GetMFA(c.Get("user").(*jwt.Token)) == "true")
+		if err != nil {
+			c.Set("err", u.Dep.PromErr["error"])
+			return CreateErrorResponse(err, c)
+		}
+		newtoken, refreshtoken = tokens.Token, tokens.RefreshToken
 	}
 	res := map[string]any{
-		"username": data.Username,
-		"fname":    data.FirstName,
-		"sname":    data.SureName,
-		"address":  data.Address,
-		"image":    data.Image,
-		"password": data.Password,
-		"email":    data.Email,
-		"token":    newtoken,
+		"username":      data.Username,
+		"fname":         data.FirstName,
+		"sname":         data.SureName,
+		"address":       data.Address,
+		"image":         data.Image,
+		"password":      data.Password,
+		"email":         data.Email,
+		"token":         newtoken,
+		"refresh_token": refreshtoken,
 	}
 	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
 }
 
 func (u *User) Delete(c echo.Context) error {
-	if err := u.Service.Delete(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token))); err != nil {
+	uid := helper.GetUid(c.Get("user").(*jwt.Token))
+	if err := u.Service.Delete(c.Request().Context(), uid); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	if err := u.Session.RevokeAll(c.Request().Context(), uid); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
+
+// Export sends everything kept about the user as a zip archive.
+func (u *User) Export(c echo.Context) error {
+	res, err := u.Service.Export(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=my-data.zip")
+	return c.Blob(http.StatusOK, "application/zip", res)
+}
+
+func (u *User) EnrollTwoFactor(c echo.Context) error {
+	res, err := u.Service.EnrollTwoFactor(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+// EnableTwoFactor confirms the enrollment, the current session is swapped for one that passed the second step
+// so the user does not have to log in again.
+func (u *User) EnableTwoFactor(c echo.Context) error {
+	req := entity.ReqTwoFactorCode{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING ENABLE TWO FACTOR, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	token := c.Get("user").(*jwt.Token)
+	uid := helper.GetUid(token)
+	codes, err := u.Service.EnableTwoFactor(c.Request().Context(), uid, req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	user, err := u.Service.GetProfile(c.Request().Context(), uid)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	if err := u.Session.Revoke(c.Request().Context(), uid, helper.GetSession(token)); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	tokens, err := u.Session.Create(c.Request().Context(), user, true)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", map[string]any{"recovery_codes": codes.RecoveryCodes, "token": tokens.Token, "refresh_token": tokens.RefreshToken, "expires_in": tokens.ExpiresIn}))
+}
+
+func (u *User) DisableTwoFactor(c echo.Context) error {
+	req := entity.ReqTwoFactorCode{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING DISABLE TWO FACTOR, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	if err := u.Service.DisableTwoFactor(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
+
+func (u *User) RegenerateRecoveryCodes(c echo.Context) error {
+	req := entity.ReqTwoFactorCode{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING RECOVERY CODES, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	res, err := u.Service.RegenerateRecoveryCodes(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+// ResetTwoFactor is the super admin removing the second step of a user who lost it, the user is logged out everywhere.
+func (u *User) ResetTwoFactor(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Id", nil))
+	}
+	if err := u.Service.ResetTwoFactor(c.Request().Context(), id); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
+
+func (u *User) UnlockLogin(c echo.Context) error {
+	username := c.Param("username")
+	if username == "" {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Missing Username", nil))
+	}
+	if err := u.Service.UnlockLogin(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), username); err != nil {
 		c.Set("err", u.Dep.PromErr["error"])
 		return CreateErrorResponse(err, c)
 	}
@@ -210,3 +481,125 @@ func (u *User) GetProfile(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
 }
+
+func (u *User) GetStudentProfile(c echo.Context) error {
+	res, err := u.Service.GetStudentProfile(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *User) UpdateStudentProfile(c echo.Context) error {
+	req := entity.ReqStudentProfile{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING UpdateStudentProfile, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	files := map[string]multipart.File{}
+	filenames := map[string]*string{"student_photo": &req.StudentPhoto, "student_signature": &req.StudentSignature, "parent_signature": &req.ParentSignature}
+	for field, filename := range filenames {
+		head, err := c.FormFile(field)
+		if err != nil {
+			continue
+		}
+		if head.Size > 2*1024*1024 {
+			return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "File is too large. Maximum size is 2MB.", nil))
+		}
+		file, err := head.Open()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Cannot Load Image", nil))
+		}
+		*filename = head.Filename
+		files[field] = file
+	}
+	res, err := u.Service.UpdateStudentProfile(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req, files)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *User) InviteChild(c echo.Context) error {
+	req := entity.ReqInviteChild{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING InviteChild, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	res, err := u.Service.InviteChild(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusCreated, CreateWebResponse(http.StatusCreated, "Status Created", res))
+}
+
+func (u *User) GetChildren(c echo.Context) error {
+	res, err := u.Service.GetChildren(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *User) GetParents(c echo.Context) error {
+	res, err := u.Service.GetParentLinks(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)))
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *User) RespondParent(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Id", nil))
+	}
+	req := entity.ReqRespondParent{}
+	if err := c.Bind(&req); err != nil {
+		c.Set("err", err.Error())
+		u.Dep.Log.Errorf("[ERROR] WHEN BINDING RespondParent, ERROR: %v", err)
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Request Body", nil))
+	}
+	res, err := u.Service.RespondParentLink(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), id, req)
+	if err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", res))
+}
+
+func (u *User) DeleteParentLink(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Id", nil))
+	}
+	if err := u.Service.DeleteParentLink(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), id); err != nil {
+		c.Set("err", u.Dep.PromErr["error"])
+		return CreateErrorResponse(err, c)
+	}
+	return c.JSON(http.StatusOK, CreateWebResponse(http.StatusOK, "Success Operation", nil))
+}
+
+// ChildMiddleWare lets a parent reach the student routes of a linked child, the handlers read the
+// child through helper.GetStudentUid.
+func (u *User) ChildMiddleWare(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		child, err := strconv.Atoi(c.Param("child"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, CreateWebResponse(http.StatusBadRequest, "Invalid Student Id", nil))
+		}
+		if err := u.Service.CheckChild(c.Request().Context(), helper.GetUid(c.Get("user").(*jwt.Token)), child); err != nil {
+			c.Set("err", u.Dep.PromErr["error"])
+			return CreateErrorResponse(err, c)
+		}
+		c.Set("child", child)
+		return next(c)
+	}
+}
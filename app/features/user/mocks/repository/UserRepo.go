@@ -7,6 +7,8 @@ import (
 	gorm "gorm.io/gorm"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserRepo is an autogenerated mock type for the UserRepo type
@@ -14,6 +16,58 @@ type UserRepo struct {
 	mock.Mock
 }
 
+// AcceptParentLink provides a mock function with given fields: db, link
+func (_m *UserRepo) AcceptParentLink(db *gorm.DB, link entities.ParentLink) error {
+	ret := _m.Called(db, link)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.ParentLink) error); ok {
+		r0 = rf(db, link)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Anonymize provides a mock function with given fields: db, user
+func (_m *UserRepo) Anonymize(db *gorm.DB, user entities.User) error {
+	ret := _m.Called(db, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.User) error); ok {
+		r0 = rf(db, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountTokensSince provides a mock function with given fields: db, model, email, since
+func (_m *UserRepo) CountTokensSince(db *gorm.DB, model interface{}, email string, since time.Time) (int64, error) {
+	ret := _m.Called(db, model, email, since)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, interface{}, string, time.Time) (int64, error)); ok {
+		return rf(db, model, email, since)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, interface{}, string, time.Time) int64); ok {
+		r0 = rf(db, model, email, since)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, interface{}, string, time.Time) error); ok {
+		r1 = rf(db, model, email, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: db, user
 func (_m *UserRepo) Create(db *gorm.DB, user entities.User) error {
 	ret := _m.Called(db, user)
@@ -28,6 +82,72 @@ func (_m *UserRepo) Create(db *gorm.DB, user entities.User) error {
 	return r0
 }
 
+// CreateIdentity provides a mock function with given fields: db, identity
+func (_m *UserRepo) CreateIdentity(db *gorm.DB, identity entities.UserIdentity) error {
+	ret := _m.Called(db, identity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.UserIdentity) error); ok {
+		r0 = rf(db, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateParentLink provides a mock function with given fields: db, link
+func (_m *UserRepo) CreateParentLink(db *gorm.DB, link entities.ParentLink) (*entities.ParentLink, error) {
+	ret := _m.Called(db, link)
+
+	var r0 *entities.ParentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.ParentLink) (*entities.ParentLink, error)); ok {
+		return rf(db, link)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.ParentLink) *entities.ParentLink); ok {
+		r0 = rf(db, link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ParentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.ParentLink) error); ok {
+		r1 = rf(db, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWithIdentity provides a mock function with given fields: db, user, identity
+func (_m *UserRepo) CreateWithIdentity(db *gorm.DB, user entities.User, identity entities.UserIdentity) (*entities.User, error) {
+	ret := _m.Called(db, user, identity)
+
+	var r0 *entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.User, entities.UserIdentity) (*entities.User, error)); ok {
+		return rf(db, user, identity)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.User, entities.UserIdentity) *entities.User); ok {
+		r0 = rf(db, user, identity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.User, entities.UserIdentity) error); ok {
+		r1 = rf(db, user, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: db, user
 func (_m *UserRepo) Delete(db *gorm.DB, user entities.User) error {
 	ret := _m.Called(db, user)
@@ -42,6 +162,20 @@ func (_m *UserRepo) Delete(db *gorm.DB, user entities.User) error {
 	return r0
 }
 
+// DeleteParentLink provides a mock function with given fields: db, id
+func (_m *UserRepo) DeleteParentLink(db *gorm.DB, id int) error {
+	ret := _m.Called(db, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) error); ok {
+		r0 = rf(db, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindByEmail provides a mock function with given fields: db, email
 func (_m *UserRepo) FindByEmail(db *gorm.DB, email string) (*entities.User, error) {
 	ret := _m.Called(db, email)
@@ -120,6 +254,188 @@ func (_m *UserRepo) GetById(db *gorm.DB, id int) (*entities.User, error) {
 	return r0, r1
 }
 
+// GetChildLinks provides a mock function with given fields: db, parentid
+func (_m *UserRepo) GetChildLinks(db *gorm.DB, parentid int) ([]entities.ParentLink, error) {
+	ret := _m.Called(db, parentid)
+
+	var r0 []entities.ParentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.ParentLink, error)); ok {
+		return rf(db, parentid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.ParentLink); ok {
+		r0 = rf(db, parentid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ParentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, parentid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdentity provides a mock function with given fields: db, provider, subject
+func (_m *UserRepo) GetIdentity(db *gorm.DB, provider string, subject string) (*entities.UserIdentity, error) {
+	ret := _m.Called(db, provider, subject)
+
+	var r0 *entities.UserIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string, string) (*entities.UserIdentity, error)); ok {
+		return rf(db, provider, subject)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string, string) *entities.UserIdentity); ok {
+		r0 = rf(db, provider, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.UserIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, string, string) error); ok {
+		r1 = rf(db, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetParentLink provides a mock function with given fields: db, id
+func (_m *UserRepo) GetParentLink(db *gorm.DB, id int) (*entities.ParentLink, error) {
+	ret := _m.Called(db, id)
+
+	var r0 *entities.ParentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.ParentLink, error)); ok {
+		return rf(db, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.ParentLink); ok {
+		r0 = rf(db, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ParentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetParentLinks provides a mock function with given fields: db, studentid
+func (_m *UserRepo) GetParentLinks(db *gorm.DB, studentid int) ([]entities.ParentLink, error) {
+	ret := _m.Called(db, studentid)
+
+	var r0 []entities.ParentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.ParentLink, error)); ok {
+		return rf(db, studentid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.ParentLink); ok {
+		r0 = rf(db, studentid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ParentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, studentid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetParents provides a mock function with given fields: db, studentid
+func (_m *UserRepo) GetParents(db *gorm.DB, studentid int) ([]entities.User, error) {
+	ret := _m.Called(db, studentid)
+
+	var r0 []entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.User, error)); ok {
+		return rf(db, studentid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.User); ok {
+		r0 = rf(db, studentid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, studentid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentProfile provides a mock function with given fields: db, uid
+func (_m *UserRepo) GetStudentProfile(db *gorm.DB, uid int) (*entities.StudentProfile, error) {
+	ret := _m.Called(db, uid)
+
+	var r0 *entities.StudentProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.StudentProfile, error)); ok {
+		return rf(db, uid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.StudentProfile); ok {
+		r0 = rf(db, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.StudentProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserData provides a mock function with given fields: db, uid
+func (_m *UserRepo) GetUserData(db *gorm.DB, uid int) (*entities.UserData, error) {
+	ret := _m.Called(db, uid)
+
+	var r0 *entities.UserData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.UserData, error)); ok {
+		return rf(db, uid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.UserData); ok {
+		r0 = rf(db, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.UserData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InsertForgotPassToken provides a mock function with given fields: db, req
 func (_m *UserRepo) InsertForgotPassToken(db *gorm.DB, req entities.ForgotPass) error {
 	ret := _m.Called(db, req)
@@ -134,13 +450,105 @@ func (_m *UserRepo) InsertForgotPassToken(db *gorm.DB, req entities.ForgotPass)
 	return r0
 }
 
-// ResetPass provides a mock function with given fields: db, newpass, token
-func (_m *UserRepo) ResetPass(db *gorm.DB, newpass string, token string) error {
-	ret := _m.Called(db, newpass, token)
+// InsertVerificationToken provides a mock function with given fields: db, req
+func (_m *UserRepo) InsertVerificationToken(db *gorm.DB, req entities.EmailVerification) error {
+	ret := _m.Called(db, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.EmailVerification) error); ok {
+		r0 = rf(db, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvalidateResetTokens provides a mock function with given fields: db, email
+func (_m *UserRepo) InvalidateResetTokens(db *gorm.DB, email string) error {
+	ret := _m.Called(db, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) error); ok {
+		r0 = rf(db, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsParentOf provides a mock function with given fields: db, parentid, studentid
+func (_m *UserRepo) IsParentOf(db *gorm.DB, parentid int, studentid int) (bool, error) {
+	ret := _m.Called(db, parentid, studentid)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) (bool, error)); ok {
+		return rf(db, parentid, studentid)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int) bool); ok {
+		r0 = rf(db, parentid, studentid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int, int) error); ok {
+		r1 = rf(db, parentid, studentid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PendingErasures provides a mock function with given fields: db, limit
+func (_m *UserRepo) PendingErasures(db *gorm.DB, limit int) ([]entities.ErasureRequest, error) {
+	ret := _m.Called(db, limit)
+
+	var r0 []entities.ErasureRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) ([]entities.ErasureRequest, error)); ok {
+		return rf(db, limit)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) []entities.ErasureRequest); ok {
+		r0 = rf(db, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ErasureRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceRecoveryCodes provides a mock function with given fields: db, uid, hashes
+func (_m *UserRepo) ReplaceRecoveryCodes(db *gorm.DB, uid int, hashes []string) error {
+	ret := _m.Called(db, uid, hashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, []string) error); ok {
+		r0 = rf(db, uid, hashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPass provides a mock function with given fields: db, newpass, tokenhash
+func (_m *UserRepo) ResetPass(db *gorm.DB, newpass string, tokenhash string) error {
+	ret := _m.Called(db, newpass, tokenhash)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*gorm.DB, string, string) error); ok {
-		r0 = rf(db, newpass, token)
+		r0 = rf(db, newpass, tokenhash)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -174,13 +582,95 @@ func (_m *UserRepo) Update(db *gorm.DB, user entities.User) (*entities.User, err
 	return r0, r1
 }
 
-// VerifyEmail provides a mock function with given fields: db, verificationcode
-func (_m *UserRepo) VerifyEmail(db *gorm.DB, verificationcode string) error {
-	ret := _m.Called(db, verificationcode)
+// UpdateErasure provides a mock function with given fields: db, req
+func (_m *UserRepo) UpdateErasure(db *gorm.DB, req entities.ErasureRequest) error {
+	ret := _m.Called(db, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.ErasureRequest) error); ok {
+		r0 = rf(db, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStudentProfile provides a mock function with given fields: db, profile
+func (_m *UserRepo) UpdateStudentProfile(db *gorm.DB, profile entities.StudentProfile) (*entities.StudentProfile, error) {
+	ret := _m.Called(db, profile)
+
+	var r0 *entities.StudentProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.StudentProfile) (*entities.StudentProfile, error)); ok {
+		return rf(db, profile)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.StudentProfile) *entities.StudentProfile); ok {
+		r0 = rf(db, profile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.StudentProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, entities.StudentProfile) error); ok {
+		r1 = rf(db, profile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTwoFactor provides a mock function with given fields: db, user
+func (_m *UserRepo) UpdateTwoFactor(db *gorm.DB, user entities.User) error {
+	ret := _m.Called(db, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, entities.User) error); ok {
+		r0 = rf(db, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: db, uid, hash
+func (_m *UserRepo) UseRecoveryCode(db *gorm.DB, uid int, hash string) error {
+	ret := _m.Called(db, uid, hash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, string) error); ok {
+		r0 = rf(db, uid, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseTOTPStep provides a mock function with given fields: db, uid, step
+func (_m *UserRepo) UseTOTPStep(db *gorm.DB, uid int, step int64) error {
+	ret := _m.Called(db, uid, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int, int64) error); ok {
+		r0 = rf(db, uid, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: db, tokenhash
+func (_m *UserRepo) VerifyEmail(db *gorm.DB, tokenhash string) error {
+	ret := _m.Called(db, tokenhash)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*gorm.DB, string) error); ok {
-		r0 = rf(db, verificationcode)
+		r0 = rf(db, tokenhash)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	multipart "mime/multipart"
+
+	oidc "github.com/education-hub/BE/app/oidc"
 )
 
 // UserService is an autogenerated mock type for the UserService type
@@ -16,6 +18,20 @@ type UserService struct {
 	mock.Mock
 }
 
+// CheckChild provides a mock function with given fields: ctx, parentid, studentid
+func (_m *UserService) CheckChild(ctx context.Context, parentid int, studentid int) error {
+	ret := _m.Called(ctx, parentid, studentid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, parentid, studentid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *UserService) Delete(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -30,6 +46,136 @@ func (_m *UserService) Delete(ctx context.Context, id int) error {
 	return r0
 }
 
+// DeleteParentLink provides a mock function with given fields: ctx, uid, id
+func (_m *UserService) DeleteParentLink(ctx context.Context, uid int, id int) error {
+	ret := _m.Called(ctx, uid, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableTwoFactor provides a mock function with given fields: ctx, uid, req
+func (_m *UserService) DisableTwoFactor(ctx context.Context, uid int, req entities.ReqTwoFactorCode) error {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqTwoFactorCode) error); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTwoFactor provides a mock function with given fields: ctx, uid, req
+func (_m *UserService) EnableTwoFactor(ctx context.Context, uid int, req entities.ReqTwoFactorCode) (*entities.ResRecoveryCodes, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 *entities.ResRecoveryCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqTwoFactorCode) (*entities.ResRecoveryCodes, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqTwoFactorCode) *entities.ResRecoveryCodes); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResRecoveryCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqTwoFactorCode) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnrollTwoFactor provides a mock function with given fields: ctx, uid
+func (_m *UserService) EnrollTwoFactor(ctx context.Context, uid int) (*entities.ResTwoFactorSetup, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *entities.ResTwoFactorSetup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*entities.ResTwoFactorSetup, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entities.ResTwoFactorSetup); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResTwoFactorSetup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ErasePending provides a mock function with given fields: ctx
+func (_m *UserService) ErasePending(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Export provides a mock function with given fields: ctx, uid
+func (_m *UserService) Export(ctx context.Context, uid int) ([]byte, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]byte, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []byte); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ForgetPass provides a mock function with given fields: ctx, email
 func (_m *UserService) ForgetPass(ctx context.Context, email string) error {
 	ret := _m.Called(ctx, email)
@@ -44,6 +190,58 @@ func (_m *UserService) ForgetPass(ctx context.Context, email string) error {
 	return r0
 }
 
+// GetChildren provides a mock function with given fields: ctx, parentid
+func (_m *UserService) GetChildren(ctx context.Context, parentid int) ([]entities.ResParentLink, error) {
+	ret := _m.Called(ctx, parentid)
+
+	var r0 []entities.ResParentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResParentLink, error)); ok {
+		return rf(ctx, parentid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResParentLink); ok {
+		r0 = rf(ctx, parentid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResParentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, parentid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetParentLinks provides a mock function with given fields: ctx, studentid
+func (_m *UserService) GetParentLinks(ctx context.Context, studentid int) ([]entities.ResParentLink, error) {
+	ret := _m.Called(ctx, studentid)
+
+	var r0 []entities.ResParentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.ResParentLink, error)); ok {
+		return rf(ctx, studentid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.ResParentLink); ok {
+		r0 = rf(ctx, studentid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ResParentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, studentid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProfile provides a mock function with given fields: ctx, id
 func (_m *UserService) GetProfile(ctx context.Context, id int) (*entities.User, error) {
 	ret := _m.Called(ctx, id)
@@ -70,6 +268,58 @@ func (_m *UserService) GetProfile(ctx context.Context, id int) (*entities.User,
 	return r0, r1
 }
 
+// GetStudentProfile provides a mock function with given fields: ctx, uid
+func (_m *UserService) GetStudentProfile(ctx context.Context, uid int) (*entities.ResStudentProfile, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *entities.ResStudentProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*entities.ResStudentProfile, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entities.ResStudentProfile); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResStudentProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteChild provides a mock function with given fields: ctx, parentid, req
+func (_m *UserService) InviteChild(ctx context.Context, parentid int, req entities.ReqInviteChild) (*entities.ResParentLink, error) {
+	ret := _m.Called(ctx, parentid, req)
+
+	var r0 *entities.ResParentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqInviteChild) (*entities.ResParentLink, error)); ok {
+		return rf(ctx, parentid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqInviteChild) *entities.ResParentLink); ok {
+		r0 = rf(ctx, parentid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResParentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqInviteChild) error); ok {
+		r1 = rf(ctx, parentid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, req
 func (_m *UserService) Login(ctx context.Context, req entities.LoginReq) (*entities.User, error) {
 	ret := _m.Called(ctx, req)
@@ -96,6 +346,32 @@ func (_m *UserService) Login(ctx context.Context, req entities.LoginReq) (*entit
 	return r0, r1
 }
 
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, uid, req
+func (_m *UserService) RegenerateRecoveryCodes(ctx context.Context, uid int, req entities.ReqTwoFactorCode) (*entities.ResRecoveryCodes, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 *entities.ResRecoveryCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqTwoFactorCode) (*entities.ResRecoveryCodes, error)); ok {
+		return rf(ctx, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqTwoFactorCode) *entities.ResRecoveryCodes); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResRecoveryCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqTwoFactorCode) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: ctx, req
 func (_m *UserService) Register(ctx context.Context, req entities.RegisterReq) error {
 	ret := _m.Called(ctx, req)
@@ -110,6 +386,20 @@ func (_m *UserService) Register(ctx context.Context, req entities.RegisterReq) e
 	return r0
 }
 
+// ResendVerification provides a mock function with given fields: ctx, email
+func (_m *UserService) ResendVerification(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetPass provides a mock function with given fields: ctx, token, newpass
 func (_m *UserService) ResetPass(ctx context.Context, token string, newpass string) error {
 	ret := _m.Called(ctx, token, newpass)
@@ -124,6 +414,86 @@ func (_m *UserService) ResetPass(ctx context.Context, token string, newpass stri
 	return r0
 }
 
+// ResetTwoFactor provides a mock function with given fields: ctx, uid
+func (_m *UserService) ResetTwoFactor(ctx context.Context, uid int) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RespondParentLink provides a mock function with given fields: ctx, studentid, id, req
+func (_m *UserService) RespondParentLink(ctx context.Context, studentid int, id int, req entities.ReqRespondParent) (*entities.ResParentLink, error) {
+	ret := _m.Called(ctx, studentid, id, req)
+
+	var r0 *entities.ResParentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqRespondParent) (*entities.ResParentLink, error)); ok {
+		return rf(ctx, studentid, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, entities.ReqRespondParent) *entities.ResParentLink); ok {
+		r0 = rf(ctx, studentid, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResParentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, entities.ReqRespondParent) error); ok {
+		r1 = rf(ctx, studentid, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SocialLogin provides a mock function with given fields: ctx, identity, role
+func (_m *UserService) SocialLogin(ctx context.Context, identity oidc.Identity, role string) (*entities.User, error) {
+	ret := _m.Called(ctx, identity, role)
+
+	var r0 *entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oidc.Identity, string) (*entities.User, error)); ok {
+		return rf(ctx, identity, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oidc.Identity, string) *entities.User); ok {
+		r0 = rf(ctx, identity, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oidc.Identity, string) error); ok {
+		r1 = rf(ctx, identity, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnlockLogin provides a mock function with given fields: ctx, suid, username
+func (_m *UserService) UnlockLogin(ctx context.Context, suid int, username string) error {
+	ret := _m.Called(ctx, suid, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, suid, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, req, file
 func (_m *UserService) Update(ctx context.Context, req entities.UpdateReq, file multipart.File) (*entities.User, error) {
 	ret := _m.Called(ctx, req, file)
@@ -150,13 +520,39 @@ func (_m *UserService) Update(ctx context.Context, req entities.UpdateReq, file
 	return r0, r1
 }
 
-// VerifyEmail provides a mock function with given fields: ctx, verificationcode
-func (_m *UserService) VerifyEmail(ctx context.Context, verificationcode string) error {
-	ret := _m.Called(ctx, verificationcode)
+// UpdateStudentProfile provides a mock function with given fields: ctx, uid, req, files
+func (_m *UserService) UpdateStudentProfile(ctx context.Context, uid int, req entities.ReqStudentProfile, files map[string]multipart.File) (*entities.ResStudentProfile, error) {
+	ret := _m.Called(ctx, uid, req, files)
+
+	var r0 *entities.ResStudentProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqStudentProfile, map[string]multipart.File) (*entities.ResStudentProfile, error)); ok {
+		return rf(ctx, uid, req, files)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ReqStudentProfile, map[string]multipart.File) *entities.ResStudentProfile); ok {
+		r0 = rf(ctx, uid, req, files)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResStudentProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entities.ReqStudentProfile, map[string]multipart.File) error); ok {
+		r1 = rf(ctx, uid, req, files)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *UserService) VerifyEmail(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, verificationcode)
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -164,6 +560,32 @@ func (_m *UserService) VerifyEmail(ctx context.Context, verificationcode string)
 	return r0
 }
 
+// VerifyTwoFactor provides a mock function with given fields: ctx, uid, code
+func (_m *UserService) VerifyTwoFactor(ctx context.Context, uid int, code string) (*entities.User, error) {
+	ret := _m.Called(ctx, uid, code)
+
+	var r0 *entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*entities.User, error)); ok {
+		return rf(ctx, uid, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *entities.User); ok {
+		r0 = rf(ctx, uid, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, uid, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUserService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"fmt"
 	"reflect"
+	"time"
 
 	entity "github.com/education-hub/BE/app/entities"
 	"github.com/education-hub/BE/errorr"
@@ -16,13 +18,37 @@ type (
 	UserRepo interface {
 		Create(db *gorm.DB, user entity.User) error
 		FindByEmail(db *gorm.DB, email string) (*entity.User, error)
-		VerifyEmail(db *gorm.DB, verificationcode string) error
+		VerifyEmail(db *gorm.DB, tokenhash string) error
+		InsertVerificationToken(db *gorm.DB, req entity.EmailVerification) error
 		InsertForgotPassToken(db *gorm.DB, req entity.ForgotPass) error
-		ResetPass(db *gorm.DB, newpass string, token string) error
+		ResetPass(db *gorm.DB, newpass string, tokenhash string) error
+		InvalidateResetTokens(db *gorm.DB, email string) error
+		CountTokensSince(db *gorm.DB, model any, email string, since time.Time) (int64, error)
 		FindByUsername(db *gorm.DB, username string) (*entity.User, error)
 		GetById(db *gorm.DB, id int) (*entity.User, error)
 		Update(db *gorm.DB, user entity.User) (*entity.User, error)
 		Delete(db *gorm.DB, user entity.User) error
+		GetStudentProfile(db *gorm.DB, uid int) (*entity.StudentProfile, error)
+		UpdateStudentProfile(db *gorm.DB, profile entity.StudentProfile) (*entity.StudentProfile, error)
+		CreateParentLink(db *gorm.DB, link entity.ParentLink) (*entity.ParentLink, error)
+		GetParentLink(db *gorm.DB, id int) (*entity.ParentLink, error)
+		GetChildLinks(db *gorm.DB, parentid int) ([]entity.ParentLink, error)
+		GetParentLinks(db *gorm.DB, studentid int) ([]entity.ParentLink, error)
+		AcceptParentLink(db *gorm.DB, link entity.ParentLink) error
+		DeleteParentLink(db *gorm.DB, id int) error
+		IsParentOf(db *gorm.DB, parentid int, studentid int) (bool, error)
+		GetParents(db *gorm.DB, studentid int) ([]entity.User, error)
+		UpdateTwoFactor(db *gorm.DB, user entity.User) error
+		UseTOTPStep(db *gorm.DB, uid int, step int64) error
+		ReplaceRecoveryCodes(db *gorm.DB, uid int, hashes []string) error
+		UseRecoveryCode(db *gorm.DB, uid int, hash string) error
+		GetIdentity(db *gorm.DB, provider string, subject string) (*entity.UserIdentity, error)
+		CreateIdentity(db *gorm.DB, identity entity.UserIdentity) error
+		CreateWithIdentity(db *gorm.DB, user entity.User, identity entity.UserIdentity) (*entity.User, error)
+		GetUserData(db *gorm.DB, uid int) (*entity.UserData, error)
+		PendingErasures(db *gorm.DB, limit int) ([]entity.ErasureRequest, error)
+		UpdateErasure(db *gorm.DB, req entity.ErasureRequest) error
+		Anonymize(db *gorm.DB, user entity.User) error
 	}
 )
 
@@ -70,10 +96,17 @@ func (u *user) FindByUsername(db *gorm.DB, username string) (*entity.User, error
 	return &res, nil
 }
 func (u *user) Delete(db *gorm.DB, user entity.User) error {
-	if err := db.Where("user_id=?", user.ID).First(&entity.School{}).Error; err == nil {
-		return errorr.NewBad("You still have active school")
-	}
-	if err := db.Delete(&user).Error; err != nil {
+	// the personal data is only cleared by the eraser, the request is queued with the deletion
+	err := db.Transaction(func(db *gorm.DB) error {
+		if err := db.Delete(&user).Error; err != nil {
+			return err
+		}
+		if err := db.Where("user_id=?", user.ID).Delete(&entity.SchoolMember{}).Error; err != nil {
+			return err
+		}
+		return db.Create(&entity.ErasureRequest{UserID: user.ID, Status: "pending", RequestedAt: time.Now()}).Error
+	})
+	if err != nil {
 		u.log.Errorf("ERROR]WHEN DELETE USER,Error: %v ", err)
 		return errorr.NewInternal(err.Error())
 	}
@@ -123,40 +156,419 @@ func (u *user) GetById(db *gorm.DB, id int) (*entity.User, error) {
 	return &res, nil
 
 }
-func (u *user) VerifyEmail(db *gorm.DB, verificationcode string) error {
-	if err := db.Model(&entity.User{}).Where("verification_code = ?", verificationcode).Update("is_verified", true).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+func (u *user) VerifyEmail(db *gorm.DB, tokenhash string) error {
+	return db.Transaction(func(db *gorm.DB) error {
+		verification := entity.EmailVerification{}
+		if err := db.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenhash, time.Now()).Find(&verification).Error; err != nil {
+			u.log.Errorf("[ERROR]When Verify Email, Error: %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		if verification.Email == "" {
+			return errorr.NewBad("Invalid Or Expired Token")
+		}
+		if err := db.Model(&entity.EmailVerification{}).Where("email = ? AND used_at IS NULL", verification.Email).Update("used_at", time.Now()).Error; err != nil {
+			u.log.Errorf("[ERROR]When Verify Email, Error: %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		if err := db.Model(&entity.User{}).Where("email = ?", verification.Email).Update("is_verified", true).Error; err != nil {
+			u.log.Errorf("[ERROR]When Verify Email, Error: %v", err)
+			return errorr.NewInternal("Internal Server Error")
+		}
+		return nil
+	})
+}
 
-			return errorr.NewBad("Data not found")
+// InsertVerificationToken and InsertForgotPassToken replace the unused tokens of the email, only the last one mailed works.
+func (u *user) InsertVerificationToken(db *gorm.DB, req entity.EmailVerification) error {
+	err := db.Transaction(func(db *gorm.DB) error {
+		if err := db.Model(&entity.EmailVerification{}).Where("email = ? AND used_at IS NULL", req.Email).Update("used_at", time.Now()).Error; err != nil {
+			return err
 		}
-		u.log.Errorf("[ERROR]When Verify Email, Error: %v", err)
+		return db.Create(&req).Error
+	})
+	if err != nil {
+		u.log.Errorf("[ERROR]WHEN INSERTING VERIFICATION TOKEN, Error: %v", err)
 		return errorr.NewInternal("Internal Server Error")
 	}
 	return nil
 }
+
 func (u *user) InsertForgotPassToken(db *gorm.DB, req entity.ForgotPass) error {
-	if err := db.Create(req).Error; err != nil {
+	err := db.Transaction(func(db *gorm.DB) error {
+		if err := db.Model(&entity.ForgotPass{}).Where("email = ? AND used_at IS NULL", req.Email).Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return db.Create(&req).Error
+	})
+	if err != nil {
 		u.log.Errorf("[ERROR]entering the password reset token,error:%v", err)
+		return errorr.NewInternal("Internal Server Error")
 	}
 	return nil
 }
 
-func (u *user) ResetPass(db *gorm.DB, newpass string, token string) error {
+func (u *user) ResetPass(db *gorm.DB, newpass string, tokenhash string) error {
 	return db.Transaction(func(db *gorm.DB) error {
 		userdata := entity.ForgotPass{}
-		if err := db.Where("token = ? AND deleted_at IS NULL", token).Find(&userdata).Error; err != nil {
+		if err := db.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenhash, time.Now()).Find(&userdata).Error; err != nil {
 			u.log.Errorf("[ERROR]WHEN Getting user information with forgot token,error:%v", err)
 			return errorr.NewInternal("Internal Server Error")
 		}
 		if userdata.Email == "" {
-			return errorr.NewBad("Data Not Found")
+			return errorr.NewBad("Invalid Or Expired Token")
 		}
 		if err := db.Model(&entity.User{}).Where("email=?", userdata.Email).Update("password", newpass).Error; err != nil {
 
 			u.log.Errorf("[ERROR]When entering the password reset token,error:%v", err)
 			return errorr.NewInternal("Internal Server Error")
 		}
-		db.Where("token =? AND deleted_at IS NULL", token).Delete(&userdata)
-		return nil
+		return u.InvalidateResetTokens(db, userdata.Email)
+	})
+}
+
+// InvalidateResetTokens ends every unused reset token of the email, it runs whenever the password changes.
+func (u *user) InvalidateResetTokens(db *gorm.DB, email string) error {
+	if err := db.Model(&entity.ForgotPass{}).Where("email = ? AND used_at IS NULL", email).Update("used_at", time.Now()).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN INVALIDATING RESET TOKENS, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// CountTokensSince counts the tokens of the model mailed to the email since the time, used or not.
+func (u *user) CountTokensSince(db *gorm.DB, model any, email string, since time.Time) (int64, error) {
+	var count int64
+	if err := db.Model(model).Where("email = ? AND created_at > ?", email, since).Count(&count).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN COUNTING TOKENS, Error: %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	return count, nil
+}
+
+func (u *user) GetStudentProfile(db *gorm.DB, uid int) (*entity.StudentProfile, error) {
+	res := entity.StudentProfile{}
+	if err := db.Where("user_id=?", uid).First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Student profile is not filled in yet")
+		}
+		u.log.Errorf("[ERROR]WHEN GETTING STUDENT PROFILE, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+
+// UpdateStudentProfile creates the profile of the student the first time and replaces it afterwards.
+func (u *user) UpdateStudentProfile(db *gorm.DB, profile entity.StudentProfile) (*entity.StudentProfile, error) {
+	err := db.Transaction(func(db *gorm.DB) error {
+		exist := entity.StudentProfile{}
+		if err := db.Where("user_id=?", profile.UserID).Find(&exist).Error; err != nil {
+			return err
+		}
+		profile.ID = exist.ID
+		return db.Save(&profile).Error
+	})
+	if err != nil {
+		u.log.Errorf("[ERROR]WHEN UPDATING STUDENT PROFILE, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &profile, nil
+}
+
+func (u *user) CreateParentLink(db *gorm.DB, link entity.ParentLink) (*entity.ParentLink, error) {
+	exist := entity.ParentLink{}
+	if err := db.Where("parent_id=? AND student_id=?", link.ParentID, link.StudentID).Find(&exist).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN CHECKING PARENT LINK, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	if exist.ID != 0 {
+		return nil, errorr.NewBad("Student is already invited")
+	}
+	if err := db.Create(&link).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN CREATING PARENT LINK, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &link, nil
+}
+
+func (u *user) GetParentLink(db *gorm.DB, id int) (*entity.ParentLink, error) {
+	res := entity.ParentLink{}
+	if err := db.Preload("Parent").Preload("Student").First(&res, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Data Not Found")
+		}
+		u.log.Errorf("[ERROR]WHEN GETTING PARENT LINK, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+
+func (u *user) GetChildLinks(db *gorm.DB, parentid int) ([]entity.ParentLink, error) {
+	res := []entity.ParentLink{}
+	if err := db.Preload("Student").Where("parent_id=?", parentid).Order("id").Find(&res).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN GETTING CHILD LINKS, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+
+func (u *user) GetParentLinks(db *gorm.DB, studentid int) ([]entity.ParentLink, error) {
+	res := []entity.ParentLink{}
+	if err := db.Preload("Parent").Where("student_id=?", studentid).Order("id").Find(&res).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN GETTING PARENT LINKS, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+
+func (u *user) AcceptParentLink(db *gorm.DB, link entity.ParentLink) error {
+	if err := db.Model(&entity.ParentLink{}).Where("id=?", link.ID).Updates(map[string]any{"status": link.Status, "accepted_at": link.AcceptedAt}).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN ACCEPTING PARENT LINK, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+func (u *user) DeleteParentLink(db *gorm.DB, id int) error {
+	if err := db.Delete(&entity.ParentLink{}, id).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN DELETING PARENT LINK, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// IsParentOf reports whether the student has accepted the link of the parent.
+func (u *user) IsParentOf(db *gorm.DB, parentid int, studentid int) (bool, error) {
+	var count int64
+	if err := db.Model(&entity.ParentLink{}).Where("parent_id=? AND student_id=? AND status=?", parentid, studentid, "accepted").Count(&count).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN CHECKING PARENT LINK, Error: %v", err)
+		return false, errorr.NewInternal("Internal Server Error")
+	}
+	return count > 0, nil
+}
+
+// GetParents returns the parents linked to the student, pending invitations are left out.
+func (u *user) GetParents(db *gorm.DB, studentid int) ([]entity.User, error) {
+	res := []entity.User{}
+	err := db.Joins("JOIN parent_links ON parent_links.parent_id = users.id").
+		Where("parent_links.student_id=? AND parent_links.status=?", studentid, "accepted").Find(&res).Error
+	if err != nil {
+		u.log.Errorf("[ERROR]WHEN GETTING PARENTS, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+
+// UseTOTPStep records the time step of a code that was just accepted, the step only moves forward
+// so a code used twice at the same time is accepted once.
+func (u *user) UseTOTPStep(db *gorm.DB, uid int, step int64) error {
+	res := db.Model(&entity.User{}).Where("id=? AND totp_step < ?", uid, step).Update("totp_step", step)
+	if res.Error != nil {
+		u.log.Errorf("[ERROR]WHEN USING TOTP STEP, Error: %v", res.Error)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	if res.RowsAffected == 0 {
+		return errorr.NewBad("Invalid Two-Factor Code")
+	}
+	return nil
+}
+
+// UpdateTwoFactor saves the two-factor fields of the user, zero values included so a reset clears them.
+func (u *user) UpdateTwoFactor(db *gorm.DB, user entity.User) error {
+	if err := db.Model(&entity.User{}).Where("id=?", user.ID).Select("totp_secret", "totp_enabled", "totp_step").Updates(&user).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN UPDATING TWO FACTOR, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes drops every recovery code of the user, used or not, and stores the new ones.
+func (u *user) ReplaceRecoveryCodes(db *gorm.DB, uid int, hashes []string) error {
+	err := db.Transaction(func(db *gorm.DB) error {
+		if err := db.Where("user_id=?", uid).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(hashes) == 0 {
+			return nil
+		}
+		codes := make([]entity.RecoveryCode, len(hashes))
+		for i, hash := range hashes {
+			codes[i] = entity.RecoveryCode{UserID: uint(uid), CodeHash: hash}
+		}
+		return db.Create(&codes).Error
+	})
+	if err != nil {
+		u.log.Errorf("[ERROR]WHEN REPLACING RECOVERY CODES, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+func (u *user) UseRecoveryCode(db *gorm.DB, uid int, hash string) error {
+	res := db.Model(&entity.RecoveryCode{}).Where("user_id=? AND code_hash=? AND used_at IS NULL", uid, hash).Update("used_at", time.Now())
+	if res.Error != nil {
+		u.log.Errorf("[ERROR]WHEN USING RECOVERY CODE, Error: %v", res.Error)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	if res.RowsAffected == 0 {
+		return errorr.NewBad("Invalid Two-Factor Code")
+	}
+	return nil
+}
+
+func (u *user) GetIdentity(db *gorm.DB, provider string, subject string) (*entity.UserIdentity, error) {
+	res := entity.UserIdentity{}
+	if err := db.Where("provider=? AND subject=?", provider, subject).First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Identity not linked")
+		}
+		u.log.Errorf("[ERROR]WHEN GETTING USER IDENTITY, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+
+func (u *user) CreateIdentity(db *gorm.DB, identity entity.UserIdentity) error {
+	if err := db.Create(&identity).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN CREATING USER IDENTITY, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// CreateWithIdentity creates the user of a first social login together with its identity.
+func (u *user) CreateWithIdentity(db *gorm.DB, user entity.User, identity entity.UserIdentity) (*entity.User, error) {
+	err := db.Transaction(func(db *gorm.DB) error {
+		if err := db.Create(&user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return db.Create(&identity).Error
 	})
+	if err != nil {
+		u.log.Errorf("[ERROR]WHEN CREATING USER WITH IDENTITY, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &user, nil
+}
+
+// GetUserData loads everything kept about the user, a deleted account included.
+func (u *user) GetUserData(db *gorm.DB, uid int) (*entity.UserData, error) {
+	res := entity.UserData{}
+	if err := db.Unscoped().First(&res.User, uid).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errorr.NewBad("Id Not Found")
+		}
+		u.log.Errorf("[ERROR]WHEN GETTING USER DATA, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	profiles := []entity.StudentProfile{}
+	err := func() error {
+		if err := db.Where("user_id=?", uid).Find(&profiles).Error; err != nil {
+			return err
+		}
+		if err := db.Where("parent_id=? OR student_id=?", uid, uid).Find(&res.ParentLinks).Error; err != nil {
+			return err
+		}
+		if err := db.Where("user_id=?", uid).Find(&res.Identities).Error; err != nil {
+			return err
+		}
+		if err := db.Where("user_id=?", uid).Find(&res.Submissions).Error; err != nil {
+			return err
+		}
+		if err := db.Unscoped().Preload("Events").Where("user_id=?", uid).Find(&res.Progresses).Error; err != nil {
+			return err
+		}
+		if err := db.Where("user_id=?", uid).Find(&res.Appeals).Error; err != nil {
+			return err
+		}
+		if err := db.Where("user_id=?", uid).Find(&res.Letters).Error; err != nil {
+			return err
+		}
+		if err := db.Preload("TransactionItems").Where("user_id=?", uid).Find(&res.Transactions).Error; err != nil {
+			return err
+		}
+		return db.Where("user_id=?", uid).Find(&res.Reviews).Error
+	}()
+	if err != nil {
+		u.log.Errorf("[ERROR]WHEN GETTING USER DATA, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	if len(profiles) > 0 {
+		res.StudentProfile = &profiles[0]
+	}
+	return &res, nil
+}
+
+func (u *user) PendingErasures(db *gorm.DB, limit int) ([]entity.ErasureRequest, error) {
+	res := []entity.ErasureRequest{}
+	if err := db.Where("status=?", "pending").Order("id").Limit(limit).Find(&res).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN GETTING PENDING ERASURES, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+
+func (u *user) UpdateErasure(db *gorm.DB, req entity.ErasureRequest) error {
+	if err := db.Save(&req).Error; err != nil {
+		u.log.Errorf("[ERROR]WHEN UPDATING ERASURE REQUEST, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// Anonymize clears the personal fields of the user and of its admission data and closes the erasure request.
+// The transactions, the carts and the reviews are kept, they only refer to the user by id.
+func (u *user) Anonymize(db *gorm.DB, user entity.User) error {
+	erased := "-"
+	err := db.Transaction(func(db *gorm.DB) error {
+		if err := db.Unscoped().Model(&entity.User{}).Where("id=?", user.ID).Updates(map[string]any{
+			"username": fmt.Sprintf("deleted_%d", user.ID), "first_name": "Deleted", "sure_name": "User",
+			"email": fmt.Sprintf("deleted_%d@erased.invalid", user.ID), "password": "", "address": erased,
+			"image": "default.jpg", "totp_secret": "", "totp_enabled": false,
+		}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("user_id=?", user.ID).Delete(&entity.StudentProfile{}).Error; err != nil {
+			return err
+		}
+		if err := db.Model(&entity.Submission{}).Where("user_id=?", user.ID).Updates(map[string]any{
+			"student_photo": "", "student_name": erased, "place_date": erased, "gender": erased, "religion": erased,
+			"graduation_from": erased, "nisn": erased, "student_address": erased, "parent_name": erased,
+			"parent_job": erased, "parent_religion": erased, "parent_gender": erased, "parent_address": erased,
+			"parent_phone": erased, "parent_signature": "", "student_signature": "", "student_latitude": nil,
+			"student_longitude": nil, "snapshot": "",
+		}).Error; err != nil {
+			return err
+		}
+		if err := db.Model(&entity.Appeal{}).Where("user_id=?", user.ID).Updates(map[string]any{"justification": erased, "attachments": "[]"}).Error; err != nil {
+			return err
+		}
+		if err := db.Model(&entity.AcceptanceLetter{}).Where("user_id=?", user.ID).Updates(map[string]any{"student_name": erased, "nisn": erased, "file": ""}).Error; err != nil {
+			return err
+		}
+		if err := db.Model(&entity.BillingSchedule{}).Where("student_email=?", user.Email).Updates(map[string]any{"student_name": erased, "student_email": ""}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("parent_id=? OR student_id=?", user.ID, user.ID).Delete(&entity.ParentLink{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("user_id=?", user.ID).Delete(&entity.UserIdentity{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("user_id=?", user.ID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("email=?", user.Email).Delete(&entity.EmailVerification{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("email=?", user.Email).Delete(&entity.ForgotPass{}).Error; err != nil {
+			return err
+		}
+		return db.Model(&entity.ErasureRequest{}).Where("user_id=?", user.ID).Updates(map[string]any{"status": "done", "last_error": "", "erased_at": time.Now()}).Error
+	})
+	if err != nil {
+		u.log.Errorf("[ERROR]WHEN ANONYMIZING USER %d, Error: %v", user.ID, err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
 }
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	dependcy "github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/helper"
+)
+
+// defaultEraseInterval is used when ERASEINTERVAL is not configured.
+const defaultEraseInterval = time.Hour
+
+// Eraser periodically erases the personal data of the deleted accounts.
+type Eraser struct {
+	user UserService
+	dep  dependcy.Depend
+}
+
+func NewEraser(user UserService, dep dependcy.Depend) *Eraser {
+	return &Eraser{user: user, dep: dep}
+}
+
+// Run erases on every interval until the context is cancelled.
+func (e *Eraser) Run(ctx context.Context) {
+	helper.Every(ctx, e.dep.Config.EraseInterval, defaultEraseInterval, e.Erase)
+}
+
+func (e *Eraser) Erase(ctx context.Context, now time.Time) {
+	erased, err := e.user.ErasePending(ctx)
+	if err != nil {
+		e.dep.Log.Errorf("[ERROR]WHEN ERASING DELETED USERS: %v", err)
+		return
+	}
+	if erased > 0 {
+		e.dep.Log.Infof("Erased the personal data of %d deleted users", erased)
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+)
+
+// InviteChild links the parent to a student found by username or email, the student is told by email
+// and the link only counts once the student accepts it.
+func (u *user) InviteChild(ctx context.Context, parentid int, req entity.ReqInviteChild) (*entity.ResParentLink, error) {
+	if err := u.validator.Struct(req); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR] WHEN VALIDATE INVITE CHILD REQ, Error: %v", err)
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	student, err := u.repo.FindByUsername(u.dep.Db.WithContext(ctx), req.Student)
+	if err != nil {
+		student, err = u.repo.FindByEmail(u.dep.Db.WithContext(ctx), req.Student)
+	}
+	if err != nil || student.Role != "student" {
+		u.dep.PromErr["error"] = "Student not found"
+		return nil, errorr.NewBad("Student not found")
+	}
+	parent, err := u.repo.GetById(u.dep.Db.WithContext(ctx), parentid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	link, err := u.repo.CreateParentLink(u.dep.Db.WithContext(ctx), entity.ParentLink{ParentID: parent.ID, StudentID: student.ID, Status: "pending"})
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	go func() {
+		if err := u.dep.Pusher.Publish(map[string]any{"username": student.Username, "type": "parent_invite", "parent": parent.FirstName + " " + parent.SureName, "link_id": link.ID}, 2); err != nil {
+			u.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
+		}
+		encodeddata, _ := json.Marshal(map[string]any{"email": student.Email, "name": student.FirstName + " " + student.SureName, "parent": parent.FirstName + " " + parent.SureName, "link_id": link.ID})
+		if err := u.dep.Nsq.Publish("15", encodeddata); err != nil {
+			u.dep.Log.Errorf("[FAILED] to publish to NSQ: %v", err)
+		}
+	}()
+	return resParentLink(*link, student), nil
+}
+
+func (u *user) GetChildren(ctx context.Context, parentid int) ([]entity.ResParentLink, error) {
+	links, err := u.repo.GetChildLinks(u.dep.Db.WithContext(ctx), parentid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res := []entity.ResParentLink{}
+	for _, val := range links {
+		res = append(res, *resParentLink(val, &val.Student))
+	}
+	return res, nil
+}
+
+func (u *user) GetParentLinks(ctx context.Context, studentid int) ([]entity.ResParentLink, error) {
+	links, err := u.repo.GetParentLinks(u.dep.Db.WithContext(ctx), studentid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	res := []entity.ResParentLink{}
+	for _, val := range links {
+		res = append(res, *resParentLink(val, &val.Parent))
+	}
+	return res, nil
+}
+
+// RespondParentLink accepts or declines an invitation sent to the student, a declined one is removed
+// so the parent may invite again.
+func (u *user) RespondParentLink(ctx context.Context, studentid int, id int, req entity.ReqRespondParent) (*entity.ResParentLink, error) {
+	if err := u.validator.Struct(req); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR] WHEN VALIDATE RESPOND PARENT REQ, Error: %v", err)
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	link, err := u.repo.GetParentLink(u.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if int(link.StudentID) != studentid {
+		u.dep.PromErr["error"] = "Parent link does not belong to the student"
+		return nil, errorr.NewBad("Data Not Found")
+	}
+	if link.Status != "pending" {
+		u.dep.PromErr["error"] = "Parent link is already accepted"
+		return nil, errorr.NewBad("Invitation is already accepted")
+	}
+	if *req.Accept {
+		now := time.Now()
+		link.Status = "accepted"
+		link.AcceptedAt = &now
+		err = u.repo.AcceptParentLink(u.dep.Db.WithContext(ctx), *link)
+	} else {
+		link.Status = "declined"
+		err = u.repo.DeleteParentLink(u.dep.Db.WithContext(ctx), id)
+	}
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	go func() {
+		if err := u.dep.Pusher.Publish(map[string]any{"username": link.Parent.Username, "type": "parent_link", "status": link.Status, "student": link.Student.Username, "link_id": link.ID}, 2); err != nil {
+			u.dep.Log.Errorf("Failed to publish to PusherJs: %v", err)
+		}
+	}()
+	return resParentLink(*link, &link.Parent), nil
+}
+
+// DeleteParentLink lets either the parent or the student remove their link.
+func (u *user) DeleteParentLink(ctx context.Context, uid int, id int) error {
+	link, err := u.repo.GetParentLink(u.dep.Db.WithContext(ctx), id)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if int(link.ParentID) != uid && int(link.StudentID) != uid {
+		u.dep.PromErr["error"] = "Parent link does not belong to the user"
+		return errorr.NewBad("Data Not Found")
+	}
+	if err := u.repo.DeleteParentLink(u.dep.Db.WithContext(ctx), id); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	return nil
+}
+
+// CheckChild makes sure the student has accepted the link of the parent before the parent acts on its behalf.
+func (u *user) CheckChild(ctx context.Context, parentid int, studentid int) error {
+	ok, err := u.repo.IsParentOf(u.dep.Db.WithContext(ctx), parentid, studentid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if !ok {
+		u.dep.PromErr["error"] = "Student is not linked to the parent"
+		return errorr.NewBad("Data Not Found")
+	}
+	return nil
+}
+
+// resParentLink describes the link from one side, other is the account on the other side of it.
+func resParentLink(link entity.ParentLink, other *entity.User) *entity.ResParentLink {
+	return &entity.ResParentLink{
+		ID:         int(link.ID),
+		UserID:     int(other.ID),
+		Username:   other.Username,
+		Name:       other.FirstName + " " + other.SureName,
+		Status:     link.Status,
+		CreatedAt:  link.CreatedAt,
+		AcceptedAt: link.AcceptedAt,
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+)
+
+const (
+	// maxErasureAttempts is the number of runs an erasure is tried before it is left to the super admin.
+	maxErasureAttempts = 5
+	erasureBatch       = 50
+)
+
+// Export bundles everything kept about the user in a zip archive, data.json holds the records and
+// files/ the stored documents.
+func (u *user) Export(ctx context.Context, uid int) ([]byte, error) {
+	data, err := u.repo.GetUserData(u.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	buf := bytes.Buffer{}
+	archive := zip.NewWriter(&buf)
+	missing := []string{}
+	for _, name := range userFiles(data) {
+		file, err := u.dep.Storage.ReadFile(name)
+		if err != nil {
+			u.dep.Log.Warnf("[WARN]FILE %s OF USER %d NOT EXPORTED, Error: %v", name, uid, err)
+			missing = append(missing, name)
+			continue
+		}
+		if err := addToArchive(archive, "files/"+path.Base(name), file); err != nil {
+			u.dep.PromErr["error"] = err.Error()
+			u.dep.Log.Errorf("[ERROR]WHEN WRITING EXPORT ARCHIVE, Error: %v", err)
+			return nil, errorr.NewInternal("Internal Server Error")
+		}
+	}
+	records := exportRecords(data)
+	if len(missing) > 0 {
+		records["missing_files"] = missing
+	}
+	content, err := json.MarshalIndent(records, "", "  ")
+	if err == nil {
+		err = addToArchive(archive, "data.json", content)
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR]WHEN WRITING EXPORT ARCHIVE, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return buf.Bytes(), nil
+}
+
+// ErasePending erases the accounts deleted since the last run. The erasure of an account whose files
+// could not be deleted is tried again on the next run, it is marked failed after maxErasureAttempts.
+func (u *user) ErasePending(ctx context.Context) (int, error) {
+	reqs, err := u.repo.PendingErasures(u.dep.Db.WithContext(ctx), erasureBatch)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
+	erased := 0
+	for _, req := range reqs {
+		if err := u.erase(ctx, req); err != nil {
+			u.dep.Log.Errorf("[ERROR]WHEN ERASING USER %d, Error: %v", req.UserID, err)
+			req.Attempts++
+			req.LastError = truncate(err.Error(), 255)
+			if req.Attempts >= maxErasureAttempts {
+				req.Status = "failed"
+			}
+			if err := u.repo.UpdateErasure(u.dep.Db.WithContext(ctx), req); err != nil {
+				u.dep.PromErr["error"] = err.Error()
+				return erased, err
+			}
+			continue
+		}
+		erased++
+	}
+	return erased, nil
+}
+
+// erase deletes the files before clearing their names, a failure leaves the names for the next attempt.
+func (u *user) erase(ctx context.Context, req entity.ErasureRequest) error {
+	data, err := u.repo.GetUserData(u.dep.Db.WithContext(ctx), int(req.UserID))
+	if err != nil {
+		return err
+	}
+	for _, name := range userFiles(data) {
+		if err := u.dep.Storage.DeleteFile(name); err != nil {
+			return err
+		}
+	}
+	return u.repo.Anonymize(u.dep.Db.WithContext(ctx), data.User)
+}
+
+// userFiles are the names of the stored files holding data of the user.
+func userFiles(data *entity.UserData) []string {
+	names := []string{data.User.Image}
+	if profile := data.StudentProfile; profile != nil {
+		names = append(names, profile.StudentPhoto, profile.StudentSignature, profile.ParentSignature)
+	}
+	for _, val := range data.Submissions {
+		names = append(names, val.StudentPhoto, val.StudentSignature, val.ParentSignature)
+	}
+	for _, val := range data.Appeals {
+		attachments := []string{}
+		json.Unmarshal([]byte(val.Attachments), &attachments)
+		names = append(names, attachments...)
+	}
+	for _, val := range data.Letters {
+		names = append(names, val.File)
+	}
+	res := []string{}
+	seen := map[string]bool{"": true, "default.jpg": true}
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+func exportRecords(data *entity.UserData) map[string]any {
+	profile, _ := columns(data.User).(map[string]any)
+	profile["id"] = data.User.ID
+	profile["role"] = data.User.Role
+	profile["verified"] = data.User.IsVerified
+	profile["created_at"] = data.User.CreatedAt
+	progresses := []any{}
+	for _, val := range data.Progresses {
+		record, _ := columns(val).(map[string]any)
+		record["events"] = columns(val.Events)
+		progresses = append(progresses, record)
+	}
+	transactions := []any{}
+	for _, val := range data.Transactions {
+		record, _ := columns(val).(map[string]any)
+		record["items"] = columns(val.TransactionItems)
+		transactions = append(transactions, record)
+	}
+	return map[string]any{
+		"exported_at":        time.Now(),
+		"profile":            profile,
+		"student_profile":    columns(data.StudentProfile),
+		"parent_links":       columns(data.ParentLinks),
+		"identities":         columns(data.Identities),
+		"submissions":        columns(data.Submissions),
+		"progresses":         progresses,
+		"appeals":            columns(data.Appeals),
+		"acceptance_letters": columns(data.Letters),
+		"transactions":       transactions,
+		"reviews":            columns(data.Reviews),
+	}
+}
+
+// columns flattens records to their json fields, without the relations loaded along and the password.
+// A slice gives a slice of records.
+func columns(val any) any {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil
+	}
+	var res any
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil
+	}
+	records, ok := res.([]any)
+	if !ok {
+		records = []any{res}
+	}
+	for _, record := range records {
+		fields, ok := record.(map[string]any)
+		if !ok {
+			continue
+		}
+		for name, field := range fields {
+			switch field.(type) {
+			case map[string]any, []any:
+				delete(fields, name)
+				continue
+			}
+			if strings.ToLower(name) == "password" {
+				delete(fields, name)
+			}
+		}
+	}
+	return res
+}
+
+func addToArchive(archive *zip.Writer, name string, content []byte) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(content)
+	return err
+}
@@ -1,21 +1,39 @@
 package service_test
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"cloud.google.com/go/storage"
 	entity "github.com/education-hub/BE/app/entities"
+	schoolmocks "github.com/education-hub/BE/app/features/school/mocks/repository"
 	mocks "github.com/education-hub/BE/app/features/user/mocks/repository"
 	user "github.com/education-hub/BE/app/features/user/service"
+	"github.com/education-hub/BE/app/oidc"
+	sessionmocks "github.com/education-hub/BE/app/session/mocks"
+	throttlemocks "github.com/education-hub/BE/app/throttle/mocks"
 	"github.com/education-hub/BE/config"
 	dependcy "github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/helper"
+	"github.com/education-hub/BE/pkg"
+	"github.com/mojocn/base64Captcha"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestService(t *testing.T) {
@@ -25,6 +43,9 @@ func TestService(t *testing.T) {
 
 var _ = Describe("user", func() {
 	var Mock *mocks.UserRepo
+	var Guard *throttlemocks.Guard
+	var School *schoolmocks.SchoolRepo
+	var Sessions *sessionmocks.Manager
 	var UserService user.UserService
 	var Depend dependcy.Depend
 	var ctx context.Context
@@ -33,10 +54,18 @@ var _ = Describe("user", func() {
 		log := logrus.New()
 		Depend.Log = log
 		Mock = mocks.NewUserRepo(GinkgoT())
-		UserService = user.NewUserService(Mock, Depend)
+		Guard = throttlemocks.NewGuard(GinkgoT())
+		School = schoolmocks.NewSchoolRepo(GinkgoT())
+		Sessions = sessionmocks.NewManager(GinkgoT())
+		UserService = user.NewUserService(Mock, Depend, Guard, School, Sessions)
 
 	})
 	Context("User Login", func() {
+		// captcha answers a captcha in the store helper.VerifyCaptcha reads.
+		captcha := func() (string, string) {
+			base64Captcha.DefaultMemStore.Set("login", "abc1234")
+			return "login", "abc1234"
+		}
 		When("Request Body kosong", func() {
 			It("Akan Mengembalikan Erorr", func() {
 				_, err := UserService.Login(ctx, entity.LoginReq{})
@@ -46,7 +75,9 @@ var _ = Describe("user", func() {
 
 		When("Username Tidak terdaftar", func() {
 			BeforeEach(func() {
+				Guard.On("Check", mock.Anything, "1321321ewqewq", "").Return(false, nil).Once()
 				Mock.On("FindByUsername", mock.Anything, "1321321ewqewq").Return(nil, errors.New("Username not registered")).Once()
+				Guard.On("Fail", mock.Anything, "1321321ewqewq", "").Return(nil).Once()
 			})
 			It("Akan Mengembalikan error dengan pesan 'Username not registered'", func() {
 				_, err := UserService.Login(ctx, entity.LoginReq{Username: "1321321ewqewq", Password: "123"})
@@ -56,7 +87,9 @@ var _ = Describe("user", func() {
 		})
 		When("Password Salah", func() {
 			BeforeEach(func() {
+				Guard.On("Check", mock.Anything, "satrio123", "").Return(false, nil).Once()
 				Mock.On("FindByUsername", mock.Anything, "satrio123").Return(&entity.User{Username: "satrio2@gmail.com", Password: "321"}, nil).Once()
+				Guard.On("Fail", mock.Anything, "satrio123", "").Return(nil).Once()
 			})
 			It("Akan Mengembalikan error dengan pesan 'wrong password' ", func() {
 				_, err := UserService.Login(ctx, entity.LoginReq{Username: "satrio123", Password: "123"})
@@ -67,7 +100,9 @@ var _ = Describe("user", func() {
 		When("Email Belum Diverifikasi", func() {
 			BeforeEach(func() {
 				data := &entity.User{Email: "satrio2@gmail.com", Password: "$2a$10$vu7o2Wl9LKyzTFkRDp7tc.VyoBB48nj97qyQjlgGCeQXJ067KZGQu", IsVerified: false}
+				Guard.On("Check", mock.Anything, "satrio", "").Return(false, nil).Once()
 				Mock.On("FindByUsername", mock.Anything, "satrio").Return(data, nil).Once()
+				Guard.On("Succeed", mock.Anything, "satrio").Return(nil).Once()
 			})
 			It("Akan Mengembalikan error dengan pesan 'Email Not Verified'", func() {
 				_, err := UserService.Login(ctx, entity.LoginReq{Username: "satrio", Password: "123"})
@@ -80,15 +115,36 @@ var _ = Describe("user", func() {
 				data := &entity.User{Email: "satrio2@gmail.com", Password: "$2a$10$vu7o2Wl9LKyzTFkRDp7tc.VyoBB48nj97qyQjlgGCeQXJ067KZGQu", IsVerified: true}
 				data.ID = 1
 				data.Role = "student"
+				Guard.On("Check", mock.Anything, "satrio", "10.0.0.1").Return(true, nil).Once()
 				Mock.On("FindByUsername", mock.Anything, "satrio").Return(data, nil).Once()
+				Guard.On("Succeed", mock.Anything, "satrio").Return(nil).Once()
 			})
 			It("Akan Mengembalikan error", func() {
-				user, err := UserService.Login(ctx, entity.LoginReq{Username: "satrio", Password: "123"})
+				id, answer := captcha()
+				user, err := UserService.Login(ctx, entity.LoginReq{Username: "satrio", Password: "123", CaptchaID: id, Captcha: answer, IP: "10.0.0.1"})
 				Expect(err).Should(BeNil())
 				Expect(int(user.ID)).To(Equal(1))
 				Expect(user.Role).To(Equal("student"))
 			})
 		})
+		When("Login Gagal Berkali-kali Tanpa Captcha", func() {
+			BeforeEach(func() {
+				Guard.On("Check", mock.Anything, "satrio", "10.0.0.1").Return(true, nil).Once()
+			})
+			It("Akan Meminta Captcha Tanpa Memeriksa Password", func() {
+				_, err := UserService.Login(ctx, entity.LoginReq{Username: "satrio", Password: "123", IP: "10.0.0.1"})
+				Expect(err).To(Equal(errorr.NewBad("Captcha Required")))
+			})
+		})
+		When("Akun Terkunci", func() {
+			BeforeEach(func() {
+				Guard.On("Check", mock.Anything, "satrio", "10.0.0.1").Return(false, errorr.NewBad("Too Many Failed Logins, Try Again In 15 Minutes")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := UserService.Login(ctx, entity.LoginReq{Username: "satrio", Password: "123", IP: "10.0.0.1"})
+				Expect(err.Error()).To(Equal("Too Many Failed Logins, Try Again In 15 Minutes"))
+			})
+		})
 
 	})
 	Context("User Register", func() {
@@ -152,6 +208,9 @@ var _ = Describe("user", func() {
 				Mock.On("FindByUsername", mock.Anything, "satrio").Return(nil, errors.New("error")).Once()
 				Mock.On("FindByEmail", mock.Anything, "satrio2@gmail.com").Return(nil, errors.New("email not registered")).Once()
 				Mock.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				Mock.On("InsertVerificationToken", mock.Anything, mock.MatchedBy(func(req entity.EmailVerification) bool {
+					return req.Email == "satrio2@gmail.com" && len(req.TokenHash) == 64 && req.ExpiresAt.After(time.Now())
+				})).Return(nil).Once()
 			})
 			It("Akan Mengembalikan error dengan nilai null", func() {
 				err := UserService.Register(ctx, entity.RegisterReq{Email: "satrio2@gmail.com", FirstName: "satrio", LastName: "w", Password: "123", Address: "bogor ct", Username: "satrio", Role: "student"})
@@ -171,7 +230,7 @@ var _ = Describe("user", func() {
 		})
 		When("Berhasil pada saat memverifikasi email user", func() {
 			BeforeEach(func() {
-				Mock.On("VerifyEmail", mock.Anything, mock.Anything).Return(nil).Once()
+				Mock.On("VerifyEmail", mock.Anything, helper.HashToken("yewquei31231231======")).Return(nil).Once()
 			})
 			It("Akan Mengembalikan error dengan nilai nil", func() {
 				err := UserService.VerifyEmail(ctx, "yewquei31231231======")
@@ -207,6 +266,7 @@ var _ = Describe("user", func() {
 			BeforeEach(func() {
 				data := &entity.User{Email: "satrio2@gmail.com", IsVerified: true}
 				Mock.On("FindByEmail", mock.Anything, mock.Anything).Return(data, nil).Once()
+				Mock.On("CountTokensSince", mock.Anything, mock.Anything, "satrio2@gmail.com", mock.Anything).Return(int64(0), nil).Once()
 				Mock.On("InsertForgotPassToken", mock.Anything, mock.Anything).Return(errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan error dengan pesan 'Internal Server Error'", func() {
@@ -219,6 +279,7 @@ var _ = Describe("user", func() {
 			BeforeEach(func() {
 				data := &entity.User{Email: "satrio2@gmail.com", IsVerified: true}
 				Mock.On("FindByEmail", mock.Anything, mock.Anything).Return(data, nil).Once()
+				Mock.On("CountTokensSince", mock.Anything, mock.Anything, "satrio2@gmail.com", mock.Anything).Return(int64(0), nil).Once()
 				Mock.On("InsertForgotPassToken", mock.Anything, mock.Anything).Return(errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan error dengan pesan 'Internal Server Error'", func() {
@@ -231,13 +292,59 @@ var _ = Describe("user", func() {
 			BeforeEach(func() {
 				data := &entity.User{Email: "satrio2@gmail.com", IsVerified: true}
 				Mock.On("FindByEmail", mock.Anything, mock.Anything).Return(data, nil).Once()
-				Mock.On("InsertForgotPassToken", mock.Anything, mock.Anything).Return(nil).Once()
+				Mock.On("CountTokensSince", mock.Anything, mock.Anything, "satrio2@gmail.com", mock.Anything).Return(int64(2), nil).Once()
+				Mock.On("InsertForgotPassToken", mock.Anything, mock.MatchedBy(func(req entity.ForgotPass) bool {
+					return req.Email == "satrio2@gmail.com" && len(req.TokenHash) == 64 && req.ExpiresAt.After(time.Now())
+				})).Return(nil).Once()
 			})
 			It("Akan Mengembalikan error dengan nilai nil", func() {
 				err := UserService.ForgetPass(ctx, "satrio2@gmail.com")
 				Expect(err).Should(BeNil())
 			})
 		})
+		When("Terlalu banyak permintaan lupa password", func() {
+			BeforeEach(func() {
+				data := &entity.User{Email: "satrio2@gmail.com", IsVerified: true}
+				Mock.On("FindByEmail", mock.Anything, mock.Anything).Return(data, nil).Once()
+				Mock.On("CountTokensSince", mock.Anything, mock.Anything, "satrio2@gmail.com", mock.Anything).Return(int64(3), nil).Once()
+			})
+			It("Akan Mengembalikan error", func() {
+				err := UserService.ForgetPass(ctx, "satrio2@gmail.com")
+				Expect(err).To(Equal(errorr.NewBad("Too Many Requests, Try Again Later")))
+			})
+		})
+	})
+
+	Context("Kirim Ulang Verifikasi", func() {
+		When("Email sudah diverifikasi", func() {
+			BeforeEach(func() {
+				Mock.On("FindByEmail", mock.Anything, "satrio2@gmail.com").Return(&entity.User{Email: "satrio2@gmail.com", IsVerified: true}, nil).Once()
+			})
+			It("Akan Mengembalikan error", func() {
+				err := UserService.ResendVerification(ctx, "satrio2@gmail.com")
+				Expect(err).To(Equal(errorr.NewBad("Email already verified")))
+			})
+		})
+		When("Terlalu banyak permintaan kirim ulang", func() {
+			BeforeEach(func() {
+				Mock.On("FindByEmail", mock.Anything, "satrio2@gmail.com").Return(&entity.User{Email: "satrio2@gmail.com"}, nil).Once()
+				Mock.On("CountTokensSince", mock.Anything, &entity.EmailVerification{}, "satrio2@gmail.com", mock.Anything).Return(int64(3), nil).Once()
+			})
+			It("Akan Mengembalikan error", func() {
+				err := UserService.ResendVerification(ctx, "satrio2@gmail.com")
+				Expect(err).To(Equal(errorr.NewBad("Too Many Requests, Try Again Later")))
+			})
+		})
+		When("Berhasil mengirim ulang", func() {
+			BeforeEach(func() {
+				Mock.On("FindByEmail", mock.Anything, "satrio2@gmail.com").Return(&entity.User{Email: "satrio2@gmail.com"}, nil).Once()
+				Mock.On("CountTokensSince", mock.Anything, &entity.EmailVerification{}, "satrio2@gmail.com", mock.Anything).Return(int64(0), nil).Once()
+				Mock.On("InsertVerificationToken", mock.Anything, mock.Anything).Return(nil).Once()
+			})
+			It("Akan Mengembalikan error dengan nilai nil", func() {
+				Expect(UserService.ResendVerification(ctx, "satrio2@gmail.com")).Should(BeNil())
+			})
+		})
 	})
 
 	When("Terdapat kesalahan query pada saat memasukan data password baru", func() {
@@ -326,6 +433,8 @@ var _ = Describe("user", func() {
 				Mock.On("FindByUsername", mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Once()
 				Mock.On("FindByEmail", mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Once()
 				Mock.On("Update", mock.Anything, mock.Anything).Return(&entity.User{Email: "satrio44@gmail.com"}, nil).Once()
+				Mock.On("InsertVerificationToken", mock.Anything, mock.Anything).Return(nil).Once()
+				Mock.On("InvalidateResetTokens", mock.Anything, "satrio44@gmail.com").Return(nil).Once()
 			})
 			It("Akan Mengembalikan data user terbaru", func() {
 				var file multipart.File
@@ -338,8 +447,34 @@ var _ = Describe("user", func() {
 
 	})
 	Context("User Delete", func() {
+		BeforeEach(func() {
+			Depend.PromErr = make(map[string]string, 1)
+			UserService = user.NewUserService(Mock, Depend, Guard, School, Sessions)
+		})
+		When("Pemilik Terakhir Sekolah", func() {
+			BeforeEach(func() {
+				School.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{SchoolID: 3, Role: "owner"}, nil).Once()
+				School.On("CountOwners", mock.Anything, 3).Return(1, nil).Once()
+			})
+			It("Akun Tidak Akan Dihapus", func() {
+				err := UserService.Delete(ctx, 1)
+				Expect(err).To(Equal(errorr.NewBad("You are the last owner of your school, add another owner first")))
+			})
+		})
+		When("Sekolah Masih Punya Pemilik Lain", func() {
+			BeforeEach(func() {
+				School.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{SchoolID: 3, Role: "owner"}, nil).Once()
+				School.On("CountOwners", mock.Anything, 3).Return(2, nil).Once()
+				Mock.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+			})
+			It("Akun Akan Dihapus", func() {
+				err := UserService.Delete(ctx, 1)
+				Expect(err).Should(BeNil())
+			})
+		})
 		When("Terjadi kesalahan pada database", func() {
 			BeforeEach(func() {
+				School.On("GetMember", mock.Anything, 1).Return(nil, errorr.NewBad("Data Not Found")).Once()
 				Mock.On("Delete", mock.Anything, mock.Anything).Return(errors.New("Internal Server Error")).Once()
 			})
 			It("Akan Mengembalikan Error", func() {
@@ -350,6 +485,7 @@ var _ = Describe("user", func() {
 		})
 		When("Berhasil menghapus akun", func() {
 			BeforeEach(func() {
+				School.On("GetMember", mock.Anything, 1).Return(&entity.SchoolMember{SchoolID: 3, Role: "admission"}, nil).Once()
 				Mock.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
 			})
 			It("Akan Mengembalikan nil error", func() {
@@ -394,4 +530,460 @@ var _ = Describe("user", func() {
 		})
 
 	})
+	Context("Profil Siswa", func() {
+		var req entity.ReqStudentProfile
+		BeforeEach(func() {
+			Depend.PromErr = make(map[string]string, 1)
+			Depend.Storage = &pkg.LocalStorage{Dir: GinkgoT().TempDir()}
+			UserService = user.NewUserService(Mock, Depend, Guard, School, Sessions)
+			req = entity.ReqStudentProfile{
+				StudentName: "Budi", PlaceDate: "Bogor, 2008-01-02", Gender: "Male", Religion: "Islam", GraduationFrom: "SMP 1", NISN: "0012345678",
+				StudentProvince: "Jawa Barat", StudentDistrict: "Bogor Tengah", StudentVillage: "Paledang", StudentZipCode: "16122", StudentCity: "Bogor", StudentDetail: "Jl. Juanda 1",
+				ParentProvince: "Jawa Barat", ParentDistrict: "Bogor Tengah", ParentVillage: "Paledang", ParentZipCode: "16122", ParentCity: "Bogor", ParentDetail: "Jl. Juanda 1",
+				ParentName: "Siti", ParentGender: "Female", ParentJob: "Guru", ParentReligion: "Islam", ParentPhone: "08123456789",
+			}
+		})
+		When("Profil Belum Diisi", func() {
+			BeforeEach(func() {
+				Mock.On("GetStudentProfile", mock.Anything, 1).Return(nil, errorr.NewBad("Student profile is not filled in yet")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := UserService.GetStudentProfile(ctx, 1)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Data Profil Tidak Lengkap", func() {
+			It("Akan Mengembalikan Error", func() {
+				_, err := UserService.UpdateStudentProfile(ctx, 1, entity.ReqStudentProfile{StudentName: "Budi"}, nil)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Profil Diperbarui Tanpa Dokumen Baru", func() {
+			BeforeEach(func() {
+				Mock.On("GetStudentProfile", mock.Anything, 1).Return(&entity.StudentProfile{ID: 3, UserID: 1, StudentPhoto: "ProfileStudent__1_foto.jpg"}, nil).Once()
+				Mock.On("UpdateStudentProfile", mock.Anything, mock.MatchedBy(func(profile entity.StudentProfile) bool {
+					return profile.ID == 3 && profile.StudentPhoto == "ProfileStudent__1_foto.jpg" && profile.NISN == "0012345678"
+				})).Return(func(db *gorm.DB, profile entity.StudentProfile) *entity.StudentProfile { return &profile }, nil).Once()
+			})
+			It("Akan Menyimpan Data Dan Mempertahankan Dokumen Lama", func() {
+				res, err := UserService.UpdateStudentProfile(ctx, 1, req, nil)
+				Expect(err).Should(BeNil())
+				Expect(res.StudentData.Photo).To(Equal("ProfileStudent__1_foto.jpg"))
+				Expect(res.StudentData.Adress.City).To(Equal("Bogor"))
+			})
+		})
+		When("Profil Pertama Kali Diisi Dengan Dokumen", func() {
+			BeforeEach(func() {
+				Mock.On("GetStudentProfile", mock.Anything, 1).Return(nil, errorr.NewBad("Student profile is not filled in yet")).Once()
+				Mock.On("UpdateStudentProfile", mock.Anything, mock.Anything).Return(func(db *gorm.DB, profile entity.StudentProfile) *entity.StudentProfile { return &profile }, nil).Once()
+			})
+			It("Akan Mengunggah Dokumen Ke Storage", func() {
+				file, _ := os.CreateTemp(GinkgoT().TempDir(), "foto")
+				file.WriteString("foto")
+				file.Seek(0, 0)
+				req.StudentPhoto = "foto.jpg"
+				res, err := UserService.UpdateStudentProfile(ctx, 1, req, map[string]multipart.File{"student_photo": file})
+				Expect(err).Should(BeNil())
+				Expect(res.StudentData.Photo).To(Equal("ProfileStudent__1_foto.jpg"))
+				data, err := Depend.Storage.ReadFile(res.StudentData.Photo)
+				Expect(err).Should(BeNil())
+				Expect(string(data)).To(Equal("foto"))
+			})
+		})
+	})
+	Context("Akun Orang Tua", func() {
+		When("Siswa Yang Diundang Tidak Ada", func() {
+			BeforeEach(func() {
+				Mock.On("FindByUsername", mock.Anything, "budi").Return(nil, errorr.NewBad("Username not registered")).Once()
+				Mock.On("FindByEmail", mock.Anything, "budi").Return(nil, errorr.NewBad("Email not registered")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := UserService.InviteChild(ctx, 2, entity.ReqInviteChild{Student: "budi"})
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Student not found"))
+			})
+		})
+		When("Akun Yang Diundang Bukan Siswa", func() {
+			BeforeEach(func() {
+				Mock.On("FindByUsername", mock.Anything, "admin").Return(&entity.User{Username: "admin", Role: "administrator"}, nil).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := UserService.InviteChild(ctx, 2, entity.ReqInviteChild{Student: "admin"})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Siswa Menjawab Undangan Orang Tua Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetParentLink", mock.Anything, 5).Return(&entity.ParentLink{ID: 5, ParentID: 2, StudentID: 3, Status: "pending"}, nil).Once()
+			})
+			It("Akan Mengembalikan Data Not Found", func() {
+				accept := true
+				_, err := UserService.RespondParentLink(ctx, 1, 5, entity.ReqRespondParent{Accept: &accept})
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Data Not Found"))
+			})
+		})
+		When("Undangan Sudah Diterima", func() {
+			BeforeEach(func() {
+				Mock.On("GetParentLink", mock.Anything, 5).Return(&entity.ParentLink{ID: 5, ParentID: 2, StudentID: 1, Status: "accepted"}, nil).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				accept := true
+				_, err := UserService.RespondParentLink(ctx, 1, 5, entity.ReqRespondParent{Accept: &accept})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Orang Tua Mengakses Siswa Yang Belum Terhubung", func() {
+			BeforeEach(func() {
+				Mock.On("IsParentOf", mock.Anything, 2, 1).Return(false, nil).Once()
+			})
+			It("Akan Mengembalikan Data Not Found", func() {
+				err := UserService.CheckChild(ctx, 2, 1)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).To(Equal("Data Not Found"))
+			})
+		})
+		When("Orang Tua Mengakses Anaknya", func() {
+			BeforeEach(func() {
+				Mock.On("IsParentOf", mock.Anything, 2, 1).Return(true, nil).Once()
+			})
+			It("Akan Diizinkan", func() {
+				Expect(UserService.CheckChild(ctx, 2, 1)).Should(BeNil())
+			})
+		})
+		When("Pengguna Lain Menghapus Hubungan", func() {
+			BeforeEach(func() {
+				Mock.On("GetParentLink", mock.Anything, 5).Return(&entity.ParentLink{ID: 5, ParentID: 2, StudentID: 1, Status: "accepted"}, nil).Once()
+			})
+			It("Akan Mengembalikan Data Not Found", func() {
+				Expect(UserService.DeleteParentLink(ctx, 9, 5)).ShouldNot(BeNil())
+			})
+		})
+	})
+	Context("Autentikasi Dua Faktor", func() {
+		// RFC 6238 test vector, the secret is "12345678901234567890" in base32
+		secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+		withId := func(user entity.User) *entity.User {
+			user.ID = 1
+			return &user
+		}
+		It("Kode TOTP Sesuai RFC 6238", func() {
+			code, err := helper.TOTPCode(secret, time.Unix(59, 0))
+			Expect(err).Should(BeNil())
+			Expect(code).To(Equal("287082"))
+			code, _ = helper.TOTPCode(secret, time.Unix(1111111109, 0))
+			Expect(code).To(Equal("081804"))
+		})
+		It("Kode Yang Sudah Dipakai Tidak Diterima Lagi", func() {
+			now := time.Now()
+			code, _ := helper.TOTPCode(secret, now)
+			step, ok := helper.ValidateTOTP(secret, code, now, 0)
+			Expect(ok).To(BeTrue())
+			_, ok = helper.ValidateTOTP(secret, code, now, step)
+			Expect(ok).To(BeFalse())
+		})
+		When("Pengguna Mendaftarkan Aplikasi Authenticator", func() {
+			BeforeEach(func() {
+				Mock.On("GetById", mock.Anything, 1).Return(withId(entity.User{Username: "budi"}), nil).Once()
+				Mock.On("UpdateTwoFactor", mock.Anything, mock.MatchedBy(func(user entity.User) bool {
+					return user.TOTPSecret != "" && !user.TOTPEnabled
+				})).Return(nil).Once()
+			})
+			It("Akan Mengembalikan Secret Dan URI QR", func() {
+				res, err := UserService.EnrollTwoFactor(ctx, 1)
+				Expect(err).Should(BeNil())
+				Expect(res.URI).To(HavePrefix("otpauth://totp/"))
+				Expect(res.URI).To(ContainSubstring("secret=" + res.Secret))
+			})
+		})
+		When("Kode Konfirmasi Salah", func() {
+			BeforeEach(func() {
+				Mock.On("GetById", mock.Anything, 1).Return(withId(entity.User{TOTPSecret: secret}), nil).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := UserService.EnableTwoFactor(ctx, 1, entity.ReqTwoFactorCode{Code: "000000"})
+				Expect(err).To(Equal(errorr.NewBad("Invalid Two-Factor Code")))
+			})
+		})
+		When("Kode Konfirmasi Benar", func() {
+			var hashes []string
+			BeforeEach(func() {
+				Mock.On("GetById", mock.Anything, 1).Return(withId(entity.User{TOTPSecret: secret}), nil).Once()
+				Mock.On("UpdateTwoFactor", mock.Anything, mock.MatchedBy(func(user entity.User) bool { return user.TOTPEnabled && user.TOTPStep > 0 })).Return(nil).Once()
+				Mock.On("ReplaceRecoveryCodes", mock.Anything, 1, mock.Anything).Run(func(args mock.Arguments) {
+					hashes = args.Get(2).([]string)
+				}).Return(nil).Once()
+			})
+			It("Akan Mengaktifkan 2FA Dan Mengembalikan Kode Pemulihan", func() {
+				code, _ := helper.TOTPCode(secret, time.Now())
+				res, err := UserService.EnableTwoFactor(ctx, 1, entity.ReqTwoFactorCode{Code: code})
+				Expect(err).Should(BeNil())
+				Expect(res.RecoveryCodes).To(HaveLen(10))
+				Expect(hashes).To(HaveLen(10))
+				Expect(hashes).ShouldNot(ContainElement(res.RecoveryCodes[0]))
+			})
+		})
+		When("Login Dengan Kode TOTP", func() {
+			BeforeEach(func() {
+				Mock.On("GetById", mock.Anything, 1).Return(withId(entity.User{TOTPSecret: secret, TOTPEnabled: true}), nil).Once()
+				Mock.On("UseTOTPStep", mock.Anything, 1, mock.Anything).Return(nil).Once()
+			})
+			It("Akan Diizinkan", func() {
+				code, _ := helper.TOTPCode(secret, time.Now())
+				res, err := UserService.VerifyTwoFactor(ctx, 1, code)
+				Expect(err).Should(BeNil())
+				Expect(res.TOTPStep).To(BeNumerically(">", 0))
+			})
+		})
+		When("Kode TOTP Dipakai Bersamaan Di Login Lain", func() {
+			BeforeEach(func() {
+				Mock.On("GetById", mock.Anything, 1).Return(withId(entity.User{TOTPSecret: secret, TOTPEnabled: true}), nil).Once()
+				Mock.On("UseTOTPStep", mock.Anything, 1, mock.Anything).Return(errorr.NewBad("Invalid Two-Factor Code")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				code, _ := helper.TOTPCode(secret, time.Now())
+				_, err := UserService.VerifyTwoFactor(ctx, 1, code)
+				Expect(err).To(Equal(errorr.NewBad("Invalid Two-Factor Code")))
+			})
+		})
+		When("Login Dengan Kode Pemulihan", func() {
+			BeforeEach(func() {
+				Mock.On("GetById", mock.Anything, 1).Return(withId(entity.User{TOTPSecret: secret, TOTPEnabled: true}), nil).Once()
+				Mock.On("UseRecoveryCode", mock.Anything, 1, mock.Anything).Return(nil).Once()
+			})
+			It("Akan Diizinkan", func() {
+				_, err := UserService.VerifyTwoFactor(ctx, 1, "ABCDE-12345")
+				Expect(err).Should(BeNil())
+			})
+		})
+		When("Administrator Menonaktifkan 2FA", func() {
+			BeforeEach(func() {
+				Mock.On("GetById", mock.Anything, 1).Return(withId(entity.User{Role: "administrator", TOTPSecret: secret, TOTPEnabled: true}), nil).Once()
+				Mock.On("UseTOTPStep", mock.Anything, 1, mock.Anything).Return(nil).Once()
+			})
+			It("Akan Ditolak Karena 2FA Wajib", func() {
+				code, _ := helper.TOTPCode(secret, time.Now())
+				err := UserService.DisableTwoFactor(ctx, 1, entity.ReqTwoFactorCode{Code: code})
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Super Admin Mereset 2FA", func() {
+			BeforeEach(func() {
+				Mock.On("GetById", mock.Anything, 1).Return(withId(entity.User{TOTPSecret: secret, TOTPEnabled: true}), nil).Once()
+				Mock.On("UpdateTwoFactor", mock.Anything, mock.MatchedBy(func(user entity.User) bool { return user.TOTPSecret == "" && !user.TOTPEnabled })).Return(nil).Once()
+				Mock.On("ReplaceRecoveryCodes", mock.Anything, 1, []string(nil)).Return(nil).Once()
+				Sessions.On("RevokeAll", mock.Anything, 1).Return(nil).Once()
+			})
+			It("Akan Menghapus Secret Dan Kode Pemulihan Dan Mengakhiri Semua Session", func() {
+				Expect(UserService.ResetTwoFactor(ctx, 1)).Should(BeNil())
+			})
+		})
+	})
+	Context("Login Google", func() {
+		google := oidc.Identity{Provider: "https://accounts.google.com", Subject: "1234567890", Email: "Budi.Santoso+x@gmail.com", EmailVerified: true, GivenName: "Budi", FamilyName: "Santoso"}
+		notLinked := errorr.NewBad("Identity not linked")
+		When("Email Belum Diverifikasi Google", func() {
+			It("Akan Ditolak", func() {
+				identity := google
+				identity.EmailVerified = false
+				_, err := UserService.SocialLogin(ctx, identity, "student")
+				Expect(err).To(Equal(errorr.NewBad("Email Not Verified By The Provider")))
+			})
+		})
+		When("Akun Google Sudah Tertaut", func() {
+			BeforeEach(func() {
+				Mock.On("GetIdentity", mock.Anything, google.Provider, google.Subject).Return(&entity.UserIdentity{UserID: 3}, nil).Once()
+				Mock.On("GetById", mock.Anything, 3).Return(&entity.User{Username: "budi", Role: "parent", IsVerified: true}, nil).Once()
+			})
+			It("Akan Login Sebagai Pengguna Tersebut", func() {
+				res, err := UserService.SocialLogin(ctx, google, "student")
+				Expect(err).Should(BeNil())
+				Expect(res.Username).To(Equal("budi"))
+			})
+		})
+		When("Email Sudah Terdaftar Dan Terverifikasi", func() {
+			BeforeEach(func() {
+				Mock.On("GetIdentity", mock.Anything, google.Provider, google.Subject).Return(nil, notLinked).Once()
+				Mock.On("FindByEmail", mock.Anything, google.Email).Return(&entity.User{Model: gorm.Model{ID: 3}, Role: "student", IsVerified: true}, nil).Once()
+				Mock.On("CreateIdentity", mock.Anything, entity.UserIdentity{UserID: 3, Provider: google.Provider, Subject: google.Subject, Email: google.Email}).Return(nil).Once()
+			})
+			It("Akan Menautkan Akun Google", func() {
+				res, err := UserService.SocialLogin(ctx, google, "parent")
+				Expect(err).Should(BeNil())
+				Expect(res.Role).To(Equal("student"))
+			})
+		})
+		When("Email Terdaftar Tapi Belum Diverifikasi", func() {
+			BeforeEach(func() {
+				Mock.On("GetIdentity", mock.Anything, google.Provider, google.Subject).Return(nil, notLinked).Once()
+				Mock.On("FindByEmail", mock.Anything, google.Email).Return(&entity.User{Role: "student"}, nil).Once()
+			})
+			It("Tidak Akan Ditautkan", func() {
+				_, err := UserService.SocialLogin(ctx, google, "student")
+				Expect(err).To(Equal(errorr.NewBad("Email Registered But Not Verified, Verify It Before Linking")))
+			})
+		})
+		When("Email Milik Administrator", func() {
+			BeforeEach(func() {
+				Mock.On("GetIdentity", mock.Anything, google.Provider, google.Subject).Return(nil, notLinked).Once()
+				Mock.On("FindByEmail", mock.Anything, google.Email).Return(&entity.User{Role: "administrator", IsVerified: true}, nil).Once()
+			})
+			It("Tidak Akan Ditautkan", func() {
+				_, err := UserService.SocialLogin(ctx, google, "student")
+				Expect(err).To(Equal(errorr.NewBad("Social Login Not Allowed For This Account")))
+			})
+		})
+		When("Email Belum Terdaftar", func() {
+			BeforeEach(func() {
+				Mock.On("GetIdentity", mock.Anything, google.Provider, google.Subject).Return(nil, notLinked).Once()
+				Mock.On("FindByEmail", mock.Anything, google.Email).Return(nil, errorr.NewBad("Email not registered")).Once()
+				Mock.On("FindByUsername", mock.Anything, "budi.santosox").Return(&entity.User{}, nil).Once()
+				Mock.On("FindByUsername", mock.Anything, mock.Anything).Return(nil, errorr.NewBad("record not found")).Once()
+				Mock.On("CreateWithIdentity", mock.Anything, mock.MatchedBy(func(user entity.User) bool {
+					return user.Email == google.Email && user.Role == "parent" && user.IsVerified && user.FirstName == "Budi" && len(user.Username) == len("budi.santosox")+5
+				}), entity.UserIdentity{Provider: google.Provider, Subject: google.Subject, Email: google.Email}).Return(&entity.User{Role: "parent"}, nil).Once()
+			})
+			It("Akan Membuat Akun Baru Yang Sudah Terverifikasi", func() {
+				res, err := UserService.SocialLogin(ctx, google, "parent")
+				Expect(err).Should(BeNil())
+				Expect(res.Role).To(Equal("parent"))
+			})
+		})
+		When("Email Belum Terdaftar Dan Role Tidak Diizinkan", func() {
+			BeforeEach(func() {
+				Mock.On("GetIdentity", mock.Anything, google.Provider, google.Subject).Return(nil, notLinked).Once()
+				Mock.On("FindByEmail", mock.Anything, google.Email).Return(nil, errorr.NewBad("Email not registered")).Once()
+			})
+			It("Akan Ditolak", func() {
+				_, err := UserService.SocialLogin(ctx, google, "administrator")
+				Expect(err).To(Equal(errorr.NewBad("Invalid Role")))
+			})
+		})
+	})
+	Context("Data Pribadi", func() {
+		var dir string
+		var data *entity.UserData
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			for _, name := range []string{"Student_1_foto.png", "StudentSign_1.png", "Appeal__1_1_rapor.pdf"} {
+				os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644)
+			}
+			Depend.Storage = &pkg.LocalStorage{Dir: dir}
+			UserService = user.NewUserService(Mock, Depend, Guard, School, Sessions)
+			data = &entity.UserData{
+				User:         entity.User{Username: "budi", Password: "hash", Email: "budi@mail.com", Image: "default.jpg", Role: "student"},
+				Submissions:  []entity.Submission{{ID: 1, StudentName: "Budi", NISN: "0012345678", StudentPhoto: "Student_1_foto.png", StudentSignature: "StudentSign_1.png", ParentSignature: "ParentSign_1_hilang.png"}},
+				Progresses:   []entity.Progress{{ID: 1, Status: "Accepted", Events: []entity.ProgressEvent{{ToStatus: "Accepted"}}}},
+				Appeals:      []entity.Appeal{{ID: 1, Attachments: `["Appeal__1_1_rapor.pdf"]`}},
+				Transactions: []entity.Transaction{{Invoice: "INV-1", Total: 100000, Status: "paid", TransactionItems: []entity.TransactionItems{{ItemName: "SPP", ItemPrice: 100000}}}},
+			}
+			data.User.ID = 1
+		})
+		When("Mengekspor Data", func() {
+			BeforeEach(func() {
+				Mock.On("GetUserData", mock.Anything, 1).Return(data, nil).Once()
+			})
+			It("Akan Mengembalikan Arsip Berisi Data Dan File", func() {
+				res, err := UserService.Export(ctx, 1)
+				Expect(err).Should(BeNil())
+				archive, err := zip.NewReader(bytes.NewReader(res), int64(len(res)))
+				Expect(err).Should(BeNil())
+				files := map[string][]byte{}
+				for _, file := range archive.File {
+					reader, _ := file.Open()
+					files[file.Name], _ = io.ReadAll(reader)
+					reader.Close()
+				}
+				Expect(files).To(HaveKey("files/Student_1_foto.png"))
+				Expect(files).To(HaveKey("files/StudentSign_1.png"))
+				Expect(files).To(HaveKey("files/Appeal__1_1_rapor.pdf"))
+				records := map[string]any{}
+				Expect(json.Unmarshal(files["data.json"], &records)).Should(Succeed())
+				Expect(records["profile"]).To(HaveKeyWithValue("username", "budi"))
+				Expect(records["profile"]).NotTo(HaveKey("password"))
+				Expect(records["missing_files"]).To(ConsistOf("ParentSign_1_hilang.png"))
+				Expect(records["submissions"]).To(ContainElement(HaveKeyWithValue("NISN", "0012345678")))
+				Expect(records["transactions"]).To(ContainElement(HaveKeyWithValue("items", HaveLen(1))))
+				Expect(records["progresses"]).To(ContainElement(HaveKeyWithValue("events", HaveLen(1))))
+			})
+		})
+		When("Berkas Disimpan Di Google Cloud Storage", func() {
+			BeforeEach(func() {
+				objects := map[string][]byte{}
+				for _, name := range []string{"Student_1_foto.png", "StudentSign_1.png", "Appeal__1_1_rapor.pdf"} {
+					objects["/education-hub/uploads/"+name] = []byte(name)
+				}
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					data, ok := objects[r.URL.Path]
+					if !ok {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					w.Write(data)
+				}))
+				DeferCleanup(server.Close)
+				os.Setenv("STORAGE_EMULATOR_HOST", server.URL)
+				DeferCleanup(os.Unsetenv, "STORAGE_EMULATOR_HOST")
+				client, err := storage.NewClient(ctx)
+				Expect(err).Should(BeNil())
+				Depend.Storage = &pkg.StorageGCP{ClG: client, BucketName: "education-hub", Path: "uploads/"}
+				UserService = user.NewUserService(Mock, Depend, Guard, School, Sessions)
+				Mock.On("GetUserData", mock.Anything, 1).Return(data, nil).Once()
+			})
+			It("Akan Mengekspor Berkas Dari Path Penyimpanan", func() {
+				res, err := UserService.Export(ctx, 1)
+				Expect(err).Should(BeNil())
+				archive, err := zip.NewReader(bytes.NewReader(res), int64(len(res)))
+				Expect(err).Should(BeNil())
+				names := []string{}
+				records := map[string]any{}
+				for _, file := range archive.File {
+					names = append(names, file.Name)
+					if file.Name == "data.json" {
+						reader, _ := file.Open()
+						json.NewDecoder(reader).Decode(&records)
+						reader.Close()
+					}
+				}
+				Expect(names).To(ContainElements("files/Student_1_foto.png", "files/StudentSign_1.png", "files/Appeal__1_1_rapor.pdf"))
+				Expect(records["missing_files"]).To(ConsistOf("ParentSign_1_hilang.png"))
+			})
+		})
+		When("User Tidak Ditemukan", func() {
+			BeforeEach(func() {
+				Mock.On("GetUserData", mock.Anything, 1).Return(nil, errorr.NewBad("Id Not Found")).Once()
+			})
+			It("Akan Mengembalikan Error", func() {
+				_, err := UserService.Export(ctx, 1)
+				Expect(err).To(Equal(errorr.NewBad("Id Not Found")))
+			})
+		})
+		When("Menghapus Data Akun Yang Dihapus", func() {
+			BeforeEach(func() {
+				Mock.On("PendingErasures", mock.Anything, 50).Return([]entity.ErasureRequest{{ID: 1, UserID: 1, Status: "pending"}}, nil).Once()
+				Mock.On("GetUserData", mock.Anything, 1).Return(data, nil).Once()
+				Mock.On("Anonymize", mock.Anything, data.User).Return(nil).Once()
+			})
+			It("Akan Menghapus File Dan Menganonimkan Data", func() {
+				erased, err := UserService.ErasePending(ctx)
+				Expect(err).Should(BeNil())
+				Expect(erased).To(Equal(1))
+				entries, _ := os.ReadDir(dir)
+				Expect(entries).To(BeEmpty())
+			})
+		})
+		When("Penghapusan Gagal Berulang Kali", func() {
+			BeforeEach(func() {
+				Mock.On("PendingErasures", mock.Anything, 50).Return([]entity.ErasureRequest{{ID: 1, UserID: 1, Status: "pending", Attempts: 4}}, nil).Once()
+				Mock.On("GetUserData", mock.Anything, 1).Return(data, nil).Once()
+				Mock.On("Anonymize", mock.Anything, data.User).Return(errorr.NewInternal("Internal Server Error")).Once()
+				Mock.On("UpdateErasure", mock.Anything, entity.ErasureRequest{ID: 1, UserID: 1, Status: "failed", Attempts: 5, LastError: "Internal Server Error"}).Return(nil).Once()
+			})
+			It("Akan Ditandai Gagal", func() {
+				erased, err := UserService.ErasePending(ctx)
+				Expect(err).Should(BeNil())
+				Expect(erased).To(Equal(0))
+			})
+		})
+	})
 })
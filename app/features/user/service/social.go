@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/app/oidc"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/helper"
+)
+
+// usernameAttempts is how many suffixes are tried before giving up on a free username.
+const usernameAttempts = 5
+
+// SocialLogin returns the user the identity belongs to. The identity is linked to the account
+// holding the same email the first time, an account is created with role when there is none.
+func (u *user) SocialLogin(ctx context.Context, identity oidc.Identity, role string) (*entity.User, error) {
+	if identity.Email == "" || !identity.EmailVerified {
+		u.dep.PromErr["error"] = "Email not verified by the provider"
+		return nil, errorr.NewBad("Email Not Verified By The Provider")
+	}
+	linked, err := u.repo.GetIdentity(u.dep.Db.WithContext(ctx), identity.Provider, identity.Subject)
+	if err == nil {
+		user, err := u.repo.GetById(u.dep.Db.WithContext(ctx), int(linked.UserID))
+		if err != nil {
+			u.dep.PromErr["error"] = err.Error()
+			return nil, err
+		}
+		return user, nil
+	}
+	if _, missing := err.(errorr.BadRequest); !missing {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	user, err := u.repo.FindByEmail(u.dep.Db.WithContext(ctx), identity.Email)
+	if err == nil {
+		return u.linkIdentity(ctx, user, identity)
+	}
+	if _, missing := err.(errorr.BadRequest); !missing {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if !oidc.SelfServiceRoles[role] {
+		u.dep.PromErr["error"] = "Social login not allowed for role " + role
+		return nil, errorr.NewBad("Invalid Role")
+	}
+	return u.createFromIdentity(ctx, identity, role)
+}
+
+// linkIdentity links the identity to an existing account, only a verified student or parent is linked:
+// an unverified account may have been registered by someone else with the email and the staff
+// accounts keep their own login.
+func (u *user) linkIdentity(ctx context.Context, user *entity.User, identity oidc.Identity) (*entity.User, error) {
+	if !oidc.SelfServiceRoles[user.Role] {
+		u.dep.PromErr["error"] = "Social login not allowed for role " + user.Role
+		return nil, errorr.NewBad("Social Login Not Allowed For This Account")
+	}
+	if !user.IsVerified {
+		u.dep.PromErr["error"] = "Email Not Verified"
+		return nil, errorr.NewBad("Email Registered But Not Verified, Verify It Before Linking")
+	}
+	data := entity.UserIdentity{UserID: user.ID, Provider: identity.Provider, Subject: identity.Subject, Email: identity.Email}
+	if err := u.repo.CreateIdentity(u.dep.Db.WithContext(ctx), data); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	u.dep.Log.Infof("[INFO]USER %d LINKED TO %s", user.ID, identity.Provider)
+	return user, nil
+}
+
+// createFromIdentity creates the account of a first social login, the provider already verified the email
+// and the password is random as the user signs in through the provider.
+func (u *user) createFromIdentity(ctx context.Context, identity oidc.Identity, role string) (*entity.User, error) {
+	username, err := u.freeUsername(ctx, identity.Email)
+	if err != nil {
+		return nil, err
+	}
+	secret, _, err := helper.NewToken()
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR]WHEN GENERATING PASSWORD, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	passhash, err := helper.HashPassword(secret)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR]WHEN HASHING PASSWORD, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	data := entity.User{
+		Username:   username,
+		Email:      identity.Email,
+		Password:   passhash,
+		FirstName:  truncate(identity.GivenName, 30),
+		SureName:   truncate(identity.FamilyName, 30),
+		Role:       role,
+		IsVerified: true,
+	}
+	link := entity.UserIdentity{Provider: identity.Provider, Subject: identity.Subject, Email: identity.Email}
+	user, err := u.repo.CreateWithIdentity(u.dep.Db.WithContext(ctx), data, link)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return user, nil
+}
+
+// freeUsername derives the username from the local part of the email, a number is added when it is taken.
+func (u *user) freeUsername(ctx context.Context, email string) (string, error) {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	base := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' {
+			return r
+		}
+		return -1
+	}, local)
+	base = truncate(base, 24)
+	if base == "" {
+		base = "user"
+	}
+	candidate := base
+	for i := 0; i < usernameAttempts; i++ {
+		if _, err := u.repo.FindByUsername(u.dep.Db.WithContext(ctx), candidate); err != nil {
+			if _, missing := err.(errorr.BadRequest); missing {
+				return candidate, nil
+			}
+			u.dep.PromErr["error"] = err.Error()
+			return "", err
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(100000))
+		if err != nil {
+			u.dep.PromErr["error"] = err.Error()
+			return "", errorr.NewInternal("Internal Server Error")
+		}
+		candidate = fmt.Sprintf("%s%05d", base, n.Int64())
+	}
+	u.dep.PromErr["error"] = "No free username for " + base
+	return "", errorr.NewInternal("Internal Server Error")
+}
+
+func truncate(val string, size int) string {
+	if runes := []rune(val); len(runes) > size {
+		return string(runes[:size])
+	}
+	return val
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/helper"
+)
+
+const (
+	verificationTTL = 24 * time.Hour
+	resetTTL        = time.Hour
+	// tokensPerHour is how many verification or reset emails one address gets in an hour.
+	tokensPerHour = 3
+)
+
+func (u *user) ResendVerification(ctx context.Context, email string) error {
+	user, err := u.repo.FindByEmail(u.dep.Db.WithContext(ctx), email)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return errorr.NewBad("Email not registered")
+	}
+	if user.IsVerified {
+		u.dep.PromErr["error"] = "Email already verified"
+		return errorr.NewBad("Email already verified")
+	}
+	if err := u.limitTokens(ctx, &entity.EmailVerification{}, user.Email); err != nil {
+		return err
+	}
+	return u.sendVerification(ctx, user, "5")
+}
+
+// sendVerification mails a new verification token, the topic picks the email the consumer sends.
+func (u *user) sendVerification(ctx context.Context, user *entity.User, topic string) error {
+	token, hash, err := helper.NewToken()
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR]WHEN GENERATING VERIFICATION TOKEN, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	if err := u.repo.InsertVerificationToken(u.dep.Db.WithContext(ctx), entity.EmailVerification{Email: user.Email, TokenHash: hash, ExpiresAt: time.Now().Add(verificationTTL)}); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	u.publishToken(topic, user, token)
+	return nil
+}
+
+func (u *user) publishToken(topic string, user *entity.User, token string) {
+	go func() {
+		encodeddata, _ := json.Marshal(map[string]any{"email": user.Email, "name": user.FirstName + " " + user.SureName, "token": token})
+		if err := u.dep.Nsq.Publish(topic, encodeddata); err != nil {
+			u.dep.PromErr["error"] = err.Error()
+			u.dep.Log.Errorf("[FAILED] to publish to NSQ: %v", err)
+		}
+	}()
+}
+
+// limitTokens refuses another email once the address got tokensPerHour of the model in the last hour.
+func (u *user) limitTokens(ctx context.Context, model any, email string) error {
+	count, err := u.repo.CountTokensSince(u.dep.Db.WithContext(ctx), model, email, time.Now().Add(-time.Hour))
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if count >= tokensPerHour {
+		u.dep.PromErr["error"] = "Too many emails requested"
+		return errorr.NewBad("Too Many Requests, Try Again Later")
+	}
+	return nil
+}
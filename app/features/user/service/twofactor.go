@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/education-hub/BE/app/audit"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/helper"
+)
+
+const (
+	totpIssuer        = "Education Hub"
+	recoveryCodeCount = 10
+)
+
+// RequiresTwoFactor reports whether the role may only reach its area once the second step of the login is passed.
+func RequiresTwoFactor(role string) bool {
+	return role == "administrator" || role == "su"
+}
+
+// EnrollTwoFactor gives the user a new secret to scan, it only protects the login once EnableTwoFactor confirms it.
+func (u *user) EnrollTwoFactor(ctx context.Context, uid int) (*entity.ResTwoFactorSetup, error) {
+	user, err := u.repo.GetById(u.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		u.dep.PromErr["error"] = "Two-factor authentication already enabled"
+		return nil, errorr.NewBad("Two-Factor Authentication Already Enabled")
+	}
+	secret, err := helper.NewTOTPSecret()
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR]WHEN GENERATING TOTP SECRET, Error: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	user.TOTPSecret = secret
+	user.TOTPStep = 0
+	if err := u.repo.UpdateTwoFactor(u.dep.Db.WithContext(ctx), *user); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return &entity.ResTwoFactorSetup{Secret: secret, URI: helper.TOTPURI(totpIssuer, user.Username, secret)}, nil
+}
+
+// EnableTwoFactor turns the enrolled secret on once the user proves the app generates its codes,
+// the recovery codes are only shown here.
+func (u *user) EnableTwoFactor(ctx context.Context, uid int, req entity.ReqTwoFactorCode) (*entity.ResRecoveryCodes, error) {
+	if err := u.validator.Struct(req); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR] WHEN VALIDATE TWO FACTOR REQ, Error: %v", err)
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	user, err := u.repo.GetById(u.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		u.dep.PromErr["error"] = "Two-factor authentication already enabled"
+		return nil, errorr.NewBad("Two-Factor Authentication Already Enabled")
+	}
+	if user.TOTPSecret == "" {
+		u.dep.PromErr["error"] = "Two-factor authentication not enrolled"
+		return nil, errorr.NewBad("Two-Factor Authentication Not Enrolled")
+	}
+	step, ok := helper.ValidateTOTP(user.TOTPSecret, req.Code, time.Now(), user.TOTPStep)
+	if !ok {
+		u.dep.PromErr["error"] = "Invalid two-factor code"
+		return nil, errorr.NewBad("Invalid Two-Factor Code")
+	}
+	user.TOTPEnabled = true
+	user.TOTPStep = step
+	if err := u.repo.UpdateTwoFactor(u.dep.Db.WithContext(ctx), *user); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return u.newRecoveryCodes(ctx, uid)
+}
+
+func (u *user) DisableTwoFactor(ctx context.Context, uid int, req entity.ReqTwoFactorCode) error {
+	if err := u.validator.Struct(req); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR] WHEN VALIDATE TWO FACTOR REQ, Error: %v", err)
+		return errorr.NewBad("Missing or Invalid Request Body")
+	}
+	user, err := u.verifyTwoFactor(ctx, uid, req.Code)
+	if err != nil {
+		return err
+	}
+	if RequiresTwoFactor(user.Role) {
+		u.dep.PromErr["error"] = "Two-factor authentication is mandatory for " + user.Role
+		return errorr.NewBad("Two-Factor Authentication Is Mandatory For Your Role")
+	}
+	return u.clearTwoFactor(ctx, user)
+}
+
+func (u *user) RegenerateRecoveryCodes(ctx context.Context, uid int, req entity.ReqTwoFactorCode) (*entity.ResRecoveryCodes, error) {
+	if err := u.validator.Struct(req); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR] WHEN VALIDATE TWO FACTOR REQ, Error: %v", err)
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	if _, err := u.verifyTwoFactor(ctx, uid, req.Code); err != nil {
+		return nil, err
+	}
+	return u.newRecoveryCodes(ctx, uid)
+}
+
+// VerifyTwoFactor is the second step of the login, the code is either from the app or a recovery code.
+func (u *user) VerifyTwoFactor(ctx context.Context, uid int, code string) (*entity.User, error) {
+	if strings.TrimSpace(code) == "" {
+		u.dep.PromErr["error"] = "Missing two-factor code"
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	return u.verifyTwoFactor(ctx, uid, code)
+}
+
+// ResetTwoFactor is used by the super admin when a user lost both the app and the recovery codes,
+// the user is logged out everywhere and enrolls again on the next login.
+func (u *user) ResetTwoFactor(ctx context.Context, uid int) error {
+	user, err := u.repo.GetById(u.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	u.dep.Log.Warnf("[WARN]TWO FACTOR OF USER %d RESET", uid)
+	before := map[string]any{"totp_enabled": user.TOTPEnabled}
+	if err := u.clearTwoFactor(ctx, user); err != nil {
+		return err
+	}
+	if err := u.sessions.RevokeAll(ctx, uid); err != nil {
+		return err
+	}
+	audit.Track(ctx, audit.Change{Type: "user", ID: uid, Before: before, After: map[string]any{"totp_enabled": false}})
+	return nil
+}
+
+func (u *user) verifyTwoFactor(ctx context.Context, uid int, code string) (*entity.User, error) {
+	user, err := u.repo.GetById(u.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		u.dep.PromErr["error"] = "Two-factor authentication not enabled"
+		return nil, errorr.NewBad("Two-Factor Authentication Not Enabled")
+	}
+	if step, ok := helper.ValidateTOTP(user.TOTPSecret, code, time.Now(), user.TOTPStep); ok {
+		if err := u.repo.UseTOTPStep(u.dep.Db.WithContext(ctx), uid, step); err != nil {
+			u.dep.PromErr["error"] = err.Error()
+			return nil, err
+		}
+		user.TOTPStep = step
+		return user, nil
+	}
+	if err := u.repo.UseRecoveryCode(u.dep.Db.WithContext(ctx), uid, hashRecoveryCode(code)); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *user) clearTwoFactor(ctx context.Context, user *entity.User) error {
+	user.TOTPSecret = ""
+	user.TOTPEnabled = false
+	user.TOTPStep = 0
+	if err := u.repo.UpdateTwoFactor(u.dep.Db.WithContext(ctx), *user); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if err := u.repo.ReplaceRecoveryCodes(u.dep.Db.WithContext(ctx), int(user.ID), nil); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	return nil
+}
+
+// newRecoveryCodes replaces the recovery codes of the user, the plain codes are never stored.
+func (u *user) newRecoveryCodes(ctx context.Context, uid int) (*entity.ResRecoveryCodes, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			u.dep.PromErr["error"] = err.Error()
+			u.dep.Log.Errorf("[ERROR]WHEN GENERATING RECOVERY CODES, Error: %v", err)
+			return nil, errorr.NewInternal("Internal Server Error")
+		}
+		code := hex.EncodeToString(buf)
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	if err := u.repo.ReplaceRecoveryCodes(u.dep.Db.WithContext(ctx), uid, hashes); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return &entity.ResRecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// hashRecoveryCode ignores the dash and the case so the code can be typed the way it reads.
+func hashRecoveryCode(code string) string {
+	return helper.HashToken(strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code)))
+}
@@ -2,12 +2,18 @@ package service
 
 import (
 	"context"
-	"encoding/base32"
+	"encoding/json"
 	"fmt"
 	"mime/multipart"
+	"time"
 
+	"github.com/education-hub/BE/app/audit"
 	entity "github.com/education-hub/BE/app/entities"
+	school "github.com/education-hub/BE/app/features/school/repository"
 	"github.com/education-hub/BE/app/features/user/repository"
+	"github.com/education-hub/BE/app/oidc"
+	"github.com/education-hub/BE/app/session"
+	"github.com/education-hub/BE/app/throttle"
 	dependcy "github.com/education-hub/BE/config/dependency"
 	"github.com/education-hub/BE/errorr"
 	"github.com/education-hub/BE/helper"
@@ -16,24 +22,46 @@ import (
 
 type (
 	user struct {
-		repo      repository.UserRepo
-		validator *validator.Validate
-		dep       dependcy.Depend
+		repo       repository.UserRepo
+		schoolrepo school.SchoolRepo
+		validator  *validator.Validate
+		dep        dependcy.Depend
+		guard      throttle.Guard
+		sessions   session.Manager
 	}
 	UserService interface {
 		Login(ctx context.Context, req entity.LoginReq) (*entity.User, error)
 		Register(ctx context.Context, req entity.RegisterReq) error
-		VerifyEmail(ctx context.Context, verificationcode string) error
+		VerifyEmail(ctx context.Context, token string) error
+		ResendVerification(ctx context.Context, email string) error
 		ForgetPass(ctx context.Context, email string) error
 		ResetPass(ctx context.Context, token string, newpass string) error
 		GetProfile(ctx context.Context, id int) (*entity.User, error)
 		Update(ctx context.Context, req entity.UpdateReq, file multipart.File) (*entity.User, error)
 		Delete(ctx context.Context, id int) error
+		GetStudentProfile(ctx context.Context, uid int) (*entity.ResStudentProfile, error)
+		UpdateStudentProfile(ctx context.Context, uid int, req entity.ReqStudentProfile, files map[string]multipart.File) (*entity.ResStudentProfile, error)
+		InviteChild(ctx context.Context, parentid int, req entity.ReqInviteChild) (*entity.ResParentLink, error)
+		GetChildren(ctx context.Context, parentid int) ([]entity.ResParentLink, error)
+		GetParentLinks(ctx context.Context, studentid int) ([]entity.ResParentLink, error)
+		RespondParentLink(ctx context.Context, studentid int, id int, req entity.ReqRespondParent) (*entity.ResParentLink, error)
+		DeleteParentLink(ctx context.Context, uid int, id int) error
+		CheckChild(ctx context.Context, parentid int, studentid int) error
+		EnrollTwoFactor(ctx context.Context, uid int) (*entity.ResTwoFactorSetup, error)
+		EnableTwoFactor(ctx context.Context, uid int, req entity.ReqTwoFactorCode) (*entity.ResRecoveryCodes, error)
+		DisableTwoFactor(ctx context.Context, uid int, req entity.ReqTwoFactorCode) error
+		RegenerateRecoveryCodes(ctx context.Context, uid int, req entity.ReqTwoFactorCode) (*entity.ResRecoveryCodes, error)
+		VerifyTwoFactor(ctx context.Context, uid int, code string) (*entity.User, error)
+		ResetTwoFactor(ctx context.Context, uid int) error
+		UnlockLogin(ctx context.Context, suid int, username string) error
+		SocialLogin(ctx context.Context, identity oidc.Identity, role string) (*entity.User, error)
+		Export(ctx context.Context, uid int) ([]byte, error)
+		ErasePending(ctx context.Context) (int, error)
 	}
 )
 
-func NewUserService(repo repository.UserRepo, dep dependcy.Depend) UserService {
-	return &user{repo: repo, dep: dep, validator: validator.New()}
+func NewUserService(repo repository.UserRepo, dep dependcy.Depend, guard throttle.Guard, school school.SchoolRepo, sessions session.Manager) UserService {
+	return &user{repo: repo, dep: dep, validator: validator.New(), guard: guard, schoolrepo: school, sessions: sessions}
 }
 
 func (u *user) Login(ctx context.Context, req entity.LoginReq) (*entity.User, error) {
@@ -42,16 +70,34 @@ func (u *user) Login(ctx context.Context, req entity.LoginReq) (*entity.User, er
 		u.dep.Log.Errorf("[ERROR] WHEN VALIDATE LOGIN REQ, Error: %v", err)
 		return nil, errorr.NewBad("Missing or Invalid Request Body")
 	}
+	captcha, err := u.guard.Check(ctx, req.Username, req.IP)
+	if err != nil {
+		return nil, err
+	}
+	// the captcha store answers an unknown id with an empty value, an empty answer would pass
+	if captcha && (req.Captcha == "" || !helper.VerifyCaptcha(req.CaptchaID, req.Captcha)) {
+		u.dep.PromErr["error"] = "Captcha required"
+		return nil, errorr.NewBad("Captcha Required")
+	}
 	user, err := u.repo.FindByUsername(u.dep.Db.WithContext(ctx), req.Username)
 	if err != nil {
 		u.dep.PromErr["error"] = err.Error()
+		if err := u.guard.Fail(ctx, req.Username, req.IP); err != nil {
+			return nil, err
+		}
 		return nil, err
 	}
 	if err := helper.VerifyPassword(user.Password, req.Password); err != nil {
 		u.dep.PromErr["error"] = err.Error()
 		u.dep.Log.Errorf("Error Service : %v", err)
+		if err := u.guard.Fail(ctx, req.Username, req.IP); err != nil {
+			return nil, err
+		}
 		return nil, errorr.NewBad("Wrong password")
 	}
+	if err := u.guard.Succeed(ctx, req.Username); err != nil {
+		return nil, err
+	}
 	if user.IsVerified == false {
 		u.dep.PromErr["error"] = "Email Not Verified"
 		return nil, errorr.NewBad("Email Not Verified")
@@ -59,6 +105,11 @@ func (u *user) Login(ctx context.Context, req entity.LoginReq) (*entity.User, er
 	return user, nil
 }
 
+// UnlockLogin lets the super admin lift the lock of a username before it expires.
+func (u *user) UnlockLogin(ctx context.Context, suid int, username string) error {
+	return u.guard.Unlock(ctx, username, suid)
+}
+
 func (u *user) Register(ctx context.Context, req entity.RegisterReq) error {
 	if err := u.validator.Struct(req); err != nil {
 		u.dep.PromErr["error"] = err.Error()
@@ -83,35 +134,25 @@ func (u *user) Register(ctx context.Context, req entity.RegisterReq) error {
 		u.dep.Log.Errorf("Erorr service: %v", err)
 		return errorr.NewBad("Register failed")
 	}
-	hashedEmailString := base32.StdEncoding.EncodeToString([]byte(req.Email))
 	data := entity.User{
-		Username:         req.Username,
-		Email:            req.Email,
-		Address:          req.Address,
-		Password:         passhash,
-		FirstName:        req.FirstName,
-		SureName:         req.LastName,
-		Role:             req.Role,
-		IsVerified:       false,
-		VerificationCode: hashedEmailString,
-	}
-	go func() {
-		err := u.dep.Nsq.Publish("5", []byte(hashedEmailString))
-		if err != nil {
-			u.dep.PromErr["error"] = err.Error()
-			u.dep.Log.Errorf("[FAILED] to publish to NSQ: %v", err)
-			return
-		}
-	}()
+		Username:   req.Username,
+		Email:      req.Email,
+		Address:    req.Address,
+		Password:   passhash,
+		FirstName:  req.FirstName,
+		SureName:   req.LastName,
+		Role:       req.Role,
+		IsVerified: false,
+	}
 	if err := u.repo.Create(u.dep.Db.WithContext(ctx), data); err != nil {
 		u.dep.PromErr["error"] = err.Error()
 		return err
 	}
-	return nil
+	return u.sendVerification(ctx, &data, "5")
 }
 
-func (u *user) VerifyEmail(ctx context.Context, verificationcode string) error {
-	if err := u.repo.VerifyEmail(u.dep.Db.WithContext(ctx), verificationcode); err != nil {
+func (u *user) VerifyEmail(ctx context.Context, token string) error {
+	if err := u.repo.VerifyEmail(u.dep.Db.WithContext(ctx), helper.HashToken(token)); err != nil {
 		u.dep.PromErr["error"] = err.Error()
 		return err
 	}
@@ -127,25 +168,26 @@ func (u *user) ForgetPass(ctx context.Context, email string) error {
 		u.dep.PromErr["error"] = "Email Not Verified"
 		return errorr.NewBad("Email not verified")
 	}
-	hashedEmailString := base32.StdEncoding.EncodeToString([]byte(user.Email))
-	if err := u.repo.InsertForgotPassToken(u.dep.Db.WithContext(ctx), entity.ForgotPass{Token: hashedEmailString, Email: user.Email}); err != nil {
+	if err := u.limitTokens(ctx, &entity.ForgotPass{}, user.Email); err != nil {
+		return err
+	}
+	token, hash, err := helper.NewToken()
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR]WHEN GENERATING RESET TOKEN, Error: %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	if err := u.repo.InsertForgotPassToken(u.dep.Db.WithContext(ctx), entity.ForgotPass{Email: user.Email, TokenHash: hash, ExpiresAt: time.Now().Add(resetTTL)}); err != nil {
 		u.dep.PromErr["error"] = err.Error()
 		return err
 	}
-	go func() {
-		err := u.dep.Nsq.Publish("6", []byte(hashedEmailString))
-		if err != nil {
-			u.dep.PromErr["error"] = err.Error()
-			u.dep.Log.Errorf("[FAILED] to publish to NSQ: %v", err)
-			return
-		}
-	}()
+	u.publishToken("6", user, token)
 	return nil
 
 }
 
 func (u *user) ResetPass(ctx context.Context, token string, newpass string) error {
-	if err := u.repo.ResetPass(u.dep.Db.WithContext(ctx), newpass, token); err != nil {
+	if err := u.repo.ResetPass(u.dep.Db.WithContext(ctx), newpass, helper.HashToken(token)); err != nil {
 		u.dep.PromErr["error"] = err.Error()
 		return err
 	}
@@ -187,21 +229,11 @@ func (u *user) Update(ctx context.Context, req entity.UpdateReq, file multipart.
 				return nil, errorr.NewBad("Email already registered")
 			}
 		}
-		hashedEmailString := base32.StdEncoding.EncodeToString([]byte(req.Email))
-		go func() {
-			err := u.dep.Nsq.Publish("7", []byte(hashedEmailString))
-			if err != nil {
-				u.dep.PromErr["error"] = err.Error()
-				u.dep.Log.Errorf("[FAILED] to publish to NSQ: %v", err)
-				return
-			}
-		}()
-		data.VerificationCode = hashedEmailString
 		data.IsVerified = true
 	}
 	if file != nil {
 		filename := fmt.Sprintf("%s_%s", "User", req.Image)
-		if err1 := u.dep.Gcp.UploadFile(file, filename); err1 != nil {
+		if err1 := u.dep.Storage.UploadFile(file, filename); err1 != nil {
 			u.dep.PromErr["error"] = err1.Error()
 			u.dep.Log.Errorf("Error Service : %v", err1)
 			return nil, errorr.NewBad("Failed to upload image")
@@ -222,15 +254,146 @@ func (u *user) Update(ctx context.Context, req entity.UpdateReq, file multipart.
 		u.dep.PromErr["error"] = err.Error()
 		return nil, err
 	}
+	if req.Email != "" {
+		if err := u.sendVerification(ctx, res, "7"); err != nil {
+			return nil, err
+		}
+	}
+	if req.Password != "" {
+		// a reset link mailed before the change must not undo it
+		if err := u.repo.InvalidateResetTokens(u.dep.Db.WithContext(ctx), res.Email); err != nil {
+			u.dep.PromErr["error"] = err.Error()
+			return nil, err
+		}
+	}
 	return res, nil
 }
+
+// Delete refuses to remove the last owner of a school, the school could not be managed anymore.
+// Any other member leaves the school with the account.
 func (u *user) Delete(ctx context.Context, id int) error {
+	member, err := u.schoolrepo.GetMember(u.dep.Db.WithContext(ctx), id)
+	if _, missing := err.(errorr.BadRequest); err != nil && !missing {
+		u.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if err == nil && member.Role == "owner" {
+		owners, err := u.schoolrepo.CountOwners(u.dep.Db.WithContext(ctx), int(member.SchoolID))
+		if err != nil {
+			u.dep.PromErr["error"] = err.Error()
+			return err
+		}
+		if owners <= 1 {
+			u.dep.PromErr["error"] = "Last owner of the school"
+			return errorr.NewBad("You are the last owner of your school, add another owner first")
+		}
+	}
 	data := entity.User{}
 	data.ID = uint(id)
-	err := u.repo.Delete(u.dep.Db.WithContext(ctx), data)
+	err = u.repo.Delete(u.dep.Db.WithContext(ctx), data)
 	if err != nil {
 		u.dep.PromErr["error"] = err.Error()
 		return err
 	}
+	audit.Track(ctx, audit.Change{Type: "user", ID: id})
 	return nil
 }
+
+func (u *user) GetStudentProfile(ctx context.Context, uid int) (*entity.ResStudentProfile, error) {
+	res, err := u.repo.GetStudentProfile(u.dep.Db.WithContext(ctx), uid)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return resStudentProfile(res), nil
+}
+
+// profileDocuments are the storage prefixes of the profile documents, the uploaded ones replace the stored ones.
+var profileDocuments = map[string]string{"student_photo": "ProfileStudent_", "student_signature": "ProfileStudentSign_", "parent_signature": "ProfileParentSign_"}
+
+func (u *user) UpdateStudentProfile(ctx context.Context, uid int, req entity.ReqStudentProfile, files map[string]multipart.File) (*entity.ResStudentProfile, error) {
+	defer func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}()
+	if err := u.validator.Struct(req); err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		u.dep.Log.Errorf("[ERROR] WHEN VALIDATE STUDENT PROFILE REQ, Error: %v", err)
+		return nil, errorr.NewBad("Missing or Invalid Request Body")
+	}
+	profile := entity.StudentProfile{}
+	if exist, err := u.repo.GetStudentProfile(u.dep.Db.WithContext(ctx), uid); err == nil {
+		profile = *exist
+	} else if _, missing := err.(errorr.BadRequest); !missing {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	documents := map[string]*string{"student_photo": &profile.StudentPhoto, "student_signature": &profile.StudentSignature, "parent_signature": &profile.ParentSignature}
+	filenames := map[string]string{"student_photo": req.StudentPhoto, "student_signature": req.StudentSignature, "parent_signature": req.ParentSignature}
+	for field, file := range files {
+		filename := fmt.Sprintf("%s_%d_%s", profileDocuments[field], uid, filenames[field])
+		if err := u.dep.Storage.UploadFile(file, filename); err != nil {
+			u.dep.PromErr["error"] = err.Error()
+			u.dep.Log.Errorf("Error Service : %v", err)
+			return nil, err
+		}
+		*documents[field] = filename
+	}
+	studentaddress, _ := json.Marshal(entity.ReqAdressSubmission{Province: req.StudentProvince, District: req.StudentDistrict, Village: req.StudentVillage, ZipCode: req.StudentZipCode, City: req.StudentCity, Detail: req.StudentDetail})
+	parentaddress, _ := json.Marshal(entity.ReqAdressSubmission{Province: req.ParentProvince, District: req.ParentDistrict, Village: req.ParentVillage, ZipCode: req.ParentZipCode, City: req.ParentCity, Detail: req.ParentDetail})
+	profile.UserID = uint(uid)
+	profile.StudentName = req.StudentName
+	profile.PlaceDate = req.PlaceDate
+	profile.Gender = req.Gender
+	profile.Religion = req.Religion
+	profile.GraduationFrom = req.GraduationFrom
+	profile.NISN = req.NISN
+	profile.StudentAddress = string(studentaddress)
+	profile.ParentName = req.ParentName
+	profile.ParentJob = req.ParentJob
+	profile.ParentReligion = req.ParentReligion
+	profile.ParentGender = req.ParentGender
+	profile.ParentAddress = string(parentaddress)
+	profile.ParentPhone = req.ParentPhone
+	profile.ReportAverage = req.ReportAverage
+	profile.Achievements = req.Achievements
+	res, err := u.repo.UpdateStudentProfile(u.dep.Db.WithContext(ctx), profile)
+	if err != nil {
+		u.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return resStudentProfile(res), nil
+}
+
+func resStudentProfile(profile *entity.StudentProfile) *entity.ResStudentProfile {
+	studentaddress := entity.ReqAdressSubmission{}
+	parentaddress := entity.ReqAdressSubmission{}
+	json.Unmarshal([]byte(profile.StudentAddress), &studentaddress)
+	json.Unmarshal([]byte(profile.ParentAddress), &parentaddress)
+	return &entity.ResStudentProfile{
+		StudentData: entity.StudentData{
+			Photo:          profile.StudentPhoto,
+			Name:           profile.StudentName,
+			PlaceDate:      profile.PlaceDate,
+			NISN:           profile.NISN,
+			GraduationFrom: profile.GraduationFrom,
+			Religion:       profile.Religion,
+			Gender:         profile.Gender,
+			Adress:         studentaddress,
+		},
+		ParentData: entity.ParentData{
+			Name:     profile.ParentName,
+			Job:      profile.ParentJob,
+			Religion: profile.ParentReligion,
+			Phone:    profile.ParentPhone,
+			Adress:   parentaddress,
+			Gender:   profile.ParentGender,
+		},
+		StudentSignature: profile.StudentSignature,
+		ParentSignature:  profile.ParentSignature,
+		ReportAverage:    profile.ReportAverage,
+		Achievements:     profile.Achievements,
+		UpdatedAt:        profile.UpdatedAt,
+	}
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	oidc "github.com/education-hub/BE/app/oidc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Flow is an autogenerated mock type for the Flow type
+type Flow struct {
+	mock.Mock
+}
+
+// Begin provides a mock function with given fields: ctx, role
+func (_m *Flow) Begin(ctx context.Context, role string) (string, error) {
+	ret := _m.Called(ctx, role)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Finish provides a mock function with given fields: ctx, state, code
+func (_m *Flow) Finish(ctx context.Context, state string, code string) (*oidc.Identity, string, error) {
+	ret := _m.Called(ctx, state, code)
+
+	var r0 *oidc.Identity
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*oidc.Identity, string, error)); ok {
+		return rf(ctx, state, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *oidc.Identity); ok {
+		r0 = rf(ctx, state, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oidc.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
+		r1 = rf(ctx, state, code)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, state, code)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewFlow interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFlow creates a new instance of Flow. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFlow(t mockConstructorTestingTNewFlow) *Flow {
+	mock := &Flow{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
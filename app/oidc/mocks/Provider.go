@@ -0,0 +1,80 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	oidc "github.com/education-hub/BE/app/oidc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Provider is an autogenerated mock type for the Provider type
+type Provider struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, state, nonce
+func (_m *Provider) AuthCodeURL(ctx context.Context, state string, nonce string) (string, error) {
+	ret := _m.Called(ctx, state, nonce)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, state, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, state, nonce)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, state, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exchange provides a mock function with given fields: ctx, code, nonce
+func (_m *Provider) Exchange(ctx context.Context, code string, nonce string) (*oidc.Identity, error) {
+	ret := _m.Called(ctx, code, nonce)
+
+	var r0 *oidc.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*oidc.Identity, error)); ok {
+		return rf(ctx, code, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *oidc.Identity); ok {
+		r0 = rf(ctx, code, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oidc.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, code, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewProvider creates a new instance of Provider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewProvider(t mockConstructorTestingTNewProvider) *Provider {
+	mock := &Provider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,72 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	oidc "github.com/education-hub/BE/app/oidc"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Store is an autogenerated mock type for the Store type
+type Store struct {
+	mock.Mock
+}
+
+// SaveState provides a mock function with given fields: ctx, state, val, ttl
+func (_m *Store) SaveState(ctx context.Context, state string, val oidc.State, ttl time.Duration) error {
+	ret := _m.Called(ctx, state, val, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, oidc.State, time.Duration) error); ok {
+		r0 = rf(ctx, state, val, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TakeState provides a mock function with given fields: ctx, state
+func (_m *Store) TakeState(ctx context.Context, state string) (*oidc.State, error) {
+	ret := _m.Called(ctx, state)
+
+	var r0 *oidc.State
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*oidc.State, error)); ok {
+		return rf(ctx, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *oidc.State); ok {
+		r0 = rf(ctx, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oidc.State)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewStore(t mockConstructorTestingTNewStore) *Store {
+	mock := &Store{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
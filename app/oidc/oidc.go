@@ -0,0 +1,107 @@
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/helper"
+)
+
+// StateTTL is how long the user has to come back from the identity provider.
+const StateTTL = 10 * time.Minute
+
+type (
+	// Identity is what the identity provider vouches for about the user, Provider is its issuer
+	// and Subject the id of the user there, it never changes even if the email does.
+	Identity struct {
+		Provider      string
+		Subject       string
+		Email         string
+		EmailVerified bool
+		GivenName     string
+		FamilyName    string
+	}
+	// Provider is an OpenID Connect identity provider, Exchange trades the authorization code for
+	// the verified identity of the user and refuses an id token not carrying nonce.
+	Provider interface {
+		AuthCodeURL(ctx context.Context, state string, nonce string) (string, error)
+		Exchange(ctx context.Context, code string, nonce string) (*Identity, error)
+	}
+	// State is kept between the redirect to the identity provider and the callback.
+	State struct {
+		Nonce string `json:"nonce"`
+		Role  string `json:"role"`
+	}
+	// Store keeps the pending states, TakeState returns a state once and removes it.
+	Store interface {
+		SaveState(ctx context.Context, state string, val State, ttl time.Duration) error
+		TakeState(ctx context.Context, state string) (*State, error)
+	}
+	Flow interface {
+		// Begin returns the url the user is sent to, the role is the one the account is created
+		// with when the user is new.
+		Begin(ctx context.Context, role string) (string, error)
+		// Finish checks the state the identity provider sent back and exchanges the code.
+		Finish(ctx context.Context, state string, code string) (*Identity, string, error)
+	}
+	flow struct {
+		provider Provider
+		store    Store
+		dep      dependency.Depend
+	}
+)
+
+// SelfServiceRoles are the roles an account may be created with through a social login,
+// the staff accounts are only made by the school.
+var SelfServiceRoles = map[string]bool{"student": true, "parent": true}
+
+func NewFlow(provider Provider, store Store, dep dependency.Depend) Flow {
+	return &flow{provider: provider, store: store, dep: dep}
+}
+
+func (f *flow) Begin(ctx context.Context, role string) (string, error) {
+	if !SelfServiceRoles[role] {
+		f.dep.PromErr["error"] = "Social login not allowed for role " + role
+		return "", errorr.NewBad("Invalid Role")
+	}
+	state, _, err := helper.NewToken()
+	if err != nil {
+		f.dep.PromErr["error"] = err.Error()
+		return "", errorr.NewInternal("Internal Server Error")
+	}
+	nonce, _, err := helper.NewToken()
+	if err != nil {
+		f.dep.PromErr["error"] = err.Error()
+		return "", errorr.NewInternal("Internal Server Error")
+	}
+	if err := f.store.SaveState(ctx, state, State{Nonce: nonce, Role: role}, StateTTL); err != nil {
+		f.dep.PromErr["error"] = err.Error()
+		return "", err
+	}
+	url, err := f.provider.AuthCodeURL(ctx, state, nonce)
+	if err != nil {
+		f.dep.PromErr["error"] = err.Error()
+		return "", err
+	}
+	return url, nil
+}
+
+func (f *flow) Finish(ctx context.Context, state string, code string) (*Identity, string, error) {
+	if state == "" || code == "" {
+		f.dep.PromErr["error"] = "Missing state or code"
+		return nil, "", errorr.NewBad("Missing or Invalid Request Body")
+	}
+	saved, err := f.store.TakeState(ctx, state)
+	if err != nil {
+		f.dep.PromErr["error"] = err.Error()
+		return nil, "", err
+	}
+	identity, err := f.provider.Exchange(ctx, code, saved.Nonce)
+	if err != nil {
+		f.dep.PromErr["error"] = err.Error()
+		return nil, "", err
+	}
+	return identity, saved.Role, nil
+}
@@ -0,0 +1,227 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/education-hub/BE/app/oidc"
+	"github.com/education-hub/BE/app/oidc/mocks"
+	"github.com/education-hub/BE/config"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"github.com/golang-jwt/jwt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOidc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Oidc Suite")
+}
+
+// idp is a local identity provider, the token endpoint answers with the id token sign returns.
+type idp struct {
+	srv  *httptest.Server
+	keys map[string]*rsa.PrivateKey
+	sign func() string
+}
+
+func newIdp() *idp {
+	res := &idp{keys: map[string]*rsa.PrivateKey{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 res.srv.URL,
+			"authorization_endpoint": res.srv.URL + "/auth",
+			"token_endpoint":         res.srv.URL + "/token",
+			"jwks_uri":               res.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		keys := []map[string]string{}
+		for kid, key := range res.keys {
+			keys = append(keys, map[string]string{
+				"kid": kid,
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "kode" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "akses", "token_type": "Bearer", "expires_in": 3600, "id_token": res.sign()})
+	})
+	res.srv = httptest.NewServer(mux)
+	return res
+}
+
+func (i *idp) addKey(kid string) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).Should(BeNil())
+	i.keys[kid] = key
+	return key
+}
+
+func token(key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	t.Header["kid"] = kid
+	res, err := t.SignedString(key)
+	Expect(err).Should(BeNil())
+	return res
+}
+
+var _ = Describe("oidc", func() {
+	var Idp *idp
+	var Store *mocks.Store
+	var Flow oidc.Flow
+	var ctx context.Context
+	var key *rsa.PrivateKey
+	var saved oidc.State
+	var claims jwt.MapClaims
+	BeforeEach(func() {
+		Idp = newIdp()
+		DeferCleanup(Idp.srv.Close)
+		key = Idp.addKey("satu")
+		Store = mocks.NewStore(GinkgoT())
+		conf := &config.Config{OIDC: config.OIDCConfig{Issuer: Idp.srv.URL, ClientID: "klien", ClientSecret: "rahasia", RedirectURL: "http://localhost/callback"}}
+		dep := dependency.Depend{Config: conf, Log: logrus.New(), PromErr: make(map[string]string, 1)}
+		Flow = oidc.NewFlow(oidc.NewProvider(conf, dep.Log), Store, dep)
+		ctx = context.Background()
+		saved = oidc.State{Nonce: "nonce", Role: "student"}
+		claims = jwt.MapClaims{
+			"iss":            Idp.srv.URL,
+			"aud":            "klien",
+			"sub":            "1234567890",
+			"email":          "satrio@gmail.com",
+			"email_verified": true,
+			"given_name":     "Satrio",
+			"family_name":    "Wibowo",
+			"nonce":          "nonce",
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+		Idp.sign = func() string { return token(key, "satu", claims) }
+	})
+	Context("Mulai Login", func() {
+		When("Role Bukan Siswa Atau Orang Tua", func() {
+			It("Akan Menolak", func() {
+				_, err := Flow.Begin(ctx, "administrator")
+				Expect(err).To(Equal(errorr.NewBad("Invalid Role")))
+			})
+		})
+		When("Role Siswa", func() {
+			It("Akan Mengarahkan Ke Provider Dengan State Dan Nonce", func() {
+				Store.On("SaveState", mock.Anything, mock.Anything, mock.Anything, oidc.StateTTL).Return(nil).Once()
+				res, err := Flow.Begin(ctx, "student")
+				Expect(err).Should(BeNil())
+				link, err := url.Parse(res)
+				Expect(err).Should(BeNil())
+				Expect(link.Path).To(Equal("/auth"))
+				query := link.Query()
+				Expect(query.Get("client_id")).To(Equal("klien"))
+				Expect(query.Get("scope")).To(Equal("openid email profile"))
+				state := Store.Calls[0].Arguments.String(1)
+				val := Store.Calls[0].Arguments.Get(2).(oidc.State)
+				Expect(query.Get("state")).To(Equal(state))
+				Expect(query.Get("nonce")).To(Equal(val.Nonce))
+				Expect(val.Role).To(Equal("student"))
+			})
+		})
+	})
+	Context("Selesai Login", func() {
+		When("Token Valid", func() {
+			It("Akan Mengembalikan Identitas", func() {
+				Store.On("TakeState", mock.Anything, "state").Return(&saved, nil).Once()
+				res, role, err := Flow.Finish(ctx, "state", "kode")
+				Expect(err).Should(BeNil())
+				Expect(role).To(Equal("student"))
+				Expect(*res).To(Equal(oidc.Identity{Provider: Idp.srv.URL, Subject: "1234567890", Email: "satrio@gmail.com", EmailVerified: true, GivenName: "Satrio", FamilyName: "Wibowo"}))
+			})
+		})
+		When("State Tidak Dikenal", func() {
+			It("Akan Menolak", func() {
+				Store.On("TakeState", mock.Anything, "state").Return(nil, errorr.NewBad("Invalid or Expired State")).Once()
+				_, _, err := Flow.Finish(ctx, "state", "kode")
+				Expect(err).To(Equal(errorr.NewBad("Invalid or Expired State")))
+			})
+		})
+		When("Kode Salah", func() {
+			It("Akan Menolak", func() {
+				Store.On("TakeState", mock.Anything, "state").Return(&saved, nil).Once()
+				_, _, err := Flow.Finish(ctx, "state", "salah")
+				Expect(err).To(Equal(errorr.NewBad("Invalid Authorization Code")))
+			})
+		})
+		When("Nonce Berbeda", func() {
+			It("Akan Menolak", func() {
+				claims["nonce"] = "lain"
+				Store.On("TakeState", mock.Anything, "state").Return(&saved, nil).Once()
+				_, _, err := Flow.Finish(ctx, "state", "kode")
+				Expect(err).To(Equal(errorr.NewBad("Invalid ID Token")))
+			})
+		})
+		When("Token Untuk Klien Lain", func() {
+			It("Akan Menolak", func() {
+				claims["aud"] = []string{"lain"}
+				Store.On("TakeState", mock.Anything, "state").Return(&saved, nil).Once()
+				_, _, err := Flow.Finish(ctx, "state", "kode")
+				Expect(err).To(Equal(errorr.NewBad("Invalid ID Token")))
+			})
+		})
+		When("Penerbit Berbeda", func() {
+			It("Akan Menolak", func() {
+				claims["iss"] = "https://accounts.google.com"
+				Store.On("TakeState", mock.Anything, "state").Return(&saved, nil).Once()
+				_, _, err := Flow.Finish(ctx, "state", "kode")
+				Expect(err).To(Equal(errorr.NewBad("Invalid ID Token")))
+			})
+		})
+		When("Token Kedaluwarsa", func() {
+			It("Akan Menolak", func() {
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+				Store.On("TakeState", mock.Anything, "state").Return(&saved, nil).Once()
+				_, _, err := Flow.Finish(ctx, "state", "kode")
+				Expect(err).To(Equal(errorr.NewBad("Invalid ID Token")))
+			})
+		})
+		When("Ditandatangani Kunci Lain", func() {
+			It("Akan Menolak", func() {
+				other, err := rsa.GenerateKey(rand.Reader, 2048)
+				Expect(err).Should(BeNil())
+				Idp.sign = func() string { return token(other, "satu", claims) }
+				Store.On("TakeState", mock.Anything, "state").Return(&saved, nil).Once()
+				_, _, err = Flow.Finish(ctx, "state", "kode")
+				Expect(err).To(Equal(errorr.NewBad("Invalid ID Token")))
+			})
+		})
+		When("Provider Mengganti Kunci", func() {
+			It("Akan Mengambil Kunci Baru", func() {
+				Store.On("TakeState", mock.Anything, "state").Return(&saved, nil).Twice()
+				_, _, err := Flow.Finish(ctx, "state", "kode")
+				Expect(err).Should(BeNil())
+				next := Idp.addKey("dua")
+				Idp.sign = func() string { return token(next, "dua", claims) }
+				_, _, err = Flow.Finish(ctx, "state", "kode")
+				Expect(err).Should(BeNil())
+			})
+		})
+	})
+})
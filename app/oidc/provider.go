@@ -0,0 +1,214 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/education-hub/BE/config"
+	"github.com/education-hub/BE/errorr"
+	"github.com/golang-jwt/jwt"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+// defaultIssuer is Google, any issuer publishing an OpenID discovery document works the same way.
+const defaultIssuer = "https://accounts.google.com"
+
+type (
+	// provider talks to the identity provider found at its issuer, the discovery document and the
+	// signing keys are fetched on first use and the keys again whenever a token names an unknown one.
+	provider struct {
+		issuer       string
+		clientid     string
+		clientsecret string
+		redirect     string
+		client       *http.Client
+		log          *logrus.Logger
+
+		mu     sync.Mutex
+		oauth  *oauth2.Config
+		jwks   string
+		keys   map[string]*rsa.PublicKey
+		loaded bool
+	}
+	discovery struct {
+		Issuer   string `json:"issuer"`
+		AuthURL  string `json:"authorization_endpoint"`
+		TokenURL string `json:"token_endpoint"`
+		JWKSURL  string `json:"jwks_uri"`
+	}
+	jwk struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	}
+)
+
+func NewProvider(c *config.Config, log *logrus.Logger) Provider {
+	issuer := c.OIDC.Issuer
+	if issuer == "" {
+		issuer = defaultIssuer
+	}
+	return &provider{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientid:     c.OIDC.ClientID,
+		clientsecret: c.OIDC.ClientSecret,
+		redirect:     c.OIDC.RedirectURL,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		log:          log,
+	}
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state string, nonce string) (string, error) {
+	oauth, err := p.config(ctx)
+	if err != nil {
+		return "", err
+	}
+	return oauth.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce)), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code string, nonce string) (*Identity, error) {
+	oauth, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := oauth.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code)
+	if err != nil {
+		p.log.Errorf("[ERROR]WHEN EXCHANGING OIDC CODE, Err : %v", err)
+		return nil, errorr.NewBad("Invalid Authorization Code")
+	}
+	raw, _ := token.Extra("id_token").(string)
+	if raw == "" {
+		p.log.Errorf("[ERROR]OIDC TOKEN RESPONSE WITHOUT ID TOKEN")
+		return nil, errorr.NewBad("Invalid Authorization Code")
+	}
+	return p.verify(ctx, raw, nonce)
+}
+
+// verify checks the signature, the issuer, the audience, the expiry and the nonce of the id token.
+func (p *provider) verify(ctx context.Context, raw string, nonce string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, kid)
+	})
+	if err != nil {
+		p.log.Errorf("[ERROR]WHEN VERIFYING ID TOKEN, Err : %v", err)
+		return nil, errorr.NewBad("Invalid ID Token")
+	}
+	iss, _ := claims["iss"].(string)
+	// Google issues tokens under its host with or without the scheme
+	if strings.TrimPrefix(iss, "https://") != strings.TrimPrefix(p.issuer, "https://") || !claims.VerifyAudience(p.clientid, true) || !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		p.log.Errorf("[ERROR]ID TOKEN ISSUED BY %s FOR %v REJECTED", iss, claims["aud"])
+		return nil, errorr.NewBad("Invalid ID Token")
+	}
+	if claimed, _ := claims["nonce"].(string); claimed == "" || claimed != nonce {
+		p.log.Warnf("[WARN]ID TOKEN NONCE MISMATCH, POSSIBLE REPLAY")
+		return nil, errorr.NewBad("Invalid ID Token")
+	}
+	res := Identity{Provider: p.issuer}
+	res.Subject, _ = claims["sub"].(string)
+	res.Email, _ = claims["email"].(string)
+	res.GivenName, _ = claims["given_name"].(string)
+	res.FamilyName, _ = claims["family_name"].(string)
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		res.EmailVerified = verified
+	case string:
+		res.EmailVerified = verified == "true"
+	}
+	if res.Subject == "" {
+		return nil, errorr.NewBad("Invalid ID Token")
+	}
+	return &res, nil
+}
+
+// config reads the discovery document once, the endpoints do not change while the app runs.
+func (p *provider) config(ctx context.Context) (*oauth2.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth != nil {
+		return p.oauth, nil
+	}
+	doc := discovery{}
+	if err := p.get(ctx, p.issuer+"/.well-known/openid-configuration", &doc); err != nil {
+		return nil, err
+	}
+	p.jwks = doc.JWKSURL
+	p.oauth = &oauth2.Config{
+		ClientID:     p.clientid,
+		ClientSecret: p.clientsecret,
+		RedirectURL:  p.redirect,
+		Endpoint:     oauth2.Endpoint{AuthURL: doc.AuthURL, TokenURL: doc.TokenURL},
+		Scopes:       []string{"openid", "email", "profile"},
+	}
+	return p.oauth, nil
+}
+
+// key returns the signing key named kid, the keys are fetched again once as the provider rotates them.
+func (p *provider) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	if err := p.get(ctx, p.jwks, &set); err != nil {
+		return nil, err
+	}
+	p.keys = make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, val := range set.Keys {
+		if val.Kty != "RSA" {
+			continue
+		}
+		n, err1 := base64.RawURLEncoding.DecodeString(val.N)
+		e, err2 := base64.RawURLEncoding.DecodeString(val.E)
+		if err1 != nil || err2 != nil {
+			p.log.Errorf("[ERROR]WHEN DECODING SIGNING KEY %s", val.Kid)
+			continue
+		}
+		p.keys[val.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %s", kid)
+	}
+	return key, nil
+}
+
+func (p *provider) get(ctx context.Context, url string, res any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		p.log.Errorf("[ERROR]WHEN CREATING HTTP REQUEST, Error : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		p.log.Errorf("[ERROR]WHEN GETTING %s, Error : %v", url, err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		p.log.Errorf("[ERROR]WHEN GETTING %s, Status : %d", url, resp.StatusCode)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		p.log.Errorf("[ERROR]WHEN DECODING %s, Error : %v", url, err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
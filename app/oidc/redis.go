@@ -0,0 +1,53 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/education-hub/BE/errorr"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+// redisStore keeps a pending state under oidc:state:<state>.
+type redisStore struct {
+	rds *redis.Client
+	log *logrus.Logger
+}
+
+func NewRedisStore(rds *redis.Client, log *logrus.Logger) Store {
+	return &redisStore{rds: rds, log: log}
+}
+
+func (r *redisStore) SaveState(ctx context.Context, state string, val State, ttl time.Duration) error {
+	data, _ := json.Marshal(val)
+	if err := r.rds.Set(ctx, "oidc:state:"+state, data, ttl).Err(); err != nil {
+		r.log.Errorf("[ERROR]WHEN SAVING OIDC STATE, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+func (r *redisStore) TakeState(ctx context.Context, state string) (*State, error) {
+	key := "oidc:state:" + state
+	var get *redis.StringCmd
+	_, err := r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if err == redis.Nil {
+		return nil, errorr.NewBad("Invalid or Expired State")
+	}
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN TAKING OIDC STATE, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	res := State{}
+	if err := json.Unmarshal([]byte(get.Val()), &res); err != nil {
+		r.log.Errorf("[ERROR]WHEN DECODING OIDC STATE, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/education-hub/BE/app/session"
 	"github.com/education-hub/BE/errorr"
 	"github.com/education-hub/BE/helper"
 	"github.com/go-playground/validator"
@@ -19,6 +20,9 @@ func AdminMiddleWare(next echo.HandlerFunc) echo.HandlerFunc {
 		if role != "administrator" {
 			return c.JSON(http.StatusUnauthorized, map[string]any{"code": 401, "message": "UnAuthorization"})
 		}
+		if helper.GetMFA(c.Get("user").(*jwt.Token)) != "true" {
+			return c.JSON(http.StatusForbidden, map[string]any{"code": 403, "message": "Two-Factor Authentication Required"})
+		}
 		return next(c)
 	}
 }
@@ -31,6 +35,15 @@ func StudentMiddleWare(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+func ParentMiddleWare(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		role := helper.GetRole(c.Get("user").(*jwt.Token))
+		if role != "parent" {
+			return c.JSON(http.StatusUnauthorized, map[string]any{"code": 401, "message": "UnAuthorization"})
+		}
+		return next(c)
+	}
+}
 
 func SuperAdmin(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -38,9 +51,32 @@ func SuperAdmin(next echo.HandlerFunc) echo.HandlerFunc {
 		if role != "su" {
 			return c.JSON(http.StatusUnauthorized, map[string]any{"code": 401, "message": "UnAuthorization"})
 		}
+		if helper.GetMFA(c.Get("user").(*jwt.Token)) != "true" {
+			return c.JSON(http.StatusForbidden, map[string]any{"code": 403, "message": "Two-Factor Authentication Required"})
+		}
 		return next(c)
 	}
 }
+
+// RevokedMiddleWare rejects the tokens of ended sessions, tokens issued without a session are rejected as well.
+func RevokedMiddleWare(sessions session.Manager) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			jti := helper.GetJti(c.Get("user").(*jwt.Token))
+			if jti == "" {
+				return c.JSON(http.StatusUnauthorized, map[string]any{"code": 401, "message": "UnAuthorization"})
+			}
+			revoked, err := sessions.IsRevoked(c.Request().Context(), jti)
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, map[string]any{"code": 500, "message": "Internal Server Error"})
+			}
+			if revoked {
+				return c.JSON(http.StatusUnauthorized, map[string]any{"code": 401, "message": "Token Revoked"})
+			}
+			return next(c)
+		}
+	}
+}
 func StatusVerifiedMiddleWare(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		isverified := helper.GetStatus(c.Get("user").(*jwt.Token))
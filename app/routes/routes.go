@@ -3,9 +3,11 @@ package routes
 import (
 	"text/template"
 
+	"github.com/education-hub/BE/app/audit"
 	schoolhand "github.com/education-hub/BE/app/features/school/handler"
 	trxhand "github.com/education-hub/BE/app/features/transaction/handler"
 	userhand "github.com/education-hub/BE/app/features/user/handler"
+	"github.com/education-hub/BE/app/session"
 	"github.com/education-hub/BE/config/dependency"
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
@@ -16,10 +18,12 @@ import (
 
 type Routes struct {
 	dig.In
-	Depend dependency.Depend
-	User   userhand.User
-	School schoolhand.School
-	Trx    trxhand.Transaction
+	Depend  dependency.Depend
+	User    userhand.User
+	School  schoolhand.School
+	Trx     trxhand.Transaction
+	Session session.Manager
+	Audit   audit.Log
 }
 
 func (r *Routes) RegisterRoutes() {
@@ -27,9 +31,11 @@ func (r *Routes) RegisterRoutes() {
 	ro.Validator = &CustomValidator{validator: validator.New(), log: r.Depend.Log}
 	ro.Use(MetricsMiddleware)
 	ro.Use(middleware.RemoveTrailingSlash())
+	ro.Use(middleware.RequestID())
 	ro.Use(middleware.Logger())
 	ro.Use(middleware.Recover())
 	ro.Use(middleware.CORS())
+	ro.Use(r.Audit.Middleware)
 	ro.GET("/prometheus", echo.WrapHandler(promhttp.Handler()))
 	//static
 	ro.Renderer = &TemplateRenderer{
@@ -37,9 +43,14 @@ func (r *Routes) RegisterRoutes() {
 	}
 	//No Auth
 	ro.POST("/login", r.User.Login)
+	ro.POST("/login/2fa", r.User.LoginTwoFactor)
+	ro.GET("/oauth/google", r.User.GoogleLogin)
+	ro.POST("/oauth/google", r.User.GoogleCallback)
+	ro.POST("/refresh", r.User.Refresh)
 	ro.POST("/register", r.User.Register)
 	ro.GET("/verify/:verifcode", r.User.Verify)
 	ro.GET("/updateverif/:verifcode", r.User.UpdateVerif)
+	ro.POST("/verify/resend", r.User.ResendVerification)
 	ro.POST("/forgot", r.User.Forgotpass)
 	ro.POST("/reset/:token", r.User.ResetPass)
 	ro.GET("/getcaptcha", r.User.GetCaptcha)
@@ -51,17 +62,31 @@ func (r *Routes) RegisterRoutes() {
 	ro.GET("/schools/search", r.School.Search)
 	ro.GET("/schools/:id", r.School.GetById)
 	ro.GET("/gmeet", r.School.CreateGmeet)
+	ro.GET("/letters/:reference", r.School.VerifyLetter)
 	///Third-Party Payment Notification
 	ro.POST("/notif", r.Trx.MidtransNotification)
 	// AUTH
-	rauth := ro.Group("", middleware.JWT([]byte(r.Depend.Config.JwtSecret)))
+	rauth := ro.Group("", middleware.JWT([]byte(r.Depend.Config.JwtSecret)), RevokedMiddleWare(r.Session))
 	rauth.GET("/quiz/set/:token", r.School.SetNewToken, SuperAdmin)
 	//User
+	rauth.POST("/logout", r.User.Logout)
+	rauth.POST("/logout/all", r.User.LogoutAll)
 	rauth.PUT("/users", r.User.Update)
 	rauth.DELETE("/users", r.User.Delete)
 	rauth.GET("/users", r.User.GetProfile)
+	rauth.GET("/users/export", r.User.Export)
+	rauth.POST("/users/2fa", r.User.EnrollTwoFactor)
+	rauth.PUT("/users/2fa", r.User.EnableTwoFactor)
+	rauth.POST("/users/2fa/disable", r.User.DisableTwoFactor)
+	rauth.POST("/users/2fa/recovery-codes", r.User.RegenerateRecoveryCodes)
+	rauth.DELETE("/users/:id/2fa", r.User.ResetTwoFactor, SuperAdmin)
+	rauth.DELETE("/lockouts/:username", r.User.UnlockLogin, SuperAdmin)
+	rauth.GET("/audit", r.School.GetAuditLog, SuperAdmin)
 	rauth.GET("/progresses/:id", r.School.GetProgressById)
+	rauth.GET("/progresses/:id/timeline", r.School.GetProgressTimeline)
+	rauth.GET("/submissions/:id/pdf", r.School.GetSubmissionPdf)
 	rverif := rauth.Group("", StatusVerifiedMiddleWare)
+	rverif.DELETE("/parent-links/:id", r.User.DeleteParentLink)
 
 	rstdnt := rverif.Group("", StudentMiddleWare)
 	///student Area
@@ -69,8 +94,30 @@ func (r *Routes) RegisterRoutes() {
 	rstdnt.GET("/transactions/:id", r.Trx.GetDetailTransaction)
 	rstdnt.POST("/transactions/checkout", r.Trx.CreateTransaction)
 	rstdnt.POST("/school/register", r.School.CreateSubbmision)
+	rstdnt.POST("/school/register/profile", r.School.CreateSubmissionFromProfile)
+	rstdnt.PUT("/school/register/:id", r.School.ReviseSubmission)
 	rstdnt.GET("/users/progress", r.School.GetAllProgressByUid)
+	rstdnt.GET("/users/student-profile", r.User.GetStudentProfile)
+	rstdnt.PUT("/users/student-profile", r.User.UpdateStudentProfile)
+	rstdnt.PUT("/users/progress/:id/offer", r.School.RespondOffer)
+	rstdnt.PUT("/users/progress/:id/withdraw", r.School.Withdraw)
+	rstdnt.GET("/users/progress/:id/letter", r.School.GetAcceptanceLetter)
 	rstdnt.POST("/reviews", r.School.AddReview)
+	rstdnt.POST("/appeals", r.School.CreateAppeal)
+	rstdnt.GET("/appeals", r.School.GetAppealsByUid)
+	rstdnt.GET("/users/parents", r.User.GetParents)
+	rstdnt.PUT("/users/parents/:id", r.User.RespondParent)
+
+	///parent Area
+	rparent := rverif.Group("", ParentMiddleWare)
+	rparent.POST("/parents/children", r.User.InviteChild)
+	rparent.GET("/parents/children", r.User.GetChildren)
+	rchild := rparent.Group("/parents/children/:child", r.User.ChildMiddleWare)
+	rchild.GET("/progress", r.School.GetAllProgressByUid)
+	rchild.GET("/progress/:id/timeline", r.School.GetProgressTimeline)
+	rchild.GET("/transactions", r.Trx.GetTransactionStudent)
+	rchild.GET("/transactions/:id", r.Trx.GetDetailTransaction)
+	rchild.POST("/transactions/checkout", r.Trx.CreateTransaction)
 
 	//ADMIN AREA
 	// not veried
@@ -80,11 +127,26 @@ func (r *Routes) RegisterRoutes() {
 	radmm.GET("/admin/admission/:id", r.School.GetSubmissionByid)
 	radmm.GET("/quiz", r.School.GetTestResult)
 	radmm.GET("/file/:fname", r.School.GetBase64File)
+	radmm.GET("/admin/pipeline", r.School.GetPipeline)
+	radmm.GET("/admin/quotas", r.School.GetQuotas)
+	radmm.GET("/admin/waitlist", r.School.GetWaitlist)
+	radmm.GET("/admin/zonasi", r.School.GetZonasiRanking)
+	radmm.GET("/admin/selection/criteria", r.School.GetSelectionCriteria)
+	radmm.GET("/admin/selection", r.School.GetSelection)
+	radmm.GET("/admin/selection/export", r.School.ExportSelection)
+	radmm.GET("/admin/periods", r.School.GetPeriods)
+	radmm.GET("/admin/appeals", r.School.GetAppeals)
+	radmm.GET("/admin/letter-template", r.School.GetLetterTemplate)
+	radmm.GET("/admin/members", r.School.GetMembers)
+	radmm.GET("/admin/members/invitations", r.School.GetInvitations)
+	radmm.GET("/admin/audit", r.School.GetAuditLog)
 	//verfied
 	radm := rverif.Group("", AdminMiddleWare)
 	radm.POST("/school", r.School.Create)
+	radm.PUT("/progresses", r.School.BulkUpdateProgress)
 	radm.PUT("/progresses/:id", r.School.UpdateProgressByid)
 	radm.DELETE("/progresses/:id", r.School.DeleteProgressByid)
+	radm.POST("/progresses/:id/notes", r.School.AddNote)
 	radm.DELETE("/school/:id", r.School.Delete)
 	radm.PUT("/school", r.School.Update)
 	radm.POST("/achievements", r.School.AddAchievement)
@@ -101,4 +163,18 @@ func (r *Routes) RegisterRoutes() {
 	radm.PUT("/payments", r.School.UpdatePayment)
 	radm.DELETE("/payments/:id", r.School.DeletePayment)
 	radm.POST("/quiz", r.School.CreateQuiz)
+	radm.PUT("/admin/pipeline", r.School.UpdatePipeline)
+	radm.PUT("/admin/quotas", r.School.UpdateQuotas)
+	radm.PUT("/admin/selection/criteria", r.School.UpdateSelectionCriteria)
+	radm.POST("/admin/selection/advance", r.School.AdvanceSelection)
+	radm.PUT("/admin/appeals/:id", r.School.DecideAppeal)
+	radm.PUT("/admin/letter-template", r.School.UpdateLetterTemplate)
+	radm.POST("/admin/periods", r.School.CreatePeriod)
+	radm.PUT("/admin/periods/:id", r.School.UpdatePeriod)
+	radm.DELETE("/admin/periods/:id", r.School.DeletePeriod)
+	radm.POST("/admin/members/invitations", r.School.InviteMember)
+	radm.DELETE("/admin/members/invitations/:id", r.School.DeleteInvitation)
+	radm.POST("/admin/invitations/:token", r.School.AcceptInvitation)
+	radm.PUT("/admin/members/:id", r.School.UpdateMember)
+	radm.DELETE("/admin/members/:id", r.School.DeleteMember)
 }
@@ -0,0 +1,212 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entities "github.com/education-hub/BE/app/entities"
+	mock "github.com/stretchr/testify/mock"
+
+	session "github.com/education-hub/BE/app/session"
+)
+
+// Manager is an autogenerated mock type for the Manager type
+type Manager struct {
+	mock.Mock
+}
+
+// Challenge provides a mock function with given fields: ctx, uid
+func (_m *Manager) Challenge(ctx context.Context, uid int) (string, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseChallenge provides a mock function with given fields: ctx, challenge
+func (_m *Manager) CloseChallenge(ctx context.Context, challenge string) error {
+	ret := _m.Called(ctx, challenge)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, user, mfa
+func (_m *Manager) Create(ctx context.Context, user *entities.User, mfa bool) (*session.Tokens, error) {
+	ret := _m.Called(ctx, user, mfa)
+
+	var r0 *session.Tokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.User, bool) (*session.Tokens, error)); ok {
+		return rf(ctx, user, mfa)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.User, bool) *session.Tokens); ok {
+		r0 = rf(ctx, user, mfa)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*session.Tokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.User, bool) error); ok {
+		r1 = rf(ctx, user, mfa)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FailChallenge provides a mock function with given fields: ctx, challenge
+func (_m *Manager) FailChallenge(ctx context.Context, challenge string) error {
+	ret := _m.Called(ctx, challenge)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetChallenge provides a mock function with given fields: ctx, challenge
+func (_m *Manager) GetChallenge(ctx context.Context, challenge string) (int, error) {
+	ret := _m.Called(ctx, challenge)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, challenge)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, challenge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsRevoked provides a mock function with given fields: ctx, jti
+func (_m *Manager) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	ret := _m.Called(ctx, jti)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, jti)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, jti)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jti)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refresh provides a mock function with given fields: ctx, token
+func (_m *Manager) Refresh(ctx context.Context, token string) (*session.Tokens, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *session.Tokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*session.Tokens, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *session.Tokens); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*session.Tokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, uid, id
+func (_m *Manager) Revoke(ctx context.Context, uid int, id string) error {
+	ret := _m.Called(ctx, uid, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAll provides a mock function with given fields: ctx, uid
+func (_m *Manager) RevokeAll(ctx context.Context, uid int) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewManager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewManager(t mockConstructorTestingTNewManager) *Manager {
+	mock := &Manager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
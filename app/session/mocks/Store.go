@@ -0,0 +1,250 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	session "github.com/education-hub/BE/app/session"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Store is an autogenerated mock type for the Store type
+type Store struct {
+	mock.Mock
+}
+
+// DeleteChallenge provides a mock function with given fields: ctx, hash
+func (_m *Store) DeleteChallenge(ctx context.Context, hash string) error {
+	ret := _m.Called(ctx, hash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSession provides a mock function with given fields: ctx, uid, id
+func (_m *Store) DeleteSession(ctx context.Context, uid int, id string) error {
+	ret := _m.Called(ctx, uid, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailChallenge provides a mock function with given fields: ctx, hash, ttl
+func (_m *Store) FailChallenge(ctx context.Context, hash string, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, hash, ttl)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, error)); ok {
+		return rf(ctx, hash, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, hash, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, hash, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChallenge provides a mock function with given fields: ctx, hash
+func (_m *Store) GetChallenge(ctx context.Context, hash string) (int, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSession provides a mock function with given fields: ctx, id
+func (_m *Store) GetSession(ctx context.Context, id string) (*session.Session, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *session.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*session.Session, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *session.Session); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*session.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionIds provides a mock function with given fields: ctx, uid
+func (_m *Store) GetSessionIds(ctx context.Context, uid int) ([]string, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]string, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsRevoked provides a mock function with given fields: ctx, jti
+func (_m *Store) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	ret := _m.Called(ctx, jti)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, jti)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, jti)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jti)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, jti, ttl
+func (_m *Store) Revoke(ctx context.Context, jti string, ttl time.Duration) error {
+	ret := _m.Called(ctx, jti, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, jti, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateSession provides a mock function with given fields: ctx, sess, prev, ttl
+func (_m *Store) RotateSession(ctx context.Context, sess session.Session, prev string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, sess, prev, ttl)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, session.Session, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, sess, prev, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, session.Session, string, time.Duration) bool); ok {
+		r0 = rf(ctx, sess, prev, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, session.Session, string, time.Duration) error); ok {
+		r1 = rf(ctx, sess, prev, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveChallenge provides a mock function with given fields: ctx, hash, uid, ttl
+func (_m *Store) SaveChallenge(ctx context.Context, hash string, uid int, ttl time.Duration) error {
+	ret := _m.Called(ctx, hash, uid, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) error); ok {
+		r0 = rf(ctx, hash, uid, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSession provides a mock function with given fields: ctx, sess, ttl
+func (_m *Store) SaveSession(ctx context.Context, sess session.Session, ttl time.Duration) error {
+	ret := _m.Called(ctx, sess, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, session.Session, time.Duration) error); ok {
+		r0 = rf(ctx, sess, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewStore(t mockConstructorTestingTNewStore) *Store {
+	mock := &Store{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "github.com/education-hub/BE/app/entities"
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Users is an autogenerated mock type for the Users type
+type Users struct {
+	mock.Mock
+}
+
+// GetById provides a mock function with given fields: db, id
+func (_m *Users) GetById(db *gorm.DB, id int) (*entities.User, error) {
+	ret := _m.Called(db, id)
+
+	var r0 *entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) (*entities.User, error)); ok {
+		return rf(db, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int) *entities.User); ok {
+		r0 = rf(db, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, int) error); ok {
+		r1 = rf(db, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewUsers interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUsers creates a new instance of Users. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUsers(t mockConstructorTestingTNewUsers) *Users {
+	mock := &Users{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
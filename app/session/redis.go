@@ -0,0 +1,168 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/education-hub/BE/errorr"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+// redisStore keeps a session under session:<id>, the ids of the sessions of a user under
+// sessions:<uid> and every revoked token id under revoked:<jti> until the token expires.
+// A login challenge is kept under challenge:<hash> with its wrong codes counted under challenge:<hash>:fails.
+type redisStore struct {
+	rds *redis.Client
+	log *logrus.Logger
+}
+
+func NewRedisStore(rds *redis.Client, log *logrus.Logger) Store {
+	return &redisStore{rds: rds, log: log}
+}
+
+func (r *redisStore) SaveSession(ctx context.Context, sess Session, ttl time.Duration) error {
+	data, _ := json.Marshal(sess)
+	key := fmt.Sprintf("sessions:%d", sess.UserID)
+	_, err := r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, "session:"+sess.ID, data, ttl)
+		pipe.SAdd(ctx, key, sess.ID)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN SAVING SESSION, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+// rotate sets the session only while its stored refresh hash is the one the refresh was checked against.
+var rotate = redis.NewScript(`
+local cur = redis.call("GET", KEYS[1])
+if not cur or cjson.decode(cur)["refresh_hash"] ~= ARGV[1] then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+redis.call("SADD", KEYS[2], ARGV[4])
+redis.call("PEXPIRE", KEYS[2], ARGV[3])
+return 1
+`)
+
+func (r *redisStore) RotateSession(ctx context.Context, sess Session, prev string, ttl time.Duration) (bool, error) {
+	data, _ := json.Marshal(sess)
+	keys := []string{"session:" + sess.ID, fmt.Sprintf("sessions:%d", sess.UserID)}
+	res, err := rotate.Run(ctx, r.rds, keys, prev, data, ttl.Milliseconds(), sess.ID).Int()
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN ROTATING SESSION, Err : %v", err)
+		return false, errorr.NewInternal("Internal Server Error")
+	}
+	return res == 1, nil
+}
+
+func (r *redisStore) GetSession(ctx context.Context, id string) (*Session, error) {
+	data, err := r.rds.Get(ctx, "session:"+id).Bytes()
+	if err == redis.Nil {
+		return nil, errorr.NewBad("Invalid Refresh Token")
+	}
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN GETTING SESSION, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	res := Session{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		r.log.Errorf("[ERROR]WHEN DECODING SESSION, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return &res, nil
+}
+
+func (r *redisStore) GetSessionIds(ctx context.Context, uid int) ([]string, error) {
+	res, err := r.rds.SMembers(ctx, fmt.Sprintf("sessions:%d", uid)).Result()
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN GETTING SESSIONS OF USER, Err : %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+
+func (r *redisStore) DeleteSession(ctx context.Context, uid int, id string) error {
+	_, err := r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, "session:"+id)
+		pipe.SRem(ctx, fmt.Sprintf("sessions:%d", uid), id)
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN DELETING SESSION, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+func (r *redisStore) Revoke(ctx context.Context, jti string, ttl time.Duration) error {
+	if err := r.rds.Set(ctx, "revoked:"+jti, 1, ttl).Err(); err != nil {
+		r.log.Errorf("[ERROR]WHEN REVOKING TOKEN, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+func (r *redisStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	res, err := r.rds.Exists(ctx, "revoked:"+jti).Result()
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN CHECKING REVOKED TOKEN, Err : %v", err)
+		return false, errorr.NewInternal("Internal Server Error")
+	}
+	return res > 0, nil
+}
+
+func (r *redisStore) SaveChallenge(ctx context.Context, hash string, uid int, ttl time.Duration) error {
+	if err := r.rds.Set(ctx, "challenge:"+hash, uid, ttl).Err(); err != nil {
+		r.log.Errorf("[ERROR]WHEN SAVING CHALLENGE, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+func (r *redisStore) GetChallenge(ctx context.Context, hash string) (int, error) {
+	res, err := r.rds.Get(ctx, "challenge:"+hash).Result()
+	if err == redis.Nil {
+		return 0, errorr.NewBad("Invalid Or Expired Challenge")
+	}
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN GETTING CHALLENGE, Err : %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	uid, err := strconv.Atoi(res)
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN DECODING CHALLENGE, Err : %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	return uid, nil
+}
+
+func (r *redisStore) FailChallenge(ctx context.Context, hash string, ttl time.Duration) (int64, error) {
+	key := "challenge:" + hash + ":fails"
+	var incr *redis.IntCmd
+	_, err := r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN COUNTING CHALLENGE FAILURE, Err : %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	return incr.Val(), nil
+}
+
+func (r *redisStore) DeleteChallenge(ctx context.Context, hash string) error {
+	if err := r.rds.Del(ctx, "challenge:"+hash, "challenge:"+hash+":fails").Err(); err != nil {
+		r.log.Errorf("[ERROR]WHEN DELETING CHALLENGE, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
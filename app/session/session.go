@@ -0,0 +1,329 @@
+package session
+
+import (
+	"context"
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"time"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"github.com/education-hub/BE/helper"
+	"github.com/golang-jwt/jwt"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultAccessTTL and defaultRefreshTTL are used when ACCESSTTL or REFRESHTTL are not configured.
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+	// ChallengeTTL is how long the user has to pass the second step of the login,
+	// the challenge is dropped earlier after maxChallengeFailures wrong codes.
+	ChallengeTTL         = 5 * time.Minute
+	maxChallengeFailures = 5
+)
+
+type (
+	// Session is a login of a user on one device, it lives as long as its refresh token is used.
+	// Jti is the access token issued last, it is revoked once the session rotates or ends.
+	// MFA tells whether the login passed the second step, it is carried into every access token.
+	Session struct {
+		ID          string    `json:"id"`
+		UserID      int       `json:"user_id"`
+		Role        string    `json:"role"`
+		Verified    bool      `json:"verified"`
+		MFA         bool      `json:"mfa"`
+		RefreshHash string    `json:"refresh_hash"`
+		Jti         string    `json:"jti"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+	Tokens struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	// Store keeps the sessions and the revoked token ids, it is satisfied by the redis store.
+	Store interface {
+		SaveSession(ctx context.Context, sess Session, ttl time.Duration) error
+		// RotateSession saves the session only if its stored refresh hash is still prev, it reports
+		// false when another refresh rotated it first.
+		RotateSession(ctx context.Context, sess Session, prev string, ttl time.Duration) (bool, error)
+		GetSession(ctx context.Context, id string) (*Session, error)
+		GetSessionIds(ctx context.Context, uid int) ([]string, error)
+		DeleteSession(ctx context.Context, uid int, id string) error
+		Revoke(ctx context.Context, jti string, ttl time.Duration) error
+		IsRevoked(ctx context.Context, jti string) (bool, error)
+		SaveChallenge(ctx context.Context, hash string, uid int, ttl time.Duration) error
+		GetChallenge(ctx context.Context, hash string) (int, error)
+		FailChallenge(ctx context.Context, hash string, ttl time.Duration) (int64, error)
+		DeleteChallenge(ctx context.Context, hash string) error
+	}
+	// Users is satisfied by the user repository.
+	Users interface {
+		GetById(db *gorm.DB, id int) (*entity.User, error)
+	}
+	Manager interface {
+		// Create opens a session for the user and issues its first pair of tokens, mfa is whether
+		// the user passed the second step of the login.
+		Create(ctx context.Context, user *entity.User, mfa bool) (*Tokens, error)
+		// Refresh rotates the refresh token of a session, a refresh token used twice ends the session
+		// as it must have been stolen. The claims are read again from the user, a role changed since
+		// the login is in the next access token.
+		Refresh(ctx context.Context, token string) (*Tokens, error)
+		// Revoke ends one session of the user, RevokeAll ends every session of the user.
+		Revoke(ctx context.Context, uid int, id string) error
+		RevokeAll(ctx context.Context, uid int) error
+		IsRevoked(ctx context.Context, jti string) (bool, error)
+		// Challenge is handed out instead of the tokens when the login needs a second step,
+		// GetChallenge returns the user it was issued for.
+		Challenge(ctx context.Context, uid int) (string, error)
+		GetChallenge(ctx context.Context, challenge string) (int, error)
+		// FailChallenge counts a wrong code, the challenge is dropped once there are too many.
+		FailChallenge(ctx context.Context, challenge string) error
+		CloseChallenge(ctx context.Context, challenge string) error
+	}
+	manager struct {
+		store Store
+		users Users
+		dep   dependency.Depend
+	}
+)
+
+func NewManager(store Store, users Users, dep dependency.Depend) Manager {
+	return &manager{store: store, users: users, dep: dep}
+}
+
+func (m *manager) Create(ctx context.Context, user *entity.User, mfa bool) (*Tokens, error) {
+	id, _, err := helper.NewToken()
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	sess := Session{ID: id, UserID: int(user.ID), Role: user.Role, Verified: user.IsVerified, MFA: mfa, CreatedAt: time.Now()}
+	return m.issue(ctx, &sess, "")
+}
+
+func (m *manager) Refresh(ctx context.Context, token string) (*Tokens, error) {
+	id, secret, ok := strings.Cut(token, ".")
+	if !ok {
+		m.dep.PromErr["error"] = "Malformed refresh token"
+		return nil, errorr.NewBad("Invalid Refresh Token")
+	}
+	sess, err := m.store.GetSession(ctx, id)
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(helper.HashToken(secret)), []byte(sess.RefreshHash)) != 1 {
+		return nil, m.reused(ctx, sess)
+	}
+	user, err := m.users.GetById(m.dep.Db.WithContext(ctx), sess.UserID)
+	if _, missing := err.(errorr.BadRequest); missing {
+		// the account was deleted, its sessions end with it
+		if err := m.end(ctx, sess); err != nil {
+			return nil, err
+		}
+		return nil, errorr.NewBad("Invalid Refresh Token")
+	}
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	// the second step passed at the login does not hold anymore once the two-factor was reset
+	prev := *sess
+	sess.Role, sess.Verified, sess.MFA = user.Role, user.IsVerified, sess.MFA && user.TOTPEnabled
+	res, err := m.issue(ctx, sess, prev.RefreshHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store.Revoke(ctx, prev.Jti, m.accessTTL()); err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	return res, nil
+}
+
+// reused ends the session whose refresh token was used twice.
+func (m *manager) reused(ctx context.Context, sess *Session) error {
+	m.dep.PromErr["error"] = "Refresh token reused"
+	m.dep.Log.Warnf("[WARN]REFRESH TOKEN OF SESSION %s REUSED, ENDING THE SESSION", sess.ID)
+	if err := m.end(ctx, sess); err != nil {
+		return err
+	}
+	return errorr.NewBad("Invalid Refresh Token")
+}
+
+func (m *manager) Revoke(ctx context.Context, uid int, id string) error {
+	sess, err := m.store.GetSession(ctx, id)
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if sess.UserID != uid {
+		m.dep.PromErr["error"] = "Session does not belong to the user"
+		return errorr.NewBad("Invalid Refresh Token")
+	}
+	return m.end(ctx, sess)
+}
+
+func (m *manager) RevokeAll(ctx context.Context, uid int) error {
+	ids, err := m.store.GetSessionIds(ctx, uid)
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	for _, id := range ids {
+		sess, err := m.store.GetSession(ctx, id)
+		if _, expired := err.(errorr.BadRequest); expired {
+			// the session expired on its own, only its id was left
+			if err := m.store.DeleteSession(ctx, uid, id); err != nil {
+				m.dep.PromErr["error"] = err.Error()
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			m.dep.PromErr["error"] = err.Error()
+			return err
+		}
+		if err := m.end(ctx, sess); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manager) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return m.store.IsRevoked(ctx, jti)
+}
+
+func (m *manager) Challenge(ctx context.Context, uid int) (string, error) {
+	challenge, challengehash, err := helper.NewToken()
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return "", errorr.NewInternal("Internal Server Error")
+	}
+	if err := m.store.SaveChallenge(ctx, challengehash, uid, ChallengeTTL); err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return "", err
+	}
+	return challenge, nil
+}
+
+func (m *manager) GetChallenge(ctx context.Context, challenge string) (int, error) {
+	uid, err := m.store.GetChallenge(ctx, helper.HashToken(challenge))
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return 0, err
+	}
+	return uid, nil
+}
+
+func (m *manager) FailChallenge(ctx context.Context, challenge string) error {
+	failures, err := m.store.FailChallenge(ctx, helper.HashToken(challenge), ChallengeTTL)
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if failures >= maxChallengeFailures {
+		m.dep.Log.Warnf("[WARN]TOO MANY WRONG TWO FACTOR CODES, DROPPING THE CHALLENGE")
+		return m.CloseChallenge(ctx, challenge)
+	}
+	return nil
+}
+
+func (m *manager) CloseChallenge(ctx context.Context, challenge string) error {
+	if err := m.store.DeleteChallenge(ctx, helper.HashToken(challenge)); err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	return nil
+}
+
+// issue gives the session a new access token and a new refresh token, prev is the refresh hash the
+// session must still have, it is empty for a new session.
+func (m *manager) issue(ctx context.Context, sess *Session, prev string) (*Tokens, error) {
+	secret, refreshhash, err := helper.NewToken()
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	jti, _, err := helper.NewToken()
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"id":       sess.UserID,
+		"role":     sess.Role,
+		"verified": strconv.FormatBool(sess.Verified),
+		"mfa":      strconv.FormatBool(sess.MFA),
+		"sid":      sess.ID,
+		"jti":      jti,
+		"iat":      now.Unix(),
+		"exp":      now.Add(m.accessTTL()).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(m.dep.Config.JwtSecret))
+	if err != nil {
+		m.dep.Log.Errorf("[ERROR]WHEN SIGNING ACCESS TOKEN, Err : %v", err)
+		m.dep.PromErr["error"] = err.Error()
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	sess.RefreshHash = refreshhash
+	sess.Jti = jti
+	if prev == "" {
+		if err := m.store.SaveSession(ctx, *sess, m.refreshTTL()); err != nil {
+			m.dep.PromErr["error"] = err.Error()
+			return nil, err
+		}
+		return &Tokens{Token: token, RefreshToken: sess.ID + "." + secret, ExpiresIn: int(m.accessTTL().Seconds())}, nil
+	}
+	rotated, err := m.store.RotateSession(ctx, *sess, prev, m.refreshTTL())
+	if err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return nil, err
+	}
+	if !rotated {
+		// another request refreshed with the same token meanwhile, the session it left is ended
+		current, err := m.store.GetSession(ctx, sess.ID)
+		if _, gone := err.(errorr.BadRequest); gone {
+			return nil, errorr.NewBad("Invalid Refresh Token")
+		}
+		if err != nil {
+			m.dep.PromErr["error"] = err.Error()
+			return nil, err
+		}
+		return nil, m.reused(ctx, current)
+	}
+	return &Tokens{Token: token, RefreshToken: sess.ID + "." + secret, ExpiresIn: int(m.accessTTL().Seconds())}, nil
+}
+
+// end removes the session and revokes its last access token.
+func (m *manager) end(ctx context.Context, sess *Session) error {
+	if err := m.store.Revoke(ctx, sess.Jti, m.accessTTL()); err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	if err := m.store.DeleteSession(ctx, sess.UserID, sess.ID); err != nil {
+		m.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	return nil
+}
+
+func (m *manager) accessTTL() time.Duration {
+	if m.dep.Config != nil && m.dep.Config.AccessTTL > 0 {
+		return time.Duration(m.dep.Config.AccessTTL) * time.Minute
+	}
+	return defaultAccessTTL
+}
+
+func (m *manager) refreshTTL() time.Duration {
+	if m.dep.Config != nil && m.dep.Config.RefreshTTL > 0 {
+		return time.Duration(m.dep.Config.RefreshTTL) * time.Hour
+	}
+	return defaultRefreshTTL
+}
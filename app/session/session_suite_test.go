@@ -0,0 +1,221 @@
+package session_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/app/session"
+	"github.com/education-hub/BE/app/session/mocks"
+	"github.com/education-hub/BE/config"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"github.com/golang-jwt/jwt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestSession(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Session Suite")
+}
+
+var _ = Describe("session", func() {
+	var Mock *mocks.Store
+	var Users *mocks.Users
+	var Manager session.Manager
+	var ctx context.Context
+	var saved session.Session
+	user := &entity.User{Role: "student", IsVerified: true}
+	user.ID = 3
+	BeforeEach(func() {
+		Mock = mocks.NewStore(GinkgoT())
+		Users = mocks.NewUsers(GinkgoT())
+		Manager = session.NewManager(Mock, Users, dependency.Depend{
+			Db:      &gorm.DB{Config: &gorm.Config{}, Statement: &gorm.Statement{}},
+			Config:  &config.Config{JwtSecret: "secret", AccessTTL: 10},
+			Log:     logrus.New(),
+			PromErr: make(map[string]string, 1),
+		})
+		ctx = context.Background()
+	})
+	// save keeps the session the manager stores so the spec can look it up later.
+	save := func() {
+		Mock.On("SaveSession", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(session.Session)
+		}).Return(nil).Once()
+	}
+	Context("Login", func() {
+		It("Akan Mengembalikan Token Dengan Masa Berlaku", func() {
+			save()
+			res, err := Manager.Create(ctx, user, false)
+			Expect(err).Should(BeNil())
+			Expect(res.ExpiresIn).To(Equal(600))
+			token, err := jwt.Parse(res.Token, func(t *jwt.Token) (any, error) { return []byte("secret"), nil })
+			Expect(err).Should(BeNil())
+			claims := token.Claims.(jwt.MapClaims)
+			Expect(claims["id"]).To(BeEquivalentTo(3))
+			Expect(claims["verified"]).To(Equal("true"))
+			Expect(claims["mfa"]).To(Equal("false"))
+			Expect(claims["jti"]).To(Equal(saved.Jti))
+			Expect(claims["sid"]).To(Equal(saved.ID))
+			Expect(int64(claims["exp"].(float64))).To(BeNumerically("~", time.Now().Add(10*time.Minute).Unix(), 5))
+			Expect(strings.HasPrefix(res.RefreshToken, saved.ID+".")).To(BeTrue())
+			Expect(saved.RefreshHash).ShouldNot(ContainSubstring(strings.TrimPrefix(res.RefreshToken, saved.ID+".")))
+		})
+	})
+	Context("Refresh Token", func() {
+		var first *session.Tokens
+		BeforeEach(func() {
+			save()
+			first, _ = Manager.Create(ctx, user, true)
+		})
+		// rotate keeps the session the manager rotates, the rotation succeeds when rotated is true.
+		rotate := func(prev string, rotated bool) {
+			Mock.On("RotateSession", mock.Anything, mock.Anything, prev, mock.Anything).Run(func(args mock.Arguments) {
+				if rotated {
+					saved = args.Get(1).(session.Session)
+				}
+			}).Return(rotated, nil).Once()
+		}
+		When("Refresh Token Valid", func() {
+			It("Akan Mengganti Token Dan Mencabut Token Lama", func() {
+				old, oldjti := saved, saved.Jti
+				Mock.On("GetSession", mock.Anything, old.ID).Return(&old, nil).Once()
+				Users.On("GetById", mock.Anything, 3).Return(&entity.User{Role: "student", IsVerified: true, TOTPEnabled: true}, nil).Once()
+				rotate(old.RefreshHash, true)
+				Mock.On("Revoke", mock.Anything, oldjti, 10*time.Minute).Return(nil).Once()
+				res, err := Manager.Refresh(ctx, first.RefreshToken)
+				Expect(err).Should(BeNil())
+				Expect(res.RefreshToken).ShouldNot(Equal(first.RefreshToken))
+				Expect(saved.ID).To(Equal(old.ID))
+				Expect(saved.Jti).ShouldNot(Equal(oldjti))
+				Expect(saved.MFA).To(BeTrue())
+			})
+		})
+		When("Data Pengguna Berubah Sejak Login", func() {
+			It("Token Baru Membawa Klaim Terbaru", func() {
+				old := saved
+				Mock.On("GetSession", mock.Anything, old.ID).Return(&old, nil).Once()
+				Users.On("GetById", mock.Anything, 3).Return(&entity.User{Role: "parent", IsVerified: false}, nil).Once()
+				rotate(old.RefreshHash, true)
+				Mock.On("Revoke", mock.Anything, old.Jti, mock.Anything).Return(nil).Once()
+				res, err := Manager.Refresh(ctx, first.RefreshToken)
+				Expect(err).Should(BeNil())
+				token, _ := jwt.Parse(res.Token, func(t *jwt.Token) (any, error) { return []byte("secret"), nil })
+				claims := token.Claims.(jwt.MapClaims)
+				Expect(claims["role"]).To(Equal("parent"))
+				Expect(claims["verified"]).To(Equal("false"))
+				Expect(claims["mfa"]).To(Equal("false"))
+			})
+		})
+		When("Akun Sudah Dihapus", func() {
+			It("Akan Mengakhiri Session", func() {
+				old := saved
+				Mock.On("GetSession", mock.Anything, old.ID).Return(&old, nil).Once()
+				Users.On("GetById", mock.Anything, 3).Return(nil, errorr.NewBad("Id not found")).Once()
+				Mock.On("Revoke", mock.Anything, old.Jti, mock.Anything).Return(nil).Once()
+				Mock.On("DeleteSession", mock.Anything, 3, old.ID).Return(nil).Once()
+				_, err := Manager.Refresh(ctx, first.RefreshToken)
+				Expect(err).To(Equal(errorr.NewBad("Invalid Refresh Token")))
+			})
+		})
+		When("Dua Refresh Bersamaan", func() {
+			It("Hanya Satu Yang Berhasil Dan Session Diakhiri", func() {
+				old := saved
+				winner := saved
+				winner.RefreshHash, winner.Jti = "rotated", "j2"
+				Mock.On("GetSession", mock.Anything, old.ID).Return(&old, nil).Once()
+				Users.On("GetById", mock.Anything, 3).Return(&entity.User{Role: "student", IsVerified: true}, nil).Once()
+				rotate(old.RefreshHash, false)
+				Mock.On("GetSession", mock.Anything, old.ID).Return(&winner, nil).Once()
+				Mock.On("Revoke", mock.Anything, "j2", mock.Anything).Return(nil).Once()
+				Mock.On("DeleteSession", mock.Anything, 3, old.ID).Return(nil).Once()
+				_, err := Manager.Refresh(ctx, first.RefreshToken)
+				Expect(err).To(Equal(errorr.NewBad("Invalid Refresh Token")))
+			})
+		})
+		When("Refresh Token Dipakai Ulang", func() {
+			It("Akan Mengakhiri Session", func() {
+				rotated := saved
+				rotated.RefreshHash = "rotated"
+				Mock.On("GetSession", mock.Anything, rotated.ID).Return(&rotated, nil).Once()
+				Mock.On("Revoke", mock.Anything, rotated.Jti, mock.Anything).Return(nil).Once()
+				Mock.On("DeleteSession", mock.Anything, 3, rotated.ID).Return(nil).Once()
+				_, err := Manager.Refresh(ctx, first.RefreshToken)
+				Expect(err).To(Equal(errorr.NewBad("Invalid Refresh Token")))
+			})
+		})
+		When("Refresh Token Tidak Valid", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				_, err := Manager.Refresh(ctx, "tanpa-titik")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+	})
+	Context("Login Dua Langkah", func() {
+		It("Akan Menyimpan Hash Challenge", func() {
+			Mock.On("SaveChallenge", mock.Anything, mock.Anything, 3, session.ChallengeTTL).Return(nil).Once()
+			challenge, err := Manager.Challenge(ctx, 3)
+			Expect(err).Should(BeNil())
+			Mock.AssertCalled(GinkgoT(), "SaveChallenge", mock.Anything, mock.MatchedBy(func(hash string) bool { return hash != challenge }), 3, session.ChallengeTTL)
+		})
+		It("Token Setelah Kode 2FA Membawa Klaim MFA", func() {
+			save()
+			res, err := Manager.Create(ctx, user, true)
+			Expect(err).Should(BeNil())
+			Expect(saved.MFA).To(BeTrue())
+			token, _ := jwt.Parse(res.Token, func(t *jwt.Token) (any, error) { return []byte("secret"), nil })
+			Expect(token.Claims.(jwt.MapClaims)["mfa"]).To(Equal("true"))
+		})
+		When("Kode Salah Terlalu Sering", func() {
+			It("Akan Menghapus Challenge", func() {
+				Mock.On("FailChallenge", mock.Anything, mock.Anything, session.ChallengeTTL).Return(int64(5), nil).Once()
+				Mock.On("DeleteChallenge", mock.Anything, mock.Anything).Return(nil).Once()
+				Expect(Manager.FailChallenge(ctx, "abc")).Should(BeNil())
+			})
+		})
+		When("Kode Salah Sekali", func() {
+			It("Challenge Masih Berlaku", func() {
+				Mock.On("FailChallenge", mock.Anything, mock.Anything, session.ChallengeTTL).Return(int64(1), nil).Once()
+				Expect(Manager.FailChallenge(ctx, "abc")).Should(BeNil())
+			})
+		})
+	})
+	Context("Logout", func() {
+		When("Session Milik Pengguna Lain", func() {
+			It("Akan Mengembalikan Erorr", func() {
+				Mock.On("GetSession", mock.Anything, "abc").Return(&session.Session{ID: "abc", UserID: 9}, nil).Once()
+				err := Manager.Revoke(ctx, 3, "abc")
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		When("Logout Satu Perangkat", func() {
+			It("Akan Mencabut Token Session", func() {
+				Mock.On("GetSession", mock.Anything, "abc").Return(&session.Session{ID: "abc", UserID: 3, Jti: "j1"}, nil).Once()
+				Mock.On("Revoke", mock.Anything, "j1", mock.Anything).Return(nil).Once()
+				Mock.On("DeleteSession", mock.Anything, 3, "abc").Return(nil).Once()
+				Expect(Manager.Revoke(ctx, 3, "abc")).Should(BeNil())
+			})
+		})
+		When("Logout Semua Perangkat", func() {
+			It("Akan Mencabut Token Semua Session", func() {
+				Mock.On("GetSessionIds", mock.Anything, 3).Return([]string{"abc", "def", "old"}, nil).Once()
+				Mock.On("GetSession", mock.Anything, "abc").Return(&session.Session{ID: "abc", UserID: 3, Jti: "j1"}, nil).Once()
+				Mock.On("GetSession", mock.Anything, "def").Return(&session.Session{ID: "def", UserID: 3, Jti: "j2"}, nil).Once()
+				Mock.On("GetSession", mock.Anything, "old").Return(nil, errorr.NewBad("Invalid Refresh Token")).Once()
+				Mock.On("Revoke", mock.Anything, "j1", mock.Anything).Return(nil).Once()
+				Mock.On("Revoke", mock.Anything, "j2", mock.Anything).Return(nil).Once()
+				Mock.On("DeleteSession", mock.Anything, 3, "abc").Return(nil).Once()
+				Mock.On("DeleteSession", mock.Anything, 3, "def").Return(nil).Once()
+				Mock.On("DeleteSession", mock.Anything, 3, "old").Return(nil).Once()
+				Expect(Manager.RevokeAll(ctx, 3)).Should(BeNil())
+			})
+		})
+	})
+})
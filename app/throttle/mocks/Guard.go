@@ -0,0 +1,95 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Guard is an autogenerated mock type for the Guard type
+type Guard struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, username, ip
+func (_m *Guard) Check(ctx context.Context, username string, ip string) (bool, error) {
+	ret := _m.Called(ctx, username, ip)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, username, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, username, ip)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Fail provides a mock function with given fields: ctx, username, ip
+func (_m *Guard) Fail(ctx context.Context, username string, ip string) error {
+	ret := _m.Called(ctx, username, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Succeed provides a mock function with given fields: ctx, username
+func (_m *Guard) Succeed(ctx context.Context, username string) error {
+	ret := _m.Called(ctx, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlock provides a mock function with given fields: ctx, username, by
+func (_m *Guard) Unlock(ctx context.Context, username string, by int) error {
+	ret := _m.Called(ctx, username, by)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, username, by)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewGuard interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewGuard creates a new instance of Guard. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewGuard(t mockConstructorTestingTNewGuard) *Guard {
+	mock := &Guard{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,80 @@
+package throttle
+
+import (
+	"context"
+	"time"
+
+	"github.com/education-hub/BE/errorr"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+// redisStore keeps everything under login:<key>, the failures under login:fails:<scope>:<id> and
+// the blocks under login:lock:<scope>:<id> and login:wait:<scope>:<id> until they expire.
+type redisStore struct {
+	rds *redis.Client
+	log *logrus.Logger
+}
+
+func NewRedisStore(rds *redis.Client, log *logrus.Logger) Store {
+	return &redisStore{rds: rds, log: log}
+}
+
+func (r *redisStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, "login:"+key)
+		pipe.Expire(ctx, "login:"+key, ttl)
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN COUNTING LOGIN FAILURE, Err : %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	return incr.Val(), nil
+}
+
+func (r *redisStore) Count(ctx context.Context, key string) (int64, error) {
+	res, err := r.rds.Get(ctx, "login:"+key).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN GETTING LOGIN FAILURES, Err : %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	return res, nil
+}
+
+func (r *redisStore) Block(ctx context.Context, key string, ttl time.Duration) error {
+	if err := r.rds.Set(ctx, "login:"+key, 1, ttl).Err(); err != nil {
+		r.log.Errorf("[ERROR]WHEN BLOCKING LOGIN, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
+
+func (r *redisStore) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	res, err := r.rds.PTTL(ctx, "login:"+key).Result()
+	if err != nil {
+		r.log.Errorf("[ERROR]WHEN CHECKING LOGIN BLOCK, Err : %v", err)
+		return 0, errorr.NewInternal("Internal Server Error")
+	}
+	// a missing key is reported as a negative duration
+	if res < 0 {
+		return 0, nil
+	}
+	return res, nil
+}
+
+func (r *redisStore) Delete(ctx context.Context, keys ...string) error {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = "login:" + key
+	}
+	if err := r.rds.Del(ctx, prefixed...).Err(); err != nil {
+		r.log.Errorf("[ERROR]WHEN CLEARING LOGIN FAILURES, Err : %v", err)
+		return errorr.NewInternal("Internal Server Error")
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/education-hub/BE/app/audit"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// captchaAfter failures of a username or an ip the login needs a captcha, from delayAfter failures
+	// of a username every attempt waits twice as long as the one before, up to maxDelay.
+	captchaAfter = 3
+	delayAfter   = 5
+	maxDelay     = time.Minute
+	// window is how long a failure is remembered.
+	window = time.Hour
+	// ipLockFactor makes an ip lock after that many times the failures of a username,
+	// a whole school can sit behind one ip.
+	ipLockFactor = 5
+	// defaultLockAfter and defaultLockTTL are used when LOGINLOCKAFTER or LOGINLOCKTTL are not configured.
+	defaultLockAfter = 10
+	defaultLockTTL   = 15 * time.Minute
+)
+
+var loginEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "login_throttle_events_total",
+		Help: "Number of failed logins, lockouts and unlocks.",
+	}, []string{"event", "scope"})
+
+func init() {
+	prometheus.MustRegister(loginEvents)
+}
+
+type (
+	// Store keeps the counters and the blocks, it is satisfied by the redis store.
+	Store interface {
+		Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+		Count(ctx context.Context, key string) (int64, error)
+		Block(ctx context.Context, key string, ttl time.Duration) error
+		// BlockedFor returns how long the key stays blocked, zero once it is not.
+		BlockedFor(ctx context.Context, key string) (time.Duration, error)
+		Delete(ctx context.Context, keys ...string) error
+	}
+	Guard interface {
+		// Check refuses the attempt while the username or the ip is locked or waiting, otherwise it
+		// reports whether the attempt needs a captcha.
+		Check(ctx context.Context, username string, ip string) (bool, error)
+		Fail(ctx context.Context, username string, ip string) error
+		Succeed(ctx context.Context, username string) error
+		// Unlock lifts the lock of the username before it expires, by is the admin doing it.
+		Unlock(ctx context.Context, username string, by int) error
+	}
+	guard struct {
+		store Store
+		log   audit.Log
+		dep   dependency.Depend
+	}
+)
+
+func NewGuard(store Store, log audit.Log, dep dependency.Depend) Guard {
+	return &guard{store: store, log: log, dep: dep}
+}
+
+func (g *guard) Check(ctx context.Context, username string, ip string) (bool, error) {
+	captcha := false
+	for _, key := range keys(username, ip) {
+		locked, err := g.store.BlockedFor(ctx, "lock:"+key)
+		if err != nil {
+			g.dep.PromErr["error"] = err.Error()
+			return false, err
+		}
+		if locked > 0 {
+			g.dep.PromErr["error"] = "Login locked for " + key
+			return false, errorr.NewBad(fmt.Sprintf("Too Many Failed Logins, Try Again In %d Minutes", int(math.Ceil(locked.Minutes()))))
+		}
+		wait, err := g.store.BlockedFor(ctx, "wait:"+key)
+		if err != nil {
+			g.dep.PromErr["error"] = err.Error()
+			return false, err
+		}
+		if wait > 0 {
+			g.dep.PromErr["error"] = "Login delayed for " + key
+			return false, errorr.NewBad(fmt.Sprintf("Too Many Failed Logins, Try Again In %d Seconds", int(math.Ceil(wait.Seconds()))))
+		}
+		failures, err := g.store.Count(ctx, "fails:"+key)
+		if err != nil {
+			g.dep.PromErr["error"] = err.Error()
+			return false, err
+		}
+		captcha = captcha || failures >= captchaAfter
+	}
+	return captcha, nil
+}
+
+func (g *guard) Fail(ctx context.Context, username string, ip string) error {
+	for _, key := range keys(username, ip) {
+		scope := strings.SplitN(key, ":", 2)[0]
+		loginEvents.WithLabelValues("failure", scope).Inc()
+		failures, err := g.store.Incr(ctx, "fails:"+key, window)
+		if err != nil {
+			g.dep.PromErr["error"] = err.Error()
+			return err
+		}
+		lockafter := int64(g.lockAfter())
+		if scope == "ip" {
+			lockafter *= ipLockFactor
+		}
+		switch {
+		case failures >= lockafter:
+			if err := g.store.Block(ctx, "lock:"+key, g.lockTTL()); err != nil {
+				g.dep.PromErr["error"] = err.Error()
+				return err
+			}
+			if err := g.store.Delete(ctx, "fails:"+key, "wait:"+key); err != nil {
+				g.dep.PromErr["error"] = err.Error()
+				return err
+			}
+			loginEvents.WithLabelValues("lockout", scope).Inc()
+			// the lock is in place whether the entry is written or not, Record logs its own failure
+			g.log.Record(ctx, entity.AuditEntry{Action: "login_lockout", ResourceType: "login", ResourceID: key, After: audit.Encode(map[string]any{"failures": failures, "until": time.Now().Add(g.lockTTL())})})
+		case scope == "user" && failures >= delayAfter:
+			if err := g.store.Block(ctx, "wait:"+key, delay(failures)); err != nil {
+				g.dep.PromErr["error"] = err.Error()
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *guard) Succeed(ctx context.Context, username string) error {
+	key := "user:" + strings.ToLower(username)
+	if err := g.store.Delete(ctx, "fails:"+key, "wait:"+key); err != nil {
+		g.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	return nil
+}
+
+func (g *guard) Unlock(ctx context.Context, username string, by int) error {
+	key := "user:" + strings.ToLower(username)
+	if err := g.store.Delete(ctx, "lock:"+key, "fails:"+key, "wait:"+key); err != nil {
+		g.dep.PromErr["error"] = err.Error()
+		return err
+	}
+	loginEvents.WithLabelValues("unlock", "user").Inc()
+	return g.log.Record(ctx, entity.AuditEntry{ActorID: uint(by), Role: "su", Action: "login_unlock", ResourceType: "login", ResourceID: key})
+}
+
+func (g *guard) lockAfter() int {
+	if g.dep.Config != nil && g.dep.Config.LoginLockAfter > 0 {
+		return g.dep.Config.LoginLockAfter
+	}
+	return defaultLockAfter
+}
+
+func (g *guard) lockTTL() time.Duration {
+	if g.dep.Config != nil && g.dep.Config.LoginLockTTL > 0 {
+		return time.Duration(g.dep.Config.LoginLockTTL) * time.Minute
+	}
+	return defaultLockTTL
+}
+
+// keys are the counters an attempt counts against, usernames are case insensitive at login.
+func keys(username string, ip string) []string {
+	res := []string{"user:" + strings.ToLower(username)}
+	if ip != "" {
+		res = append(res, "ip:"+ip)
+	}
+	return res
+}
+
+func delay(failures int64) time.Duration {
+	wait := time.Second << (failures - delayAfter)
+	if wait > maxDelay || wait <= 0 {
+		return maxDelay
+	}
+	return wait
+}
@@ -0,0 +1,134 @@
+package throttle_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	auditmocks "github.com/education-hub/BE/app/audit/mocks"
+	entity "github.com/education-hub/BE/app/entities"
+	"github.com/education-hub/BE/app/throttle"
+	"github.com/education-hub/BE/app/throttle/mocks"
+	"github.com/education-hub/BE/config"
+	"github.com/education-hub/BE/config/dependency"
+	"github.com/education-hub/BE/errorr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestThrottle(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Throttle Suite")
+}
+
+var _ = Describe("throttle", func() {
+	var Mock *mocks.Store
+	var Audit *auditmocks.Log
+	var Guard throttle.Guard
+	var ctx context.Context
+	BeforeEach(func() {
+		Mock = mocks.NewStore(GinkgoT())
+		Audit = auditmocks.NewLog(GinkgoT())
+		Guard = throttle.NewGuard(Mock, Audit, dependency.Depend{
+			Config:  &config.Config{LoginLockAfter: 4, LoginLockTTL: 30},
+			Log:     logrus.New(),
+			PromErr: make(map[string]string, 1),
+		})
+		ctx = context.Background()
+	})
+	// free leaves the username and the ip without any block.
+	free := func(failures int64) {
+		for _, key := range []string{"user:satrio", "ip:10.0.0.1"} {
+			Mock.On("BlockedFor", mock.Anything, "lock:"+key).Return(time.Duration(0), nil).Once()
+			Mock.On("BlockedFor", mock.Anything, "wait:"+key).Return(time.Duration(0), nil).Once()
+		}
+		Mock.On("Count", mock.Anything, "fails:user:satrio").Return(failures, nil).Once()
+		Mock.On("Count", mock.Anything, "fails:ip:10.0.0.1").Return(int64(0), nil).Once()
+	}
+	Context("Cek Login", func() {
+		When("Belum Pernah Gagal", func() {
+			It("Tidak Perlu Captcha", func() {
+				free(0)
+				captcha, err := Guard.Check(ctx, "Satrio", "10.0.0.1")
+				Expect(err).Should(BeNil())
+				Expect(captcha).To(BeFalse())
+			})
+		})
+		When("Sudah Gagal Tiga Kali", func() {
+			It("Wajib Captcha", func() {
+				free(3)
+				captcha, err := Guard.Check(ctx, "satrio", "10.0.0.1")
+				Expect(err).Should(BeNil())
+				Expect(captcha).To(BeTrue())
+			})
+		})
+		When("Username Terkunci", func() {
+			It("Akan Menolak Login", func() {
+				Mock.On("BlockedFor", mock.Anything, "lock:user:satrio").Return(14*time.Minute+time.Second, nil).Once()
+				_, err := Guard.Check(ctx, "satrio", "10.0.0.1")
+				Expect(err).To(Equal(errorr.NewBad("Too Many Failed Logins, Try Again In 15 Minutes")))
+			})
+		})
+		When("Masih Dalam Jeda", func() {
+			It("Akan Menolak Login", func() {
+				Mock.On("BlockedFor", mock.Anything, "lock:user:satrio").Return(time.Duration(0), nil).Once()
+				Mock.On("BlockedFor", mock.Anything, "wait:user:satrio").Return(2*time.Second, nil).Once()
+				_, err := Guard.Check(ctx, "satrio", "10.0.0.1")
+				Expect(err).To(Equal(errorr.NewBad("Too Many Failed Logins, Try Again In 2 Seconds")))
+			})
+		})
+	})
+	Context("Login Gagal", func() {
+		When("Belum Mencapai Batas", func() {
+			It("Hanya Menambah Hitungan", func() {
+				Mock.On("Incr", mock.Anything, "fails:user:satrio", time.Hour).Return(int64(2), nil).Once()
+				Mock.On("Incr", mock.Anything, "fails:ip:10.0.0.1", time.Hour).Return(int64(2), nil).Once()
+				Expect(Guard.Fail(ctx, "satrio", "10.0.0.1")).Should(BeNil())
+			})
+		})
+		When("Mencapai Batas Kunci", func() {
+			It("Akan Mengunci Username", func() {
+				Mock.On("Incr", mock.Anything, "fails:user:satrio", time.Hour).Return(int64(4), nil).Once()
+				Mock.On("Block", mock.Anything, "lock:user:satrio", 30*time.Minute).Return(nil).Once()
+				Mock.On("Delete", mock.Anything, "fails:user:satrio", "wait:user:satrio").Return(nil).Once()
+				Audit.On("Record", mock.Anything, mock.MatchedBy(func(entry entity.AuditEntry) bool {
+					return entry.Action == "login_lockout" && entry.ResourceID == "user:satrio" && strings.Contains(string(entry.After), `"failures":4`)
+				})).Return(nil).Once()
+				Mock.On("Incr", mock.Anything, "fails:ip:10.0.0.1", time.Hour).Return(int64(4), nil).Once()
+				Expect(Guard.Fail(ctx, "satrio", "10.0.0.1")).Should(BeNil())
+			})
+		})
+		When("Banyak Username Gagal Dari Satu IP", func() {
+			It("Akan Mengunci IP", func() {
+				Mock.On("Incr", mock.Anything, "fails:user:budi", time.Hour).Return(int64(1), nil).Once()
+				Mock.On("Incr", mock.Anything, "fails:ip:10.0.0.1", time.Hour).Return(int64(20), nil).Once()
+				Mock.On("Block", mock.Anything, "lock:ip:10.0.0.1", 30*time.Minute).Return(nil).Once()
+				Mock.On("Delete", mock.Anything, "fails:ip:10.0.0.1", "wait:ip:10.0.0.1").Return(nil).Once()
+				Audit.On("Record", mock.Anything, mock.MatchedBy(func(entry entity.AuditEntry) bool {
+					return entry.Action == "login_lockout" && entry.ResourceID == "ip:10.0.0.1"
+				})).Return(nil).Once()
+				Expect(Guard.Fail(ctx, "budi", "10.0.0.1")).Should(BeNil())
+			})
+		})
+	})
+	Context("Jeda Bertahap", func() {
+		It("Jeda Berlipat Setiap Kegagalan", func() {
+			Guard = throttle.NewGuard(Mock, Audit, dependency.Depend{Log: logrus.New(), PromErr: make(map[string]string, 1)})
+			for failures, wait := range map[int64]time.Duration{5: time.Second, 6: 2 * time.Second, 8: 8 * time.Second, 9: 16 * time.Second} {
+				Mock.On("Incr", mock.Anything, "fails:user:satrio", time.Hour).Return(failures, nil).Once()
+				Mock.On("Block", mock.Anything, "wait:user:satrio", wait).Return(nil).Once()
+				Expect(Guard.Fail(ctx, "satrio", "")).Should(BeNil())
+			}
+		})
+	})
+	Context("Buka Kunci", func() {
+		It("Akan Menghapus Kunci Dan Mencatat Audit", func() {
+			Mock.On("Delete", mock.Anything, "lock:user:satrio", "fails:user:satrio", "wait:user:satrio").Return(nil).Once()
+			Audit.On("Record", mock.Anything, entity.AuditEntry{ActorID: 1, Role: "su", Action: "login_unlock", ResourceType: "login", ResourceID: "user:satrio"}).Return(nil).Once()
+			Expect(Guard.Unlock(ctx, "Satrio", 1)).Should(BeNil())
+		})
+	})
+})
@@ -26,6 +26,12 @@ type GCPConfig struct {
 	Path       string `mapstructure:"PATH"`
 }
 
+// StorageConfig selects where the uploaded files are kept, "gcp" or "local".
+type StorageConfig struct {
+	Driver string `mapstructure:"DRIVER"`
+	Dir    string `mapstructure:"DIR"`
+}
+
 type MidtransConfig struct {
 	ServerKey      string `mapstructure:"SERVERKEY"`
 	ClientKey      string `mapstructure:"CLIENTKEY"`
@@ -51,6 +57,8 @@ type NSQConfig struct {
 	Topic12 string `mapstructure:"TOPIC12"`
 	Topic13 string `mapstructure:"TOPIC13"`
 	Topic14 string `mapstructure:"TOPIC14"`
+	Topic15 string `mapstructure:"TOPIC15"`
+	Topic16 string `mapstructure:"TOPIC16"`
 }
 type PusherConfig struct {
 	AppId   string `mapstructure:"APPID"`
@@ -63,19 +71,36 @@ type PusherConfig struct {
 	Event2  string `mapstructure:"EVENT2"`
 	Event3  string `mapstructure:"EVENT3"`
 }
+
+// OIDCConfig is the OAuth client registered at the identity provider, Google when Issuer is empty.
+type OIDCConfig struct {
+	Issuer       string `mapstructure:"ISSUER"`
+	ClientID     string `mapstructure:"CLIENTID"`
+	ClientSecret string `mapstructure:"CLIENTSECRET"`
+	RedirectURL  string `mapstructure:"REDIRECTURL"`
+}
 type Config struct {
-	Server     Server         `mapstructure:"SERVER"`
-	Database   DatabaseConfig `mapstructure:"DATABASE"`
-	Midtrans   MidtransConfig `mapstructure:"MIDTRANS"`
-	JwtSecret  string         `mapstructure:"JWTSECRET"`
-	GmapsKey   string         `mapstructure:"GMAPS"`
-	Redis      RedisConfig    `mapstructure:"REDIS"`
-	CSRFLength int            `mapstructure:"CSRFLENGTH"`
-	CSRFMode   string         `mapstructure:"CSRFMODE"`
-	NSQ        NSQConfig      `mapstructure:"NSQ"`
-	GCP        GCPConfig      `mapstructure:"GCP"`
-	Pusher     PusherConfig   `mapstructure:"PUSHER"`
-	QuizAuth   string         `mapstructure:"QUIZ"`
+	Server         Server         `mapstructure:"SERVER"`
+	Database       DatabaseConfig `mapstructure:"DATABASE"`
+	Midtrans       MidtransConfig `mapstructure:"MIDTRANS"`
+	JwtSecret      string         `mapstructure:"JWTSECRET"`
+	GmapsKey       string         `mapstructure:"GMAPS"`
+	Redis          RedisConfig    `mapstructure:"REDIS"`
+	CSRFLength     int            `mapstructure:"CSRFLENGTH"`
+	CSRFMode       string         `mapstructure:"CSRFMODE"`
+	NSQ            NSQConfig      `mapstructure:"NSQ"`
+	GCP            GCPConfig      `mapstructure:"GCP"`
+	Storage        StorageConfig  `mapstructure:"STORAGE"`
+	Pusher         PusherConfig   `mapstructure:"PUSHER"`
+	QuizAuth       string         `mapstructure:"QUIZ"`
+	SweepInterval  int            `mapstructure:"SWEEPINTERVAL"`
+	EraseInterval  int            `mapstructure:"ERASEINTERVAL"`
+	VerifyURL      string         `mapstructure:"VERIFYURL"`
+	AccessTTL      int            `mapstructure:"ACCESSTTL"`
+	RefreshTTL     int            `mapstructure:"REFRESHTTL"`
+	LoginLockAfter int            `mapstructure:"LOGINLOCKAFTER"`
+	LoginLockTTL   int            `mapstructure:"LOGINLOCKTTL"`
+	OIDC           OIDCConfig     `mapstructure:"OIDC"`
 }
 
 func InitConfiguration() (*Config, error) {
@@ -66,6 +66,9 @@ func RunAll() {
 	if err := Container.Provide(NewValidation); err != nil {
 		panic(err)
 	}
+	if err := Container.Provide(NewGeocoder); err != nil {
+		panic(err)
+	}
 	Container.Provide(func() map[int]bool {
 		return make(map[int]bool)
 	})
@@ -81,6 +84,14 @@ func RunAll() {
 
 }
 
+// NewGeocoder uses Google Maps when a key is configured, addresses are left without coordinates otherwise.
+func NewGeocoder(conf *config.Config, log *logrus.Logger) pkg.Geocoder {
+	if conf.GmapsKey == "" {
+		return &pkg.OfflineGeocoder{}
+	}
+	return pkg.NewClientGmaps(conf.GmapsKey, log)
+}
+
 func NewPusher(conf *config.Config) (ps *pkg.Pusher) {
 	ps = &pkg.Pusher{}
 	ps.Env = conf.Pusher
@@ -93,7 +104,12 @@ func NewPusher(conf *config.Config) (ps *pkg.Pusher) {
 	}
 	return ps
 }
-func NewStorage(cfg *config.Config) (*pkg.StorageGCP, error) {
+
+// NewStorage keeps the files in a local directory when the storage driver is "local", in GCP otherwise.
+func NewStorage(cfg *config.Config) (pkg.Storage, error) {
+	if cfg.Storage.Driver == "local" {
+		return &pkg.LocalStorage{Dir: cfg.Storage.Dir}, nil
+	}
 	os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", cfg.GCP.Credential)
 	client, err := storage.NewClient(context.Background())
 	if err != nil {
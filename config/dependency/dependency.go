@@ -16,7 +16,7 @@ type Depend struct {
 	Config     *config.Config
 	Echo       *echo.Echo
 	Log        *logrus.Logger
-	Gcp        *pkg.StorageGCP
+	Storage    pkg.Storage
 	Rds        *redis.Client
 	Mds        *pkg.Midtrans
 	Nsq        *pkg.NSQProducer
@@ -24,5 +24,6 @@ type Depend struct {
 	Pusher     *pkg.Pusher
 	Calendar   *pkg.Calendar
 	Quiz       *pkg.Quiz
+	Geocoder   pkg.Geocoder
 	PromErr    map[string]string
 }
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"fmt"
+
 	entity "github.com/education-hub/BE/app/entities"
 	"github.com/education-hub/BE/config"
 )
@@ -10,7 +12,41 @@ func Migrate(c *config.Config) {
 	if err != nil {
 		panic(err)
 	}
-	if err := db.AutoMigrate(entity.User{}, entity.ForgotPass{}, entity.School{}, entity.Achievement{}, entity.Extracurricular{}, entity.Faq{}, entity.Payment{}, entity.Submission{}, entity.Progress{}, entity.Reviews{}, entity.Transaction{}, entity.Carts{}, entity.TransactionItems{}, entity.BillingSchedule{}); err != nil {
+	// the reset tokens used to be stored in plain text, they are dropped instead of migrated
+	if db.Migrator().HasColumn(&entity.ForgotPass{}, "token") {
+		if err := db.Migrator().DropTable(&entity.ForgotPass{}); err != nil {
+			panic(err)
+		}
+	}
+	seedPeriods := !db.Migrator().HasTable(&entity.AdmissionPeriod{})
+	if err := db.AutoMigrate(entity.User{}, entity.ForgotPass{}, entity.EmailVerification{}, entity.School{}, entity.Achievement{}, entity.Extracurricular{}, entity.Faq{}, entity.Payment{}, entity.Submission{}, entity.Progress{}, entity.Reviews{}, entity.Transaction{}, entity.Carts{}, entity.TransactionItems{}, entity.BillingSchedule{}, entity.PipelineStep{}, entity.ProgressEvent{}, entity.AdmissionNote{}, entity.Quota{}, entity.AdmissionPeriod{}, entity.SelectionCriterion{}, entity.Appeal{}, entity.LetterTemplate{}, entity.AcceptanceLetter{}, entity.StudentProfile{}, entity.ParentLink{}, entity.SchoolMember{}, entity.SchoolInvitation{}, entity.RecoveryCode{}, entity.UserIdentity{}, entity.AuditEntry{}, entity.ErasureRequest{}); err != nil {
+		panic(err)
+	}
+	// the admission used to be open all year, the schools keep admitting in an open-ended period until
+	// their admin closes it, it is only seeded with the periods table so a school closing its admission
+	// by deleting its periods stays closed
+	if seedPeriods {
+		if err := db.Exec("INSERT INTO admission_periods (school_id, name, open_at, close_at, step_deadline) SELECT s.id, 'Default', NOW(), '9999-12-31 00:00:00', 0 FROM schools s WHERE s.deleted_at IS NULL").Error; err != nil {
+			panic(err)
+		}
+	}
+	if db.Migrator().HasColumn(&entity.User{}, "verification_code") {
+		if err := db.Migrator().DropColumn(&entity.User{}, "verification_code"); err != nil {
+			panic(err)
+		}
+	}
+	// schools registered before the memberships keep the admin who registered them as owner. A user is
+	// a member of one school only, the schools of an admin who registered several or who already joined
+	// another school would be left without owner, they are to be handed over before migrating.
+	const unowned = "s.deleted_at IS NULL AND s.user_id <> 0 AND NOT EXISTS (SELECT 1 FROM school_members m WHERE m.school_id = s.id)"
+	conflicts := []int{}
+	if err := db.Raw("SELECT s.id FROM schools s WHERE " + unowned + " AND (EXISTS (SELECT 1 FROM school_members m WHERE m.user_id = s.user_id) OR (SELECT COUNT(*) FROM schools o WHERE o.user_id = s.user_id AND o.deleted_at IS NULL AND o.id <> s.id AND NOT EXISTS (SELECT 1 FROM school_members m WHERE m.school_id = o.id)) > 0) ORDER BY s.id").Scan(&conflicts).Error; err != nil {
+		panic(err)
+	}
+	if len(conflicts) > 0 {
+		panic(fmt.Sprintf("schools %v belong to an admin owning more than one school, give each of them its own owner before migrating", conflicts))
+	}
+	if err := db.Exec("INSERT INTO school_members (school_id, user_id, role, created_at) SELECT s.id, s.user_id, 'owner', NOW() FROM schools s WHERE " + unowned).Error; err != nil {
 		panic(err)
 	}
 }
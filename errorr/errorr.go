@@ -23,3 +23,15 @@ func (i InternalServer) Error() string {
 func NewInternal(err string) InternalServer {
 	return InternalServer{err}
 }
+
+type Forbidden struct {
+	Err string
+}
+
+func (f Forbidden) Error() string {
+	return f.Err
+}
+
+func NewForbidden(err string) Forbidden {
+	return Forbidden{err}
+}
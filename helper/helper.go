@@ -10,9 +10,9 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
-	depedency "github.com/education-hub/BE/config/dependency"
 	"github.com/education-hub/BE/errorr"
 	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
 	"github.com/mojocn/base64Captcha"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
@@ -24,6 +24,13 @@ func GetUid(token *jwt.Token) int {
 
 	return id
 }
+// GetStudentUid returns the student a request acts for, which is the linked child when a parent acts on its behalf.
+func GetStudentUid(c echo.Context) int {
+	if child, ok := c.Get("child").(int); ok {
+		return child
+	}
+	return GetUid(c.Get("user").(*jwt.Token))
+}
 func GetRole(token *jwt.Token) string {
 	parse := token.Claims.(jwt.MapClaims)
 	return parse["role"].(string)
@@ -33,6 +40,22 @@ func GetStatus(token *jwt.Token) string {
 	return parse["verified"].(string)
 }
 
+// GetJti and GetSession return the ids of the token and of its session, tokens issued without a session have neither.
+func GetJti(token *jwt.Token) string {
+	jti, _ := token.Claims.(jwt.MapClaims)["jti"].(string)
+	return jti
+}
+func GetSession(token *jwt.Token) string {
+	sid, _ := token.Claims.(jwt.MapClaims)["sid"].(string)
+	return sid
+}
+
+// GetMFA tells whether the login of the token passed the second step, it is "true" or "false" like GetStatus.
+func GetMFA(token *jwt.Token) string {
+	mfa, _ := token.Claims.(jwt.MapClaims)["mfa"].(string)
+	return mfa
+}
+
 func HashPassword(password string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -45,20 +68,6 @@ func VerifyPassword(passhash string, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(passhash), []byte(password))
 }
 
-func GenerateJWT(id int, role string, is_verified string, dp depedency.Depend) string {
-	var informasi = jwt.MapClaims{}
-	informasi["id"] = id
-	informasi["role"] = role
-	informasi["verified"] = is_verified
-	rawToken := jwt.NewWithClaims(jwt.SigningMethodHS256, informasi)
-	resultToken, err := rawToken.SignedString([]byte(dp.Config.JwtSecret))
-	if err != nil {
-		log.Println("generate jwt error ", err.Error())
-		return ""
-	}
-	return resultToken
-}
-
 func GenerateEndTime(timee string, duration float32) string {
 	t, err := time.Parse("2006-01-02 15:04:05", strings.Replace(timee, "T", " ", 1))
 	if err != nil {
@@ -0,0 +1,25 @@
+package helper
+
+import (
+	"context"
+	"time"
+)
+
+// Every calls job on every interval until the context is cancelled, the interval is configured in
+// minutes and fallback is used when it is not set.
+func Every(ctx context.Context, minutes int, fallback time.Duration, job func(ctx context.Context, now time.Time)) {
+	interval := fallback
+	if minutes > 0 {
+		interval = time.Duration(minutes) * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			job(ctx, now)
+		}
+	}
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/education-hub/BE/app/admission"
+	userserv "github.com/education-hub/BE/app/features/user/service"
 	"github.com/education-hub/BE/app/routes"
 	"github.com/education-hub/BE/config/dependency"
 	"github.com/education-hub/BE/config/dependency/container"
@@ -15,11 +18,14 @@ import (
 
 func main() {
 	container.RunAll()
-	err := container.Container.Invoke(func(depend dependency.Depend, ro routes.Routes) {
+	err := container.Container.Invoke(func(depend dependency.Depend, ro routes.Routes, sweeper *admission.Sweeper, eraser *userserv.Eraser) {
 		db.Migrate(depend.Config)
 		var sig = make(chan os.Signal, 1)
 		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 		ro.RegisterRoutes()
+		ctx, cancel := context.WithCancel(context.Background())
+		go sweeper.Run(ctx)
+		go eraser.Run(ctx)
 		go func() {
 			depend.Log.Infof("Starting server on port %s", depend.Config.Server.Port)
 			if err := depend.Echo.Start(fmt.Sprintf(":%s", depend.Config.Server.Port)); err != nil {
@@ -28,6 +34,7 @@ func main() {
 			}
 		}()
 		<-sig
+		cancel()
 		depend.Nsq.Stop()
 		depend.Log.Info("Shutting down server")
 	})
@@ -1,13 +1,11 @@
 package pkg
 
 import (
+	"bytes"
 	"context"
-	"encoding/base64"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
-	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -22,13 +20,12 @@ type StorageGCP struct {
 }
 
 func (s *StorageGCP) UploadFile(file multipart.File, fileName string) error {
-	if !strings.Contains(strings.ToLower(fileName), ".jpg") && !strings.Contains(strings.ToLower(fileName), ".png") && !strings.Contains(strings.ToLower(fileName), ".jpeg") && !strings.Contains(strings.ToLower(fileName), ".pdf") {
-		fmt.Println(strings.Contains(strings.ToLower(fileName), ".jpg"))
-		return errorr.NewBad("File type not allowed")
+	if err := checkFileType(fileName); err != nil {
+		return err
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
-	wc := s.ClG.Bucket(s.BucketName).Object(s.Path + fileName).NewWriter(ctx)
+	wc := s.object(fileName).NewWriter(ctx)
 	if _, err := io.Copy(wc, file); err != nil {
 		return errorr.NewInternal(err.Error())
 	}
@@ -39,18 +36,48 @@ func (s *StorageGCP) UploadFile(file multipart.File, fileName string) error {
 	return nil
 }
 
+func (s *StorageGCP) WriteFile(fileName string, data []byte) error {
+	return s.UploadFile(nopCloser{bytes.NewReader(data)}, fileName)
+}
+
+// nopCloser lets a generated file go through UploadFile like an uploaded one.
+type nopCloser struct {
+	*bytes.Reader
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
 func (s *StorageGCP) GetFile(filename string) (string, error) {
+	data, err := s.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	return encodeFile(data), nil
+}
+
+func (s *StorageGCP) ReadFile(filename string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*25)
 	defer cancel()
-	rc, err := s.ClG.Bucket(s.BucketName).Object(filename).NewReader(ctx)
+	rc, err := s.object(filename).NewReader(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
 
-	data, err := ioutil.ReadAll(rc)
-	if err != nil {
-		return "", err
+func (s *StorageGCP) DeleteFile(filename string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if err := s.object(filename).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+		return errorr.NewInternal(err.Error())
 	}
-	return base64.StdEncoding.EncodeToString(data), nil
+	return nil
+}
+
+// object is where a file is kept in the bucket, every file lives under Path.
+func (s *StorageGCP) object(filename string) *storage.ObjectHandle {
+	return s.ClG.Bucket(s.BucketName).Object(s.Path + filename)
 }
@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/education-hub/BE/errorr"
+	"googlemaps.github.io/maps"
+)
+
+// earthRadius is the mean radius of the earth in kilometers.
+const earthRadius = 6371.0
+
+type (
+	// Coordinate is a point on the earth in decimal degrees.
+	Coordinate struct {
+		Lat float64 `json:"lat"`
+		Lng float64 `json:"lng"`
+	}
+	// Geocoder turns an address into a coordinate, it is implemented by Client and OfflineGeocoder.
+	Geocoder interface {
+		Geocode(ctx context.Context, address string) (*Coordinate, error)
+	}
+	// OfflineGeocoder resolves addresses from a fixed table, it is used in tests and
+	// when no Google Maps key is configured.
+	OfflineGeocoder struct {
+		Points map[string]Coordinate
+	}
+)
+
+func (c *Client) Geocode(ctx context.Context, address string) (*Coordinate, error) {
+	resp, err := c.Client.Geocode(ctx, &maps.GeocodingRequest{Address: address, Region: "id", Language: "id"})
+	if err != nil {
+		c.Log.Errorf("[ERROR]WHEN GEOCODING ADDRESS, Err: %v", err)
+		return nil, errorr.NewInternal("Internal Server Error")
+	}
+	if len(resp) == 0 {
+		return nil, errorr.NewBad("Address not found")
+	}
+	return &Coordinate{Lat: resp[0].Geometry.Location.Lat, Lng: resp[0].Geometry.Location.Lng}, nil
+}
+
+func (o *OfflineGeocoder) Geocode(ctx context.Context, address string) (*Coordinate, error) {
+	if point, ok := o.Points[strings.ToLower(strings.TrimSpace(address))]; ok {
+		return &point, nil
+	}
+	return nil, errorr.NewBad("Address not found")
+}
+
+// Distance returns the straight-line (great-circle) distance between two coordinates in kilometers.
+func Distance(a, b Coordinate) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dlat := (b.Lat - a.Lat) * math.Pi / 180
+	dlng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Sin(dlat/2)*math.Sin(dlat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dlng/2)*math.Sin(dlng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// Address joins the parts of an address from the most to the least specific one, skipping the empty parts.
+func Address(parts ...string) string {
+	res := []string{}
+	for _, val := range parts {
+		if strings.TrimSpace(val) != "" {
+			res = append(res, strings.TrimSpace(val))
+		}
+	}
+	return strings.Join(res, ", ")
+}
@@ -12,37 +12,58 @@ type NSQProducer struct {
 }
 
 func (np *NSQProducer) Publish(Topic string, message []byte) error {
+	topic, err := np.topic(Topic)
+	if err != nil {
+		return err
+	}
+	return np.Producer.Publish(topic, message)
+}
+
+// MultiPublish sends several messages to the same topic in a single round trip.
+func (np *NSQProducer) MultiPublish(Topic string, messages [][]byte) error {
+	topic, err := np.topic(Topic)
+	if err != nil {
+		return err
+	}
+	return np.Producer.MultiPublish(topic, messages)
+}
+
+func (np *NSQProducer) topic(Topic string) (string, error) {
 	switch Topic {
 	case "1":
-		return np.Producer.Publish(np.Env.Topic, message)
+		return np.Env.Topic, nil
 	case "2":
-		return np.Producer.Publish(np.Env.Topic2, message)
+		return np.Env.Topic2, nil
 	case "3":
-		return np.Producer.Publish(np.Env.Topic3, message)
+		return np.Env.Topic3, nil
 	case "4":
-		return np.Producer.Publish(np.Env.Topic4, message)
+		return np.Env.Topic4, nil
 	case "5":
-		return np.Producer.Publish(np.Env.Topic5, message)
+		return np.Env.Topic5, nil
 	case "6":
-		return np.Producer.Publish(np.Env.Topic6, message)
+		return np.Env.Topic6, nil
 	case "7":
-		return np.Producer.Publish(np.Env.Topic7, message)
+		return np.Env.Topic7, nil
 	case "8":
-		return np.Producer.Publish(np.Env.Topic8, message)
+		return np.Env.Topic8, nil
 	case "9":
-		return np.Producer.Publish(np.Env.Topic9, message)
+		return np.Env.Topic9, nil
 	case "10":
-		return np.Producer.Publish(np.Env.Topic10, message)
+		return np.Env.Topic10, nil
 	case "11":
-		return np.Producer.Publish(np.Env.Topic11, message)
+		return np.Env.Topic11, nil
 	case "12":
-		return np.Producer.Publish(np.Env.Topic12, message)
+		return np.Env.Topic12, nil
 	case "13":
-		return np.Producer.Publish(np.Env.Topic13, message)
+		return np.Env.Topic13, nil
 	case "14":
-		return np.Producer.Publish(np.Env.Topic14, message)
+		return np.Env.Topic14, nil
+	case "15":
+		return np.Env.Topic15, nil
+	case "16":
+		return np.Env.Topic16, nil
 	}
-	return errorr.NewBad("Topic not available")
+	return "", errorr.NewBad("Topic not available")
 }
 
 func (np *NSQProducer) Stop() {
@@ -0,0 +1,165 @@
+package pkg
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+)
+
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// PDF renders simple A4 documents with the standard Helvetica fonts, lines and images, so the
+// printable forms do not need an external renderer. Coordinates are points from the top left corner.
+type PDF struct {
+	pages  []*bytes.Buffer
+	images [][]byte
+}
+
+func NewPDF() *PDF {
+	p := &PDF{}
+	p.AddPage()
+	return p
+}
+
+func (p *PDF) AddPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+}
+
+func (p *PDF) page() *bytes.Buffer {
+	return p.pages[len(p.pages)-1]
+}
+
+// Text writes a single line, y is the top of the line.
+func (p *PDF) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(p.page(), "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PageHeight-y-size*0.8, escapeText(text))
+}
+
+// TextBox writes a text wrapped on the words to fit the width and returns the y below the last line.
+func (p *PDF) TextBox(x, y, width, size float64, text string) float64 {
+	// Helvetica is about half as wide as it is high on average.
+	limit := int(width / (size * 0.5))
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > limit {
+			p.Text(x, y, size, false, line)
+			y += size * 1.4
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	p.Text(x, y, size, false, line)
+	return y + size*1.4
+}
+
+func (p *PDF) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(p.page(), "%.2f %.2f m %.2f %.2f l S\n", x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+func (p *PDF) Rect(x, y, w, h float64) {
+	fmt.Fprintf(p.page(), "%.2f %.2f %.2f %.2f re S\n", x, PageHeight-y-h, w, h)
+}
+
+// Image draws the image stretched into the box, transparent pixels are drawn white.
+func (p *PDF) Image(img image.Image, x, y, w, h float64) error {
+	bounds := img.Bounds()
+	raw := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for py := bounds.Min.Y; py < bounds.Max.Y; py++ {
+		for px := bounds.Min.X; px < bounds.Max.X; px++ {
+			r, g, b, a := img.At(px, py).RGBA()
+			raw = append(raw, byte((r+0xffff-a)>>8), byte((g+0xffff-a)>>8), byte((b+0xffff-a)>>8))
+		}
+	}
+	buf := &bytes.Buffer{}
+	zw := zlib.NewWriter(buf)
+	if _, err := zw.Write(raw); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	obj := &bytes.Buffer{}
+	fmt.Fprintf(obj, "<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n", bounds.Dx(), bounds.Dy(), buf.Len())
+	obj.Write(buf.Bytes())
+	obj.WriteString("\nendstream")
+	p.images = append(p.images, obj.Bytes())
+	fmt.Fprintf(p.page(), "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", w, h, x, PageHeight-y-h, len(p.images))
+	return nil
+}
+
+// Bytes assembles the document, the objects are the catalog, the page tree, both fonts,
+// the images and then every page followed by its content.
+func (p *PDF) Bytes() []byte {
+	objects := [][]byte{nil, nil,
+		[]byte("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>"),
+		[]byte("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>"),
+	}
+	objects = append(objects, p.images...)
+	resources := "<< /Font << /F1 3 0 R /F2 4 0 R >> /XObject << "
+	for i := range p.images {
+		resources += fmt.Sprintf("/Im%d %d 0 R ", i+1, i+5)
+	}
+	resources += ">> >>"
+	kids := []string{}
+	for _, content := range p.pages {
+		id := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", id))
+		objects = append(objects,
+			[]byte(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources %s /Contents %d 0 R >>", PageWidth, PageHeight, resources, id+1)),
+			[]byte(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String())),
+		)
+	}
+	objects[0] = []byte("<< /Type /Catalog /Pages 2 0 R >>")
+	objects[1] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+
+	res := &bytes.Buffer{}
+	res.WriteString("%PDF-1.4\n")
+	offsets := []int{}
+	for i, obj := range objects {
+		offsets = append(offsets, res.Len())
+		fmt.Fprintf(res, "%d 0 obj\n", i+1)
+		res.Write(obj)
+		res.WriteString("\nendobj\n")
+	}
+	xref := res.Len()
+	fmt.Fprintf(res, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(res, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(res, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return res.Bytes()
+}
+
+// escapeText encodes the text in WinAnsi for the standard fonts, characters outside of it become "?".
+func escapeText(text string) string {
+	res := &strings.Builder{}
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			res.WriteByte('\\')
+			res.WriteRune(r)
+		case r < 32:
+			res.WriteByte(' ')
+		case r < 127:
+			res.WriteRune(r)
+		case r >= 160 && r <= 255:
+			res.WriteByte(byte(r))
+		default:
+			res.WriteByte('?')
+		}
+	}
+	return res.String()
+}
@@ -6,19 +6,53 @@ import (
 	"github.com/pusher/pusher-http-go"
 )
 
+// maxBatchEvents is the number of events Pusher accepts in a single batch request.
+const maxBatchEvents = 10
+
 type Pusher struct {
 	Client *pusher.Client
 	Env    config.PusherConfig
 }
 
 func (p *Pusher) Publish(data any, event int) error {
+	name, err := p.event(event)
+	if err != nil {
+		return err
+	}
+	return p.Client.Trigger(p.Env.Channel, name, data)
+}
+
+// PublishBatch triggers the same event once for every data, using as few requests as possible.
+func (p *Pusher) PublishBatch(data []any, event int) error {
+	name, err := p.event(event)
+	if err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		size := len(data)
+		if size > maxBatchEvents {
+			size = maxBatchEvents
+		}
+		batch := []pusher.Event{}
+		for _, val := range data[:size] {
+			batch = append(batch, pusher.Event{Channel: p.Env.Channel, Name: name, Data: val})
+		}
+		if err := p.Client.TriggerBatch(batch); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+	return nil
+}
+
+func (p *Pusher) event(event int) (string, error) {
 	switch event {
 	case 1:
-		return p.Client.Trigger(p.Env.Channel, p.Env.Event1, data)
+		return p.Env.Event1, nil
 	case 2:
-		return p.Client.Trigger(p.Env.Channel, p.Env.Event2, data)
+		return p.Env.Event2, nil
 	case 3:
-		return p.Client.Trigger(p.Env.Channel, p.Env.Event3, data)
+		return p.Env.Event3, nil
 	}
-	return errorr.NewBad("Event Not Available")
+	return "", errorr.NewBad("Event Not Available")
 }
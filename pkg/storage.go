@@ -0,0 +1,100 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/education-hub/BE/errorr"
+)
+
+type (
+	// Storage keeps the uploaded files, it is implemented by StorageGCP and LocalStorage.
+	Storage interface {
+		UploadFile(file multipart.File, fileName string) error
+		// WriteFile stores a file generated by the server.
+		WriteFile(fileName string, data []byte) error
+		// GetFile returns the content of a file encoded in base64.
+		GetFile(filename string) (string, error)
+		ReadFile(filename string) ([]byte, error)
+		// DeleteFile removes a stored file, a file already gone is not an error.
+		DeleteFile(filename string) error
+	}
+	// LocalStorage keeps the files in a directory, it is used in development and in tests.
+	LocalStorage struct {
+		Dir string
+	}
+)
+
+var allowedFiles = []string{".jpg", ".png", ".jpeg", ".pdf"}
+
+func checkFileType(fileName string) error {
+	for _, val := range allowedFiles {
+		if strings.Contains(strings.ToLower(fileName), val) {
+			return nil
+		}
+	}
+	return errorr.NewBad("File type not allowed")
+}
+
+func (s *LocalStorage) UploadFile(file multipart.File, fileName string) error {
+	if err := checkFileType(fileName); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return errorr.NewInternal(err.Error())
+	}
+	dst, err := os.Create(s.path(fileName))
+	if err != nil {
+		return errorr.NewInternal(err.Error())
+	}
+	defer dst.Close()
+	if _, err := io.Copy(dst, file); err != nil {
+		return errorr.NewInternal(err.Error())
+	}
+	return nil
+}
+
+func (s *LocalStorage) WriteFile(fileName string, data []byte) error {
+	if err := checkFileType(fileName); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return errorr.NewInternal(err.Error())
+	}
+	if err := os.WriteFile(s.path(fileName), data, 0o644); err != nil {
+		return errorr.NewInternal(err.Error())
+	}
+	return nil
+}
+
+func (s *LocalStorage) GetFile(filename string) (string, error) {
+	data, err := s.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	return encodeFile(data), nil
+}
+
+func (s *LocalStorage) ReadFile(filename string) ([]byte, error) {
+	return os.ReadFile(s.path(filename))
+}
+
+func (s *LocalStorage) DeleteFile(filename string) error {
+	if err := os.Remove(s.path(filename)); err != nil && !os.IsNotExist(err) {
+		return errorr.NewInternal(err.Error())
+	}
+	return nil
+}
+
+// path keeps the file inside the storage directory whatever the name holds.
+func (s *LocalStorage) path(filename string) string {
+	return filepath.Join(s.Dir, filepath.Base(filename))
+}
+
+func encodeFile(data []byte) string {
+	return base64.StdEncoding.EncodeToString(data)
+}